@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/fchimpan/gh-slimify/internal/git"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// checkBaseRef exits if --base-ref is combined with a mode that doesn't scan
+// the workflows of a local checkout.
+func checkBaseRef() {
+	if baseRef == "" {
+		return
+	}
+	var conflict string
+	switch {
+	case readStdin:
+		conflict = "--stdin"
+	case remoteRepo != "":
+		conflict = "--repo"
+	case watch:
+		conflict = "--watch"
+	default:
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: --base-ref cannot be combined with %s\n", conflict)
+	os.Exit(exitError)
+}
+
+// filterBaseRef drops the candidates that were already candidates at
+// --base-ref, so that only the ones introduced since are reported.
+func filterBaseRef(result *scan.ScanResult, paths []string) {
+	if baseRef == "" {
+		return
+	}
+	base, err := scanBaseRef(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --base-ref %s: %v\n", baseRef, err)
+		os.Exit(exitError)
+	}
+	removed := result.FilterExisting(base)
+	if removed > 0 && outputFormat == formatText && outputVerbosity() > verbosityQuiet {
+		fmt.Fprintf(os.Stderr, "ℹ️  %d candidate(s) already in %s are not shown\n", removed, baseRef)
+	}
+}
+
+// scanBaseRef scans the workflows in paths, or in the workflow directories if
+// paths is empty, as they are at --base-ref. The files are read with git into
+// a temporary directory laid out like the working tree, with the .github
+// directory for local actions, and scanned there with .slimify.yml and
+// .slimifyignore of the working tree, so that both scans follow the same rules.
+// Durations are not fetched; only which jobs are candidates matters.
+func scanBaseRef(paths []string) (*scan.ScanResult, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are read from git relative to the current directory, and given
+	// back in the results as they were given, so they match the scan of the
+	// working tree
+	paths = slices.Clone(paths)
+	given := make(map[string]string, len(paths))
+	for i, path := range paths {
+		if filepath.IsAbs(path) {
+			rel, err := filepath.Rel(cwd, path)
+			if err != nil {
+				return nil, err
+			}
+			given[rel] = path
+			paths[i] = rel
+		}
+	}
+
+	roots := paths
+	if len(paths) == 0 {
+		roots = append([]string{workflow.DefaultWorkflowDir}, workflowDirs...)
+	}
+	files, err := git.ListFiles(baseRef, append([]string{".github"}, roots...)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		// A base without workflows, such as before the first one was added,
+		// had no candidates
+		rootFiles, err := git.ListFiles(baseRef, roots...)
+		if err != nil {
+			return nil, err
+		}
+		if len(rootFiles) == 0 {
+			return &scan.ScanResult{}, nil
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "gh-slimify-base-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, f := range files {
+		content, err := git.ReadBlob(f.Object)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range []string{scan.IgnoreFileName, scan.ConfigFileName} {
+		content, err := os.ReadFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	// Workflow files given explicitly may not exist yet at the base, and the
+	// workflow directories may be empty
+	var existing []string
+	for _, path := range paths {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	if len(paths) > 0 && len(existing) == 0 {
+		return &scan.ScanResult{}, nil
+	}
+	for _, root := range append([]string{workflow.DefaultWorkflowDir}, workflowDirs...) {
+		if err := os.MkdirAll(filepath.Join(dir, root), 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		return nil, fmt.Errorf("failed to change directory: %w", err)
+	}
+	defer os.Chdir(cwd)
+
+	opts := scanOptions(existing, nil)
+	opts.SkipDuration = true
+	opts.Cache = nil
+	opts.Timing = nil
+	result, err := scan.Scan(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range result.AllCandidates() {
+		if path, ok := given[c.WorkflowPath]; ok {
+			c.WorkflowPath = path
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanBaseRef_MissingWorkflowDirectory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	const content = `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+
+	tests := []struct {
+		name           string
+		dirs           []string
+		baseFiles      []string // Workflows committed at the base
+		wantCandidates int
+	}{
+		{
+			name:           "no workflows at the base",
+			wantCandidates: 0,
+		},
+		{
+			name:           "workflows only in an additional directory",
+			dirs:           []string{"ci/workflows"},
+			baseFiles:      []string{"ci/workflows/ci.yml"},
+			wantCandidates: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			git := func(args ...string) {
+				t.Helper()
+				if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+					t.Fatalf("git %v: %v\n%s", args, err, out)
+				}
+			}
+			writeFile := func(path string) {
+				t.Helper()
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			git("init", "--initial-branch=main")
+			git("config", "user.name", "test")
+			git("config", "user.email", "test@example.com")
+			git("config", "commit.gpgsign", "false")
+			writeFile("README.md")
+			for _, path := range tt.baseFiles {
+				writeFile(path)
+			}
+			git("add", ".")
+			git("commit", "-m", "initial")
+
+			// The change under review adds the first workflow of .github/workflows
+			writeFile(".github/workflows/ci.yml")
+
+			prevBaseRef, prevDirs := baseRef, workflowDirs
+			baseRef, workflowDirs = "HEAD", tt.dirs
+			t.Cleanup(func() { baseRef, workflowDirs = prevBaseRef, prevDirs })
+
+			result, err := scanBaseRef(nil)
+			if err != nil {
+				t.Fatalf("scanBaseRef() error: %v", err)
+			}
+			if got := len(result.AllCandidates()); got != tt.wantCandidates {
+				t.Errorf("scanBaseRef() found %d candidates, want %d", got, tt.wantCandidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// scanCache returns the cache of scan results, or nil with --no-cache.
+// Results are cached in .git/slimify-cache when run at the root of a git
+// repository, and in a directory under the system temp dir otherwise.
+func scanCache() *scan.Cache {
+	if noCache {
+		return nil
+	}
+	dir := filepath.Join(os.TempDir(), "gh-slimify-cache")
+	if info, err := os.Stat(".git"); err == nil && info.IsDir() {
+		dir = filepath.Join(".git", "slimify-cache")
+	}
+	return scan.NewCache(dir, buildVersion())
+}
+
+// buildVersion identifies the running build of slimify: its module version,
+// plus the VCS revision for builds from a checkout, so that cache entries
+// written by another build are not used.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision", "vcs.modified":
+			version += " " + setting.Key + "=" + setting.Value
+		}
+	}
+	return version
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+	"os"
+)
+
+// ANSI escape sequences used to style text output.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// palette styles text output. The zero value leaves text unchanged, so the
+// content is identical with and without color.
+type palette struct {
+	enabled bool
+}
+
+// newPalette returns a palette for w. Color is used only when w is a terminal,
+// --no-color is not set and the NO_COLOR environment variable is empty
+// (see https://no-color.org).
+func newPalette(w io.Writer) palette {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return palette{}
+	}
+	f, ok := w.(*os.File)
+	return palette{enabled: ok && isTerminal(f)}
+}
+
+func (p palette) style(code, s string) string {
+	if !p.enabled {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func (p palette) bold(s string) string   { return p.style(ansiBold, s) }
+func (p palette) red(s string) string    { return p.style(ansiRed, s) }
+func (p palette) green(s string) string  { return p.style(ansiGreen, s) }
+func (p palette) yellow(s string) string { return p.style(ansiYellow, s) }
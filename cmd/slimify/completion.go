@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// completionShells are the shells completion scripts can be generated for.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// confidenceNames are the values accepted by --min-confidence.
+var confidenceNames = []string{string(scan.ConfidenceLow), string(scan.ConfidenceMedium), string(scan.ConfidenceHigh)}
+
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <shell>",
+		Short: "Generate the shell completion script",
+		Long: `Print a completion script for bash, zsh, fish or powershell. It completes
+subcommands, flags, the values of flags such as --format, and workflow files.
+
+To load completions in the current bash session:
+
+  source <(gh slimify completion bash)
+
+Add the same line to ~/.bashrc (or the equivalent for your shell) to load
+them in every session.`,
+		Example:   "  gh slimify completion zsh > \"${fpath[1]}/_slimify\"",
+		ValidArgs: completionShells,
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE:      runCompletion,
+	}
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	root := cmd.Root()
+	w := cmd.OutOrStdout()
+	switch args[0] {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	default:
+		return root.GenPowerShellCompletionWithDesc(w)
+	}
+}
+
+func newManCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "man [directory]",
+		Short: "Generate man pages",
+		Long: `Write a man page for slimify and each of its subcommands (slimify.1,
+slimify-fix.1, ...) to directory, or to the current directory if not given.
+The directory is created if it doesn't exist.`,
+		Example: "  gh slimify man /usr/local/share/man/man1",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runMan,
+	}
+}
+
+func runMan(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create man page directory: %w", err)
+	}
+
+	root := cmd.Root()
+	// Keep the pages reproducible; the date would change them on every run
+	root.DisableAutoGenTag = true
+	header := &doc.GenManHeader{
+		Title:   "SLIMIFY",
+		Section: "1",
+		Source:  "gh-slimify",
+		Manual:  "GitHub CLI extension manual",
+	}
+	if err := doc.GenManTree(root, header, dir); err != nil {
+		return fmt.Errorf("failed to generate man pages: %w", err)
+	}
+	return nil
+}
+
+// registerCompletions registers completions for the values of enum flags and
+// for workflow file arguments.
+func registerCompletions(rootCmd, fixCmd, reviewCmd, statsCmd, explainCmd *cobra.Command) {
+	fixed := func(values []string) cobra.CompletionFunc {
+		return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+	}
+	_ = rootCmd.RegisterFlagCompletionFunc("format", fixed(outputFormats))
+	_ = rootCmd.RegisterFlagCompletionFunc("log-level", fixed(logLevels))
+	_ = rootCmd.RegisterFlagCompletionFunc("group-by", fixed(groupByValues))
+	_ = rootCmd.RegisterFlagCompletionFunc("min-confidence", fixed(confidenceNames))
+	_ = fixCmd.RegisterFlagCompletionFunc("min-confidence", fixed(confidenceNames))
+	_ = reviewCmd.RegisterFlagCompletionFunc("min-confidence", fixed(confidenceNames))
+
+	rootCmd.ValidArgsFunction = completeWorkflowFiles
+	fixCmd.ValidArgsFunction = completeWorkflowFiles
+	reviewCmd.ValidArgsFunction = completeWorkflowFiles
+	statsCmd.ValidArgsFunction = completeWorkflowFiles
+	explainCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			// The job ID can't be completed without parsing the workflow
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeWorkflowFiles(cmd, args, toComplete)
+	}
+}
+
+// completeWorkflowFiles completes workflow file arguments with YAML files.
+func completeWorkflowFiles(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{"yml", "yaml"}, cobra.ShellCompDirectiveFilterFileExt
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := newRootCmd()
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"completion", shell})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			if !strings.Contains(out.String(), "slimify") {
+				t.Errorf("completion %s printed no script for slimify:\n%s", shell, out.String())
+			}
+		})
+	}
+
+	t.Run("enum flag values", func(t *testing.T) {
+		var out bytes.Buffer
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"__complete", "--format", ""})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error: %v", err)
+		}
+		for _, format := range outputFormats {
+			if !strings.Contains(out.String(), format+"\n") {
+				t.Errorf("--format completions = %q, want %s", out.String(), format)
+			}
+		}
+	})
+}
+
+func TestMan(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man1")
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"man", dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	for _, page := range []string{"slimify.1", "slimify-fix.1", "slimify-stats.1"} {
+		data, err := os.ReadFile(filepath.Join(dir, page))
+		if err != nil {
+			t.Fatalf("man page %s not written: %v", page, err)
+		}
+		if !strings.Contains(string(data), ".TH \"SLIMIFY\"") {
+			t.Errorf("%s has no SLIMIFY title header", page)
+		}
+	}
+}
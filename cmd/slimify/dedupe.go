@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// repeatedCandidates returns the groups of candidates repeated in several
+// workflows with --dedupe, listed once by printRepeatedCandidates, and the
+// remaining candidates to list as usual.
+func repeatedCandidates(candidates []*scan.Candidate) ([]*scan.CandidateGroup, []*scan.Candidate) {
+	if !dedupe {
+		return nil, candidates
+	}
+	groups := scan.RepeatedCandidates(candidates)
+	grouped := make(map[*scan.Candidate]bool)
+	for _, g := range groups {
+		for _, c := range g.Candidates {
+			grouped[c] = true
+		}
+	}
+	var rest []*scan.Candidate
+	for _, c := range candidates {
+		if !grouped[c] {
+			rest = append(rest, c)
+		}
+	}
+	return groups, rest
+}
+
+// printRepeatedCandidates lists each group of repeated candidates once, with
+// the number of workflows it appears in. Only the first location is shown
+// unless verbose, since they are copies of the same job.
+func printRepeatedCandidates(w io.Writer, groups []*scan.CandidateGroup, level verbosity) {
+	if len(groups) == 0 {
+		return
+	}
+	p := newPalette(w)
+	fmt.Fprintf(w, "\n🔁 Repeated in several workflows (%d pattern(s)):\n", len(groups))
+	for _, g := range groups {
+		first := g.Candidates[0]
+		_, warningJobs := classifyCandidates(g.Candidates)
+		name := p.green(quoted(first.JobName))
+		status := "safe to migrate"
+		if len(warningJobs) > 0 {
+			name = p.yellow(quoted(first.JobName))
+			status = "can migrate but requires attention"
+		}
+		fmt.Fprintf(w, "   • %s appears in %d workflows (%s)\n", name, g.Workflows(), status)
+		if level == verbosityVerbose {
+			for _, c := range g.Candidates {
+				fmt.Fprintf(w, "     %s\n", formatLocalLink(c.WorkflowPath, c.LineNumber))
+			}
+			continue
+		}
+		fmt.Fprintf(w, "     %s", formatLocalLink(first.WorkflowPath, first.LineNumber))
+		if more := len(g.Candidates) - 1; more > 0 {
+			fmt.Fprintf(w, " and %d more (use --verbose to see them)", more)
+		}
+		fmt.Fprintln(w)
+	}
+}
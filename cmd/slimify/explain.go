@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/spf13/cobra"
+)
+
+// JSON output types for explain command
+type explainJSON struct {
+	WorkflowPath     string             `json:"workflow_path"`
+	JobID            string             `json:"job_id"`
+	JobName          string             `json:"job_name"`
+	LineNumber       int                `json:"line_number"`
+	RunsOn           []string           `json:"runs_on"`
+	IgnoreRule       string             `json:"ignore_rule,omitempty"`
+	ReusableWorkflow string             `json:"reusable_workflow,omitempty"`
+	OtherOS          []string           `json:"other_os,omitempty"`
+	RunsOnVariables  []string           `json:"runs_on_variables,omitempty"`
+	Checks           []explainCheckJSON `json:"checks"`
+	MissingCommands  []string           `json:"missing_commands"`
+	Verdict          string             `json:"verdict"`
+}
+
+type explainCheckJSON struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func runExplain(cmd *cobra.Command, args []string) {
+	if outputFormat == formatSARIF || outputFormat == formatGitHub || outputFormat == formatMarkdown || outputFormat == formatCSV || outputFormat == formatDOT || outputFormat == formatJSONSummary {
+		fmt.Fprintf(os.Stderr, "Error: explain does not support --format %s\n", outputFormat)
+		os.Exit(exitError)
+	}
+
+	explanation, err := scan.Explain(args[0], args[1], scanOptions(nil, nil))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if jsonOutput {
+		printExplainJSON(explanation)
+		return
+	}
+	printExplainText(explanation)
+}
+
+// explainVerdict returns the classification of an explained job, using the
+// same status names as the JSON scan output.
+func explainVerdict(e *scan.Explanation) string {
+	switch {
+	case e.IgnoreRule != "":
+		return "ignored"
+	case e.ReusableWorkflow != "":
+		return "reusable_workflow"
+	case len(e.UnresolvedVariables) > 0:
+		return "unresolved_runs_on"
+	case e.AlreadySlim:
+		return "already_slim"
+	case len(e.OtherOS) > 0:
+		return "other_os"
+	case !e.Eligible:
+		return "ineligible"
+	case len(e.MissingCommands) > 0:
+		return "warning"
+	default:
+		return "safe"
+	}
+}
+
+func printExplainJSON(e *scan.Explanation) {
+	output := explainJSON{
+		WorkflowPath:     e.WorkflowPath,
+		JobID:            e.JobID,
+		JobName:          e.JobName,
+		LineNumber:       e.LineNumber,
+		RunsOn:           e.RunsOn,
+		IgnoreRule:       e.IgnoreRule,
+		ReusableWorkflow: e.ReusableWorkflow,
+		OtherOS:          e.OtherOS,
+		RunsOnVariables:  e.UnresolvedVariables,
+		Checks:           []explainCheckJSON{},
+		MissingCommands:  e.MissingCommands,
+		Verdict:          explainVerdict(e),
+	}
+	if output.RunsOn == nil {
+		output.RunsOn = []string{}
+	}
+	if output.MissingCommands == nil {
+		output.MissingCommands = []string{}
+	}
+	for _, check := range e.Checks {
+		output.Checks = append(output.Checks, explainCheckJSON{
+			Name:   check.Name,
+			Passed: check.Passed,
+			Reason: check.Reason,
+		})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(output)
+}
+
+func printExplainText(e *scan.Explanation) {
+	p := newPalette(os.Stdout)
+
+	fmt.Printf("📄 %s\n", p.bold(formatLocalLink(e.WorkflowPath, e.LineNumber)))
+	fmt.Printf("🔍 Job %s (ID: %s)\n", quoted(e.JobName), e.JobID)
+	runsOn := "not set"
+	if len(e.RunsOn) > 0 {
+		runsOn = strings.Join(e.RunsOn, ", ")
+	}
+	fmt.Printf("   runs-on: %s\n", runsOn)
+	if e.ReusableWorkflow != "" {
+		fmt.Printf("   uses: %s\n", e.ReusableWorkflow)
+	}
+
+	if len(e.Checks) > 0 {
+		fmt.Println()
+		fmt.Println("Checks:")
+		for _, check := range e.Checks {
+			if check.Passed {
+				fmt.Printf("  ✅ %s\n", p.green(check.Name))
+			} else {
+				fmt.Printf("  ❌ %s: %s\n", p.red(check.Name), check.Reason)
+			}
+		}
+		if len(e.MissingCommands) > 0 {
+			fmt.Printf("  ⚠️  %s: %s\n", p.yellow("commands missing in ubuntu-slim"), strings.Join(e.MissingCommands, ", "))
+		} else {
+			fmt.Printf("  ✅ %s\n", p.green("no commands missing in ubuntu-slim"))
+		}
+	}
+
+	fmt.Println()
+	switch explainVerdict(e) {
+	case "ignored":
+		fmt.Printf("Verdict: 🙈 ignored by %s rule %q\n", scan.IgnoreFileName, e.IgnoreRule)
+	case "reusable_workflow":
+		fmt.Println("Verdict: ℹ️  calls a reusable workflow; the scan evaluates the called jobs instead")
+	case "unresolved_runs_on":
+		fmt.Printf("Verdict: ❓ runs-on uses %s; cannot determine (use --resolve-var NAME=value)\n", strings.Join(e.UnresolvedVariables, ", "))
+	case "already_slim":
+		fmt.Printf("Verdict: ✨ %s\n", p.green("already using ubuntu-slim"))
+	case "other_os":
+		fmt.Printf("Verdict: 🖥️  runs on %s; ubuntu-slim is not applicable\n", strings.Join(e.OtherOS, ", "))
+	case "ineligible":
+		fmt.Printf("Verdict: ❌ %s\n", p.red("cannot migrate"))
+	case "warning":
+		fmt.Printf("Verdict: ⚠️  %s\n", p.yellow("can migrate but requires attention"))
+	default:
+		fmt.Printf("Verdict: ✅ %s\n", p.green("safe to migrate"))
+	}
+}
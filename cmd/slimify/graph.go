@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/report"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// needsGraphFormats lists the --format values supported with --needs-graph.
+var needsGraphFormats = []string{formatText, formatJSON, formatDOT}
+
+// graphStatusIcons mark the status of each job in the text graph.
+var graphStatusIcons = map[scan.GraphStatus]string{
+	scan.GraphEligible:   "✅",
+	scan.GraphIneligible: "❌",
+	scan.GraphSlim:       "✨",
+	scan.GraphOther:      "➖",
+}
+
+// JSON output types for --needs-graph
+type graphJobJSON struct {
+	JobID      string   `json:"job_id"`
+	JobName    string   `json:"job_name"`
+	LineNumber int      `json:"line_number"`
+	Status     string   `json:"status"`
+	Needs      []string `json:"needs"`
+	Dependents []string `json:"dependents"`
+	Leaf       bool     `json:"leaf"`
+}
+
+type graphWorkflowJSON struct {
+	WorkflowPath string         `json:"workflow_path"`
+	Jobs         []graphJobJSON `json:"jobs"`
+}
+
+type graphOutputJSON struct {
+	Workflows []graphWorkflowJSON `json:"workflows"`
+}
+
+// checkNeedsGraph exits if --format dot is set without --needs-graph, or
+// --needs-graph with a format it doesn't support.
+func checkNeedsGraph() {
+	if needsGraph {
+		if slices.Contains(needsGraphFormats, outputFormat) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: --needs-graph supports --format %s, not %s\n", strings.Join(needsGraphFormats, ", "), outputFormat)
+		os.Exit(exitError)
+	}
+	if outputFormat == formatDOT {
+		fmt.Fprintf(os.Stderr, "Error: --format dot requires --needs-graph\n")
+		os.Exit(exitError)
+	}
+}
+
+// writeNeedsGraph writes the needs: dependency graph of each scanned workflow
+// with --needs-graph, instead of the scan results, and exits with exitError if
+// some workflow files could not be parsed.
+func writeNeedsGraph(result *scan.ScanResult) {
+	graphs, err := scan.JobGraphs(result)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	prefixRemoteGraphPaths(graphs)
+
+	err = writeOutput(func(w io.Writer) error {
+		switch outputFormat {
+		case formatDOT:
+			return report.WriteDOT(w, graphs)
+		case formatJSON:
+			return printNeedsGraphJSON(w, graphs)
+		}
+		printNeedsGraphText(w, graphs)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	checkParseErrors(result, false)
+}
+
+// printNeedsGraphText lists the jobs of each workflow in line order with the
+// jobs they need and the jobs that need them. Leaf jobs, which no other job
+// needs, are marked as the lowest-risk ones to migrate.
+func printNeedsGraphText(w io.Writer, graphs []*scan.JobGraph) {
+	if len(graphs) == 0 {
+		fmt.Fprintln(w, "No jobs found.")
+		return
+	}
+	for i, g := range graphs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "📊 %s\n", g.WorkflowPath)
+		for _, j := range g.Jobs {
+			fmt.Fprintf(w, "  %s %q (L%d) %s", graphStatusIcons[j.Status], j.JobName, j.LineNumber, j.Status)
+			if len(j.Needs) > 0 {
+				fmt.Fprintf(w, "; needs %s", strings.Join(j.Needs, ", "))
+			}
+			if j.IsLeaf() {
+				fmt.Fprint(w, "; leaf")
+			} else {
+				fmt.Fprintf(w, "; needed by %s", strings.Join(j.Dependents, ", "))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func printNeedsGraphJSON(w io.Writer, graphs []*scan.JobGraph) error {
+	output := graphOutputJSON{Workflows: []graphWorkflowJSON{}}
+	for _, g := range graphs {
+		wf := graphWorkflowJSON{WorkflowPath: g.WorkflowPath, Jobs: []graphJobJSON{}}
+		for _, j := range g.Jobs {
+			job := graphJobJSON{
+				JobID:      j.JobID,
+				JobName:    j.JobName,
+				LineNumber: j.LineNumber,
+				Status:     string(j.Status),
+				Needs:      j.Needs,
+				Dependents: j.Dependents,
+				Leaf:       j.IsLeaf(),
+			}
+			if job.Needs == nil {
+				job.Needs = []string{}
+			}
+			if job.Dependents == nil {
+				job.Dependents = []string{}
+			}
+			wf.Jobs = append(wf.Jobs, job)
+		}
+		output.Workflows = append(output.Workflows, wf)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
+
+// prefixRemoteGraphPaths prefixes workflow paths in graphs with the repository
+// given by --repo, like prefixRemotePaths does for scan results.
+func prefixRemoteGraphPaths(graphs []*scan.JobGraph) {
+	if remoteRepo == "" {
+		return
+	}
+	_, owner, repo, err := api.ParseRepo(remoteRepo)
+	if err != nil {
+		return
+	}
+	for _, g := range graphs {
+		g.WorkflowPath = path.Join(owner, repo, filepath.ToSlash(g.WorkflowPath))
+	}
+}
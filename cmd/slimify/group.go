@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// Values accepted by --group-by.
+const (
+	groupByFile   = "file"
+	groupByReason = "reason"
+	groupByFlat   = "flat"
+)
+
+// groupByValues lists the values accepted by --group-by.
+var groupByValues = []string{groupByFile, groupByReason, groupByFlat}
+
+// checkGroupBy validates --group-by, exiting on an invalid value.
+func checkGroupBy() {
+	if !slices.Contains(groupByValues, groupBy) {
+		fmt.Fprintf(os.Stderr, "Error: invalid --group-by %q: must be one of %s\n", groupBy, strings.Join(groupByValues, ", "))
+		os.Exit(exitError)
+	}
+}
+
+// printScanByReason lists the jobs of a scan by why they can or cannot be
+// migrated: eligible jobs first, then ineligible jobs under each reason
+// category, most common first. A job blocked for several reasons is listed
+// under each of them. candidates are the eligible jobs to list.
+func printScanByReason(w io.Writer, result *scan.ScanResult, candidates []*scan.Candidate, level verbosity) {
+	p := newPalette(w)
+	safeJobs, warningJobs := classifyCandidates(candidates)
+
+	if len(safeJobs) > 0 {
+		fmt.Fprintf(w, "\n✅ %s\n", p.green(fmt.Sprintf("Safe to migrate (%d job(s)):", len(safeJobs))))
+		for _, job := range safeJobs {
+			fmt.Fprintf(w, "   • %s (L%d) - Last execution time: %s\n", p.green(quoted(job.JobName)), job.LineNumber, job.Duration)
+			if level == verbosityVerbose {
+				fmt.Fprintf(w, "     🎯 Confidence: %s\n", job.Confidence)
+			}
+			fmt.Fprintf(w, "     %s\n", formatLocalLink(job.WorkflowPath, job.LineNumber))
+		}
+	}
+
+	if len(warningJobs) > 0 {
+		fmt.Fprintf(w, "\n⚠️  %s\n", p.yellow(fmt.Sprintf("Can migrate but requires attention (%d job(s)):", len(warningJobs))))
+		for _, job := range warningJobs {
+			var reasons []string
+			if len(job.MissingCommands) > 0 {
+				reasons = append(reasons, "requires installing: "+strings.Join(job.MissingCommands, ", "))
+			}
+			if job.MayIncreaseRuntime() {
+				reasons = append(reasons, describeManyInstalls(job))
+			}
+			if job.Duration == "" || job.Duration == "unknown" {
+				reasons = append(reasons, "Last execution time: unknown"+estimatedDuration(job))
+			}
+			fmt.Fprintf(w, "   • %s (L%d) - %s\n", p.yellow(quoted(job.JobName)), job.LineNumber, strings.Join(reasons, "; "))
+			if level == verbosityVerbose {
+				fmt.Fprintf(w, "     🎯 Confidence: %s\n", job.Confidence)
+			}
+			fmt.Fprintf(w, "     %s\n", formatLocalLink(job.WorkflowPath, job.LineNumber))
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, job := range result.IneligibleJobs {
+		for category := range job.ReasonsByCategory() {
+			counts[category]++
+		}
+	}
+	for _, category := range sortedCategories(counts) {
+		fmt.Fprintf(w, "\n❌ %s\n", p.red(fmt.Sprintf("Blocked by %s (%d job(s)):", reasonLabels[category], counts[category])))
+		for _, job := range result.IneligibleJobs {
+			reasons := job.ReasonsByCategory()[category]
+			if len(reasons) == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "   • %s (L%d) - %s\n", p.red(quoted(job.JobName)), job.LineNumber, strings.Join(reasons, "; "))
+			fmt.Fprintf(w, "     %s\n", formatLocalLink(job.WorkflowPath, job.LineNumber))
+		}
+	}
+}
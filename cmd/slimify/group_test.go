@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+func TestPrintScanText_GroupBy(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: "ci.yml", JobID: "lint", JobName: "lint", LineNumber: 5, Duration: "2m"},
+		},
+		IneligibleJobs: []*scan.IneligibleJob{
+			{WorkflowPath: "ci.yml", JobID: "build", JobName: "build", LineNumber: 9, Reasons: []string{"uses Docker commands in step 1", "requires services: db"}},
+			{WorkflowPath: "release.yml", JobID: "image", JobName: "image", LineNumber: 4, Reasons: []string{"uses Docker commands in step 2"}},
+		},
+	}
+
+	t.Cleanup(func() { groupBy = groupByFile })
+
+	t.Run("reason", func(t *testing.T) {
+		groupBy = groupByReason
+		var out bytes.Buffer
+		printScanText(&out, result, verbosityNormal)
+
+		got := out.String()
+		for _, want := range []string{
+			"Safe to migrate (1 job(s)):",
+			"Blocked by Docker commands (2 job(s)):",
+			"Blocked by service containers (1 job(s)):",
+			"release.yml:4",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("output does not contain %q:\n%s", want, got)
+			}
+		}
+		if docker, services := strings.Index(got, "Docker commands"), strings.Index(got, "service containers"); docker > services {
+			t.Errorf("most common reason is not listed first:\n%s", got)
+		}
+		if strings.Contains(got, "📄") {
+			t.Errorf("output is grouped by file:\n%s", got)
+		}
+	})
+
+	t.Run("flat", func(t *testing.T) {
+		groupBy = groupByFlat
+		var out bytes.Buffer
+		printScanText(&out, result, verbosityVerbose)
+
+		got := out.String()
+		if strings.Contains(got, "📄") {
+			t.Errorf("output is grouped by file:\n%s", got)
+		}
+		if !strings.Contains(got, "Cannot migrate (2 job(s)):") {
+			t.Errorf("ineligible jobs of both files are not listed together:\n%s", got)
+		}
+	})
+}
+
+func TestPrintScanText_Dedupe(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: "a.yml", JobID: "lint", JobName: "lint", LineNumber: 5, Duration: "2m", Signature: "abc"},
+			{WorkflowPath: "b.yml", JobID: "lint", JobName: "lint", LineNumber: 5, Duration: "1m", Signature: "abc"},
+			{WorkflowPath: "c.yml", JobID: "lint", JobName: "lint", LineNumber: 7, Duration: "3m", Signature: "abc"},
+			{WorkflowPath: "c.yml", JobID: "docs", JobName: "docs", LineNumber: 12, Duration: "1m", Signature: "def"},
+		},
+	}
+
+	dedupe = true
+	t.Cleanup(func() { dedupe = false })
+	var out bytes.Buffer
+	printScanText(&out, result, verbosityNormal)
+
+	got := out.String()
+	for _, want := range []string{
+		`"lint" appears in 3 workflows (safe to migrate)`,
+		"and 2 more",
+		"4 job(s) can be safely migrated",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "a.yml\n") || strings.Contains(got, "📄 b.yml") {
+		t.Errorf("repeated jobs are listed under their workflows:\n%s", got)
+	}
+	if !strings.Contains(got, "📄 c.yml") {
+		t.Errorf("other jobs are not listed under their workflows:\n%s", got)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/git"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// initActionPath is the workflow written by init-action.
+var initActionPath = filepath.Join(workflow.DefaultWorkflowDir, "slimify.yml")
+
+// initActionTemplate is the workflow written by init-action. Values are
+// inserted as JSON strings, which are valid YAML flow scalars.
+var initActionTemplate = template.Must(template.New("slimify.yml").Funcs(template.FuncMap{
+	"quote": func(s string) (string, error) {
+		b, err := json.Marshal(s)
+		return string(b), err
+	},
+}).Parse(`# Generated by gh slimify init-action.
+# Fails pull requests that leave jobs which can migrate to ubuntu-slim on
+# their current runner. Mark jobs that must stay with a
+# "# slimify-ignore: <reason>" comment or list them in .slimifyignore.
+name: slimify
+
+on:
+  pull_request:
+    branches: [{{ quote .Branch }}]
+    paths:
+      - ".github/workflows/**"
+      - ".slimify.yml"
+      - ".slimifyignore"
+
+permissions:
+  contents: read
+  actions: read
+
+jobs:
+  slimify:
+    name: Check for jobs that can run on ubuntu-slim
+    runs-on: ubuntu-slim
+    steps:
+      - uses: actions/checkout@v4
+      - name: Install gh-slimify
+        run: gh extension install fchimpan/gh-slimify
+        env:
+          GH_TOKEN: ${{ "{{" }} github.token {{ "}}" }}
+      - name: Scan workflows
+        run: gh slimify --all --exit-code --format github
+        env:
+          GH_TOKEN: ${{ "{{" }} github.token {{ "}}" }}
+`))
+
+func newInitActionCmd() *cobra.Command {
+	var overwrite bool
+	var branch string
+	cmd := &cobra.Command{
+		Use:   "init-action",
+		Short: "Write a workflow that runs slimify on pull requests",
+		Long: `Write ` + initActionPath + `, a workflow that scans the workflows of
+each pull request against the default branch with --exit-code, so that the
+check fails while jobs that can migrate to ubuntu-slim remain. Jobs are
+reported as annotations on the pull request.
+
+The default branch is read from origin/HEAD in the local clone, or from the
+GitHub API if it isn't recorded. Use --branch to set it instead.
+
+An existing workflow is not overwritten unless --force is set.`,
+		Example: "  gh slimify init-action\n  gh slimify init-action --branch develop --force",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInitAction(cmd, branch, overwrite)
+		},
+	}
+	cmd.Flags().BoolVar(&overwrite, "force", false, "Overwrite "+initActionPath+" if it exists")
+	cmd.Flags().StringVar(&branch, "branch", "", "Branch whose pull requests are checked (default: the repository's default branch)")
+	return cmd
+}
+
+func runInitAction(cmd *cobra.Command, branch string, overwrite bool) error {
+	if _, err := os.Stat(initActionPath); err == nil && !overwrite {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", initActionPath)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if branch == "" {
+		branch = detectDefaultBranch()
+	}
+	content, err := generateInitAction(branch)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(initActionPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(initActionPath), err)
+	}
+	if err := os.WriteFile(initActionPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", initActionPath, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Wrote %s, checking pull requests against %s\n", initActionPath, branch)
+	return nil
+}
+
+// generateInitAction returns the workflow written by init-action for pull
+// requests against branch.
+func generateInitAction(branch string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := initActionTemplate.Execute(&b, struct{ Branch string }{branch}); err != nil {
+		return nil, fmt.Errorf("failed to generate workflow: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// detectDefaultBranch returns the default branch of the repository: the one
+// recorded for origin in the local clone, else the one reported by the GitHub
+// API, else main.
+func detectDefaultBranch() string {
+	if branch, err := git.DefaultBranch("origin"); err == nil {
+		return branch
+	}
+	branch, err := fetchDefaultBranch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to detect the default branch, using main: %v\n", err)
+		return "main"
+	}
+	return branch
+}
+
+// fetchDefaultBranch returns the default branch of the repository reported by
+// the GitHub API.
+func fetchDefaultBranch() (string, error) {
+	host, owner, repo, err := api.GetRepoInfo()
+	if err != nil {
+		return "", err
+	}
+	if hostname != "" {
+		host = hostname
+	}
+	client, err := api.NewClient(host, owner, repo, apiRetries)
+	if err != nil {
+		return "", err
+	}
+	return client.GetDefaultBranch(context.Background())
+}
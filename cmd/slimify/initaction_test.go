@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestGenerateInitAction(t *testing.T) {
+	content, err := generateInitAction("release/v1")
+	if err != nil {
+		t.Fatalf("generateInitAction() error: %v", err)
+	}
+	if !strings.Contains(string(content), `branches: ["release/v1"]`) {
+		t.Errorf("generated workflow doesn't check pull requests against release/v1:\n%s", content)
+	}
+	if !strings.Contains(string(content), "${{ github.token }}") {
+		t.Errorf("generated workflow doesn't pass github.token:\n%s", content)
+	}
+
+	wf, err := workflow.Parse(bytes.NewReader(content), initActionPath)
+	if err != nil {
+		t.Fatalf("generated workflow doesn't parse: %v\n%s", err, content)
+	}
+	job, ok := wf.Jobs["slimify"]
+	if !ok {
+		t.Fatalf("generated workflow has no slimify job: %+v", wf.Jobs)
+	}
+	if got := job.RunsOnLabels(); len(got) != 1 || got[0] != "ubuntu-slim" {
+		t.Errorf("slimify job runs-on = %v, want [ubuntu-slim]", got)
+	}
+	wantRuns := []string{"", "gh extension install fchimpan/gh-slimify", "gh slimify --all --exit-code --format github"}
+	if len(job.Steps) != len(wantRuns) {
+		t.Fatalf("slimify job has %d steps, want %d", len(job.Steps), len(wantRuns))
+	}
+	if job.Steps[0].Uses != "actions/checkout@v4" {
+		t.Errorf("step 0 uses = %q, want actions/checkout@v4", job.Steps[0].Uses)
+	}
+	for i, want := range wantRuns {
+		if job.Steps[i].Run != want {
+			t.Errorf("step %d run = %q, want %q", i, job.Steps[i].Run, want)
+		}
+	}
+}
+
+func TestInitAction(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	run := func(args ...string) error {
+		cmd := newRootCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"init-action"}, args...))
+		return cmd.Execute()
+	}
+
+	if err := run("--branch", "main"); err != nil {
+		t.Fatalf("init-action error: %v", err)
+	}
+	if _, err := os.Stat(initActionPath); err != nil {
+		t.Fatalf("init-action didn't write %s: %v", initActionPath, err)
+	}
+
+	if err := os.WriteFile(initActionPath, []byte("edited\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit workflow: %v", err)
+	}
+	if err := run("--branch", "main"); err == nil {
+		t.Fatal("init-action overwrote an existing workflow without --force")
+	}
+	if data, _ := os.ReadFile(initActionPath); string(data) != "edited\n" {
+		t.Errorf("existing workflow changed without --force:\n%s", data)
+	}
+
+	if err := run("--branch", "develop", "--force"); err != nil {
+		t.Fatalf("init-action --force error: %v", err)
+	}
+	data, err := os.ReadFile(initActionPath)
+	if err != nil {
+		t.Fatalf("Failed to read workflow: %v", err)
+	}
+	if !strings.Contains(string(data), `branches: ["develop"]`) {
+		t.Errorf("init-action --force didn't overwrite the workflow:\n%s", data)
+	}
+}
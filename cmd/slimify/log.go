@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+)
+
+// logLevels are the values accepted by --log-level.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// setupLogger sends log/slog records at or above --log-level to stderr, so
+// that stdout is kept for the results.
+func setupLogger() error {
+	if !slices.Contains(logLevels, strings.ToLower(logLevel)) {
+		return fmt.Errorf("invalid --log-level %q: must be one of %s", logLevel, strings.Join(logLevels, ", "))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", logLevel, err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	return nil
+}
@@ -5,10 +5,21 @@ import (
 	"os"
 )
 
+// Exit codes, so that scripts can tell findings from failures.
+const (
+	// exitFindings reports findings the user asked to fail on: migration
+	// candidates with --exit-code, or unjustified ineligible jobs with
+	// --fail-on-ineligible
+	exitFindings = 1
+	// exitError reports that slimify could not do its job, e.g. invalid flags,
+	// a workflow file that can't be read or parsed, or an API failure
+	exitError = 2
+)
+
 func main() {
 	rootCmd := newRootCmd()
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
@@ -3,52 +3,102 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
 )
 
 // JSON output types for scan command
 type scanJobJSON struct {
-	WorkflowPath      string   `json:"workflow_path"`
-	JobID             string   `json:"job_id"`
-	JobName           string   `json:"job_name"`
-	LineNumber        int      `json:"line_number"`
-	Status            string   `json:"status"`
-	StatusDescription string   `json:"status_description"`
-	RecommendedAction string   `json:"recommended_action"`
-	DurationSeconds   *float64 `json:"duration_seconds,omitempty"`
-	MissingCommands   []string `json:"missing_commands,omitempty"`
-	Reasons           []string `json:"reasons,omitempty"`
+	WorkflowPath            string   `json:"workflow_path"`
+	WorkflowName            string   `json:"workflow_name,omitempty"`
+	Triggers                []string `json:"triggers,omitempty"`
+	JobID                   string   `json:"job_id"`
+	JobName                 string   `json:"job_name"`
+	LineNumber              int      `json:"line_number"`
+	SourceLabel             string   `json:"source_label,omitempty"`
+	Confidence              string   `json:"confidence,omitempty"`
+	Status                  string   `json:"status"`
+	StatusDescription       string   `json:"status_description"`
+	RecommendedAction       string   `json:"recommended_action"`
+	DurationSeconds         *float64 `json:"duration_seconds,omitempty"`
+	TimeoutMinutes          int      `json:"timeout_minutes,omitempty"`
+	LastRun                 string   `json:"last_run,omitempty"`
+	MissingCommands         []string `json:"missing_commands,omitempty"`
+	InstallCount            int      `json:"install_count,omitempty"`
+	MayIncreaseRuntime      bool     `json:"may_increase_runtime,omitempty"`
+	Condition               string   `json:"condition,omitempty"`
+	SetupActions            []string `json:"setup_actions,omitempty"`
+	OutdatedActions         []string `json:"outdated_actions,omitempty"`
+	NoSteps                 bool     `json:"no_steps,omitempty"`
+	NonPOSIXShellSteps      []int    `json:"non_posix_shell_steps,omitempty"`
+	LinuxSkippedDockerSteps []int    `json:"linux_skipped_docker_steps,omitempty"`
+	DockerLayerCacheSteps   []int    `json:"docker_layer_cache_steps,omitempty"`
+	Reasons                 []string `json:"reasons,omitempty"`
+	PartiallyEligible       bool     `json:"partially_eligible,omitempty"`
+	Services                []string `json:"services,omitempty"`
+	OS                      []string `json:"os,omitempty"`
+	RunsOnVariables         []string `json:"runs_on_variables,omitempty"`
+	Justification           string   `json:"justification,omitempty"`
+	PinnedLabel             string   `json:"pinned_label,omitempty"`
+	Caller                  string   `json:"caller,omitempty"`
 }
 
 type scanSummaryJSON struct {
 	Safe        int `json:"safe"`
 	Warning     int `json:"warning"`
+	NeedsSetup  int `json:"needs_setup"`
 	Ineligible  int `json:"ineligible"`
 	AlreadySlim int `json:"already_slim"`
-	Total       int `json:"total"`
+	Ignored     int `json:"ignored"`
+	OtherOS     int `json:"other_os"`
+	// UnresolvedRunsOn counts jobs whose runs-on uses a variable with no known
+	// value
+	UnresolvedRunsOn int `json:"unresolved_runs_on"`
+	// Stale counts eligible jobs that have not run within --since. They are
+	// not counted as safe or warning.
+	Stale int `json:"stale"`
+	// SlimRegressions counts already-slim jobs that use features ubuntu-slim
+	// doesn't support. They are also counted as already_slim.
+	SlimRegressions int `json:"slim_regressions"`
+	// SetupActions counts eligible jobs using actions/setup-* actions. They are
+	// also counted as safe or warning.
+	SetupActions int `json:"setup_actions"`
+	Total        int `json:"total"`
+}
+
+// parseErrorJSON is a workflow file that could not be parsed
+type parseErrorJSON struct {
+	WorkflowPath string `json:"workflow_path"`
+	LineNumber   int    `json:"line_number,omitempty"`
+	Error        string `json:"error"`
 }
 
 type scanOutputJSON struct {
-	Jobs    []scanJobJSON   `json:"jobs"`
-	Summary scanSummaryJSON `json:"summary"`
+	Jobs        []scanJobJSON    `json:"jobs"`
+	Summary     scanSummaryJSON  `json:"summary"`
+	ParseErrors []parseErrorJSON `json:"parse_errors,omitempty"`
 }
 
 // JSON output types for fix command
 type fixJobJSON struct {
-	WorkflowPath      string `json:"workflow_path"`
-	JobID             string `json:"job_id"`
-	JobName           string `json:"job_name"`
-	LineNumber        int    `json:"line_number"`
-	Status            string `json:"status"`
-	StatusDescription string `json:"status_description"`
-	RecommendedAction string `json:"recommended_action"`
-	HasWarnings       bool   `json:"has_warnings"`
-	Error             string `json:"error,omitempty"`
+	WorkflowPath      string   `json:"workflow_path"`
+	JobID             string   `json:"job_id"`
+	JobName           string   `json:"job_name"`
+	LineNumber        int      `json:"line_number"`
+	Status            string   `json:"status"`
+	StatusDescription string   `json:"status_description"`
+	RecommendedAction string   `json:"recommended_action"`
+	HasWarnings       bool     `json:"has_warnings"`
+	InstalledPackages []string `json:"installed_packages,omitempty"`
+	Error             string   `json:"error,omitempty"`
 }
 
 type fixSummaryJSON struct {
@@ -58,8 +108,9 @@ type fixSummaryJSON struct {
 }
 
 type fixOutputJSON struct {
-	Jobs    []fixJobJSON   `json:"jobs"`
-	Summary fixSummaryJSON `json:"summary"`
+	Jobs           []fixJobJSON   `json:"jobs"`
+	Summary        fixSummaryJSON `json:"summary"`
+	PullRequestURL string         `json:"pull_request_url,omitempty"`
 }
 
 // updateResult holds the result of updating a single job in a workflow.
@@ -69,9 +120,11 @@ type updateResult struct {
 	jobName      string
 	lineNumber   int
 	hasWarnings  bool
-	isError      bool
-	errorMsg     string
-	isNotFound   bool
+	// installedPackages lists the packages installed by a step added with --install-missing
+	installedPackages []string
+	isError           bool
+	errorMsg          string
+	isNotFound        bool
 }
 
 // parseDurationSeconds parses a human-readable duration string (e.g. "2m30s")
@@ -88,6 +141,32 @@ func parseDurationSeconds(s string) *float64 {
 	return &secs
 }
 
+// formatTimestamp formats t in RFC 3339 for JSON output. Returns an empty
+// string for the zero time.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// estimatedDuration returns a note bounding an unknown duration by the job's
+// timeout-minutes, e.g. " (est. ≤ 30 min)", or "" if the job sets none.
+func estimatedDuration(job *scan.Candidate) string {
+	if job.TimeoutMinutes == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (est. ≤ %d min)", job.TimeoutMinutes)
+}
+
+// formatLastRun formats when a job last ran as a date, e.g. 2026-01-02.
+func formatLastRun(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
 // classifyCandidates splits candidates into safe and warning groups.
 func classifyCandidates(candidates []*scan.Candidate) (safe, warning []*scan.Candidate) {
 	for _, job := range candidates {
@@ -104,8 +183,75 @@ func classifyCandidates(candidates []*scan.Candidate) (safe, warning []*scan.Can
 	return
 }
 
-func printScanJSON(result *scan.ScanResult) {
-	candidates := result.Candidates
+// pinnedFromFlags returns the number of ineligible jobs that only fail the
+// runs-on criterion because they run on a pinned Ubuntu version, and the
+// --from flags that would make them candidates.
+func pinnedFromFlags(jobs []*scan.IneligibleJob) (int, string) {
+	count := 0
+	var flags []string
+	for _, job := range jobs {
+		if job.PinnedLabel == "" {
+			continue
+		}
+		count++
+		if flag := "--from " + job.PinnedLabel; !slices.Contains(flags, flag) {
+			flags = append(flags, flag)
+		}
+	}
+	sort.Strings(flags)
+	return count, strings.Join(flags, " ")
+}
+
+// writeOutput calls write with the destination for formatted results: the file
+// given by --output, or stdout if it is not set. Parent directories of the file
+// are created as needed.
+func writeOutput(write func(w io.Writer) error) error {
+	if outputPath == "" {
+		return write(os.Stdout)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", outputPath, err)
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	return f.Close()
+}
+
+// scanTotalsJSON is the output of --format json-summary: the totals of a scan
+// without the jobs, for dashboards. Other counts the jobs that are neither
+// eligible, ineligible nor already slim: ignored jobs, jobs on other operating
+// systems, jobs with unresolved runs-on and stale candidates.
+type scanTotalsJSON struct {
+	Eligible   int `json:"eligible"`
+	Ineligible int `json:"ineligible"`
+	Slim       int `json:"slim"`
+	Other      int `json:"other"`
+	Files      int `json:"files"`
+}
+
+// printScanJSONSummary writes the totals of result as JSON.
+func printScanJSONSummary(w io.Writer, result *scan.ScanResult) error {
+	totals := scanTotalsJSON{
+		Eligible:   len(result.Candidates) + len(result.NeedsSetup),
+		Ineligible: len(result.IneligibleJobs),
+		Slim:       len(result.AlreadySlimJobs),
+		Other:      len(result.IgnoredJobs) + len(result.OtherOSJobs) + len(result.UnresolvedRunsOnJobs) + len(result.StaleJobs),
+		Files:      result.Files,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(totals)
+}
+
+func printScanJSON(w io.Writer, result *scan.ScanResult) error {
+	candidates := result.AllCandidates()
 	ineligibleJobs := result.IneligibleJobs
 	alreadySlimJobs := result.AlreadySlimJobs
 
@@ -115,14 +261,28 @@ func printScanJSON(result *scan.ScanResult) {
 
 	for _, job := range safeJobs {
 		jobs = append(jobs, scanJobJSON{
-			WorkflowPath:      job.WorkflowPath,
-			JobID:             job.JobID,
-			JobName:           job.JobName,
-			LineNumber:        job.LineNumber,
-			Status:            "safe",
-			StatusDescription: "Safe to migrate to ubuntu-slim. No missing commands and execution time is known.",
-			RecommendedAction: "migrate",
-			DurationSeconds:   parseDurationSeconds(job.Duration),
+			WorkflowPath:            job.WorkflowPath,
+			WorkflowName:            job.WorkflowName,
+			Triggers:                job.Triggers,
+			JobID:                   job.JobID,
+			JobName:                 job.JobName,
+			LineNumber:              job.LineNumber,
+			Caller:                  callerString(job.Caller),
+			SourceLabel:             job.SourceLabel,
+			Confidence:              string(job.Confidence),
+			Status:                  "safe",
+			StatusDescription:       "Safe to migrate to ubuntu-slim. No missing commands and execution time is known.",
+			RecommendedAction:       "migrate",
+			DurationSeconds:         parseDurationSeconds(job.Duration),
+			LastRun:                 formatTimestamp(job.LastRun),
+			Condition:               job.Condition,
+			SetupActions:            job.SetupActions,
+			OutdatedActions:         job.OutdatedActions,
+			NoSteps:                 job.NoSteps,
+			NonPOSIXShellSteps:      job.NonPOSIXShellSteps,
+			LinuxSkippedDockerSteps: job.LinuxSkippedDockerSteps,
+			DockerLayerCacheSteps:   job.DockerLayerCacheSteps,
+			RunsOnVariables:         job.RunsOnVariables,
 		})
 	}
 
@@ -134,51 +294,169 @@ func printScanJSON(result *scan.ScanResult) {
 
 		var details []string
 		if len(job.MissingCommands) > 0 {
-			details = append(details, fmt.Sprintf("Setup may be required for: %s.", strings.Join(job.MissingCommands, ", ")))
+			details = append(details, fmt.Sprintf("Requires installing: %s.", strings.Join(job.MissingCommands, ", ")))
+		}
+		if job.MayIncreaseRuntime() {
+			details = append(details, fmt.Sprintf("Migration may increase runtime: %d packages to install on every run.", job.InstallCount()))
 		}
 		if duration == "unknown" {
-			details = append(details, "Last execution time is unknown.")
+			details = append(details, "Last execution time is unknown"+estimatedDuration(job)+".")
 		}
 
 		jobs = append(jobs, scanJobJSON{
-			WorkflowPath:      job.WorkflowPath,
-			JobID:             job.JobID,
-			JobName:           job.JobName,
-			LineNumber:        job.LineNumber,
-			Status:            "warning",
-			StatusDescription: "Can migrate but requires attention. " + strings.Join(details, " "),
-			RecommendedAction: "review_before_migrate",
-			DurationSeconds:   parseDurationSeconds(job.Duration),
-			MissingCommands:   job.MissingCommands,
+			WorkflowPath:            job.WorkflowPath,
+			WorkflowName:            job.WorkflowName,
+			Triggers:                job.Triggers,
+			JobID:                   job.JobID,
+			JobName:                 job.JobName,
+			LineNumber:              job.LineNumber,
+			Caller:                  callerString(job.Caller),
+			SourceLabel:             job.SourceLabel,
+			Confidence:              string(job.Confidence),
+			Status:                  "warning",
+			StatusDescription:       "Can migrate but requires attention. " + strings.Join(details, " "),
+			RecommendedAction:       "review_before_migrate",
+			DurationSeconds:         parseDurationSeconds(job.Duration),
+			TimeoutMinutes:          job.TimeoutMinutes,
+			LastRun:                 formatTimestamp(job.LastRun),
+			MissingCommands:         job.MissingCommands,
+			InstallCount:            job.InstallCount(),
+			MayIncreaseRuntime:      job.MayIncreaseRuntime(),
+			Condition:               job.Condition,
+			SetupActions:            job.SetupActions,
+			OutdatedActions:         job.OutdatedActions,
+			NoSteps:                 job.NoSteps,
+			NonPOSIXShellSteps:      job.NonPOSIXShellSteps,
+			LinuxSkippedDockerSteps: job.LinuxSkippedDockerSteps,
+			DockerLayerCacheSteps:   job.DockerLayerCacheSteps,
+			RunsOnVariables:         job.RunsOnVariables,
 		})
 	}
 
 	for _, job := range ineligibleJobs {
-		reasonsStr := strings.Join(job.Reasons, ", ")
+		reasonsStr := strings.Join(job.Reasons, "; ")
 		jobs = append(jobs, scanJobJSON{
 			WorkflowPath:      job.WorkflowPath,
+			WorkflowName:      job.WorkflowName,
+			Triggers:          job.Triggers,
 			JobID:             job.JobID,
 			JobName:           job.JobName,
 			LineNumber:        job.LineNumber,
+			Caller:            callerString(job.Caller),
 			Status:            "ineligible",
 			StatusDescription: "Cannot migrate to ubuntu-slim. " + reasonsStr,
 			RecommendedAction: "do_not_migrate",
+			MissingCommands:   job.MissingCommands,
 			Reasons:           job.Reasons,
+			PartiallyEligible: job.PartiallyEligible,
+			Services:          job.Services,
+			Justification:     job.Justification,
+			PinnedLabel:       job.PinnedLabel,
 		})
 	}
 
 	for _, job := range alreadySlimJobs {
+		if len(job.Reasons) > 0 {
+			jobs = append(jobs, scanJobJSON{
+				WorkflowPath:      job.WorkflowPath,
+				WorkflowName:      job.WorkflowName,
+				Triggers:          job.Triggers,
+				JobID:             job.JobID,
+				JobName:           job.JobName,
+				LineNumber:        job.LineNumber,
+				Caller:            callerString(job.Caller),
+				Status:            "slim_regression",
+				StatusDescription: "Runs on ubuntu-slim but uses features it doesn't support. " + strings.Join(job.Reasons, "; "),
+				RecommendedAction: "fix_or_revert",
+				Reasons:           job.Reasons,
+			})
+			continue
+		}
 		jobs = append(jobs, scanJobJSON{
 			WorkflowPath:      job.WorkflowPath,
+			WorkflowName:      job.WorkflowName,
+			Triggers:          job.Triggers,
 			JobID:             job.JobID,
 			JobName:           job.JobName,
 			LineNumber:        job.LineNumber,
+			Caller:            callerString(job.Caller),
 			Status:            "already_slim",
 			StatusDescription: "Already using ubuntu-slim. No action needed.",
 			RecommendedAction: "no_action_needed",
 		})
 	}
 
+	for _, job := range result.IgnoredJobs {
+		jobs = append(jobs, scanJobJSON{
+			WorkflowPath:      job.WorkflowPath,
+			WorkflowName:      job.WorkflowName,
+			Triggers:          job.Triggers,
+			JobID:             job.JobID,
+			JobName:           job.JobName,
+			LineNumber:        job.LineNumber,
+			Caller:            callerString(job.Caller),
+			Status:            "ignored",
+			StatusDescription: fmt.Sprintf("Ignored by %s rule %q.", scan.IgnoreFileName, job.Rule),
+			RecommendedAction: "no_action_needed",
+		})
+	}
+
+	for _, job := range result.OtherOSJobs {
+		jobs = append(jobs, scanJobJSON{
+			WorkflowPath:      job.WorkflowPath,
+			WorkflowName:      job.WorkflowName,
+			Triggers:          job.Triggers,
+			JobID:             job.JobID,
+			JobName:           job.JobName,
+			LineNumber:        job.LineNumber,
+			Caller:            callerString(job.Caller),
+			Status:            "other_os",
+			StatusDescription: fmt.Sprintf("Runs on %s. ubuntu-slim is not applicable.", strings.Join(job.OS, ", ")),
+			RecommendedAction: "no_action_needed",
+			OS:                job.OS,
+		})
+	}
+
+	for _, job := range result.StaleJobs {
+		jobs = append(jobs, scanJobJSON{
+			WorkflowPath:            job.WorkflowPath,
+			WorkflowName:            job.WorkflowName,
+			Triggers:                job.Triggers,
+			JobID:                   job.JobID,
+			JobName:                 job.JobName,
+			LineNumber:              job.LineNumber,
+			Caller:                  callerString(job.Caller),
+			SourceLabel:             job.SourceLabel,
+			Confidence:              string(job.Confidence),
+			Status:                  "stale",
+			StatusDescription:       fmt.Sprintf("Eligible, but last ran on %s, longer ago than --since %s.", formatLastRun(job.LastRun), since),
+			RecommendedAction:       "review_usage",
+			DurationSeconds:         parseDurationSeconds(job.Duration),
+			LastRun:                 formatTimestamp(job.LastRun),
+			MissingCommands:         job.MissingCommands,
+			NonPOSIXShellSteps:      job.NonPOSIXShellSteps,
+			LinuxSkippedDockerSteps: job.LinuxSkippedDockerSteps,
+			DockerLayerCacheSteps:   job.DockerLayerCacheSteps,
+			RunsOnVariables:         job.RunsOnVariables,
+		})
+	}
+
+	for _, job := range result.UnresolvedRunsOnJobs {
+		jobs = append(jobs, scanJobJSON{
+			WorkflowPath:      job.WorkflowPath,
+			WorkflowName:      job.WorkflowName,
+			Triggers:          job.Triggers,
+			JobID:             job.JobID,
+			JobName:           job.JobName,
+			LineNumber:        job.LineNumber,
+			Caller:            callerString(job.Caller),
+			Status:            "unresolved_runs_on",
+			StatusDescription: fmt.Sprintf("runs-on uses variable expression (%s); cannot determine.", strings.Join(job.Variables, ", ")),
+			RecommendedAction: "resolve_variable",
+			RunsOnVariables:   job.Variables,
+		})
+	}
+
 	if jobs == nil {
 		jobs = []scanJobJSON{}
 	}
@@ -186,40 +464,116 @@ func printScanJSON(result *scan.ScanResult) {
 	output := scanOutputJSON{
 		Jobs: jobs,
 		Summary: scanSummaryJSON{
-			Safe:        len(safeJobs),
-			Warning:     len(warningJobs),
-			Ineligible:  len(ineligibleJobs),
-			AlreadySlim: len(alreadySlimJobs),
-			Total:       len(safeJobs) + len(warningJobs) + len(ineligibleJobs) + len(alreadySlimJobs),
+			Safe:             len(safeJobs),
+			Warning:          len(warningJobs),
+			NeedsSetup:       len(result.NeedsSetup),
+			Ineligible:       len(ineligibleJobs),
+			AlreadySlim:      len(alreadySlimJobs),
+			Ignored:          len(result.IgnoredJobs),
+			OtherOS:          len(result.OtherOSJobs),
+			UnresolvedRunsOn: len(result.UnresolvedRunsOnJobs),
+			Stale:            len(result.StaleJobs),
+			SetupActions:     len(result.UsingSetupActions()),
+			SlimRegressions:  len(result.SlimRegressions()),
+			Total:            len(safeJobs) + len(warningJobs) + len(ineligibleJobs) + len(alreadySlimJobs) + len(result.IgnoredJobs) + len(result.OtherOSJobs) + len(result.UnresolvedRunsOnJobs) + len(result.StaleJobs),
 		},
 	}
+	for _, e := range result.ParseErrors {
+		output.ParseErrors = append(output.ParseErrors, parseErrorJSON{
+			WorkflowPath: e.Path,
+			LineNumber:   e.Line(),
+			Error:        e.Error(),
+		})
+	}
 
-	enc := json.NewEncoder(os.Stdout)
+	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
-	enc.Encode(output)
+	return enc.Encode(output)
 }
 
-func printScanText(result *scan.ScanResult) {
-	candidates := result.Candidates
+func printScanText(w io.Writer, result *scan.ScanResult, level verbosity) {
+	candidates := result.AllCandidates()
 	ineligibleJobs := result.IneligibleJobs
 	alreadySlimJobs := result.AlreadySlimJobs
 
+	p := newPalette(w)
+
+	if level == verbosityQuiet {
+		if len(candidates) > 0 {
+			fmt.Fprintf(w, "📊 Total: %d job(s) eligible for migration\n", len(candidates))
+		}
+		if regressions := result.SlimRegressions(); len(regressions) > 0 {
+			fmt.Fprintf(w, "🚨 %d job(s) on ubuntu-slim use features it doesn't support\n", len(regressions))
+		}
+		printParseErrors(w, result.ParseErrors)
+		return
+	}
+
+	// With --dedupe, jobs repeated in several workflows are listed once
+	// after the others
+	repeated, candidates := repeatedCandidates(candidates)
+
+	if groupBy == groupByReason {
+		printScanByReason(w, result, candidates, level)
+		printRepeatedCandidates(w, repeated, level)
+		printScanSummary(w, result, level)
+		return
+	}
+
+	// Jobs are grouped by workflow file, or listed together with --group-by flat
+	groupKey := func(path string) string {
+		if groupBy == groupByFlat {
+			return ""
+		}
+		return path
+	}
+
 	// Group candidates by workflow file
 	workflowMap := make(map[string][]*scan.Candidate)
 	for _, c := range candidates {
-		workflowMap[c.WorkflowPath] = append(workflowMap[c.WorkflowPath], c)
+		workflowMap[groupKey(c.WorkflowPath)] = append(workflowMap[groupKey(c.WorkflowPath)], c)
 	}
 
 	// Group ineligible jobs by workflow file
 	ineligibleMap := make(map[string][]*scan.IneligibleJob)
 	for _, job := range ineligibleJobs {
-		ineligibleMap[job.WorkflowPath] = append(ineligibleMap[job.WorkflowPath], job)
+		ineligibleMap[groupKey(job.WorkflowPath)] = append(ineligibleMap[groupKey(job.WorkflowPath)], job)
 	}
 
-	// Group already slim jobs by workflow file
+	// Group already slim jobs by workflow file, apart from those that use
+	// features ubuntu-slim doesn't support
 	alreadySlimMap := make(map[string][]*scan.AlreadySlimJob)
+	regressionMap := make(map[string][]*scan.AlreadySlimJob)
 	for _, job := range alreadySlimJobs {
-		alreadySlimMap[job.WorkflowPath] = append(alreadySlimMap[job.WorkflowPath], job)
+		if len(job.Reasons) > 0 {
+			regressionMap[groupKey(job.WorkflowPath)] = append(regressionMap[groupKey(job.WorkflowPath)], job)
+			continue
+		}
+		alreadySlimMap[groupKey(job.WorkflowPath)] = append(alreadySlimMap[groupKey(job.WorkflowPath)], job)
+	}
+
+	// Group ignored jobs by workflow file
+	ignoredMap := make(map[string][]*scan.IgnoredJob)
+	for _, job := range result.IgnoredJobs {
+		ignoredMap[groupKey(job.WorkflowPath)] = append(ignoredMap[groupKey(job.WorkflowPath)], job)
+	}
+
+	// Group jobs on other operating systems by workflow file
+	otherOSMap := make(map[string][]*scan.OtherOSJob)
+	for _, job := range result.OtherOSJobs {
+		otherOSMap[groupKey(job.WorkflowPath)] = append(otherOSMap[groupKey(job.WorkflowPath)], job)
+	}
+
+	// Group candidates that have not run within --since by workflow file
+	staleMap := make(map[string][]*scan.Candidate)
+	for _, c := range result.StaleJobs {
+		staleMap[groupKey(c.WorkflowPath)] = append(staleMap[groupKey(c.WorkflowPath)], c)
+	}
+
+	// Group jobs whose runs-on uses an unresolved variable by workflow file
+	unresolvedMap := make(map[string][]*scan.UnresolvedRunsOnJob)
+	for _, job := range result.UnresolvedRunsOnJobs {
+		unresolvedMap[groupKey(job.WorkflowPath)] = append(unresolvedMap[groupKey(job.WorkflowPath)], job)
 	}
 
 	// Display results grouped by workflow file
@@ -227,138 +581,346 @@ func printScanText(result *scan.ScanResult) {
 	for path := range workflowMap {
 		allWorkflowPaths[path] = true
 	}
-	for path := range ineligibleMap {
+	// Ineligible and already slim jobs are only listed in verbose mode
+	if level == verbosityVerbose {
+		for path := range ineligibleMap {
+			allWorkflowPaths[path] = true
+		}
+		for path := range alreadySlimMap {
+			allWorkflowPaths[path] = true
+		}
+		for path := range otherOSMap {
+			allWorkflowPaths[path] = true
+		}
+		for path := range staleMap {
+			allWorkflowPaths[path] = true
+		}
+	}
+	for path := range ignoredMap {
+		allWorkflowPaths[path] = true
+	}
+	for path := range regressionMap {
 		allWorkflowPaths[path] = true
 	}
-	for path := range alreadySlimMap {
+	for path := range unresolvedMap {
 		allWorkflowPaths[path] = true
 	}
 
+	// Workflow names and triggers, for the headers of workflow files
+	workflowTitles := make(map[string]string)
+	for _, c := range candidates {
+		workflowTitles[groupKey(c.WorkflowPath)] = describeWorkflow(c.WorkflowName, c.Triggers)
+	}
+	for _, job := range ineligibleJobs {
+		workflowTitles[groupKey(job.WorkflowPath)] = describeWorkflow(job.WorkflowName, job.Triggers)
+	}
+	for _, job := range alreadySlimJobs {
+		workflowTitles[groupKey(job.WorkflowPath)] = describeWorkflow(job.WorkflowName, job.Triggers)
+	}
+	for _, job := range result.IgnoredJobs {
+		workflowTitles[groupKey(job.WorkflowPath)] = describeWorkflow(job.WorkflowName, job.Triggers)
+	}
+	for _, job := range result.OtherOSJobs {
+		workflowTitles[groupKey(job.WorkflowPath)] = describeWorkflow(job.WorkflowName, job.Triggers)
+	}
+	for _, job := range result.UnresolvedRunsOnJobs {
+		workflowTitles[groupKey(job.WorkflowPath)] = describeWorkflow(job.WorkflowName, job.Triggers)
+	}
+	for _, c := range result.StaleJobs {
+		workflowTitles[groupKey(c.WorkflowPath)] = describeWorkflow(c.WorkflowName, c.Triggers)
+	}
+
 	for workflowPath := range allWorkflowPaths {
-		fmt.Printf("\n📄 %s\n", workflowPath)
+		if groupBy == groupByFlat {
+			fmt.Fprintln(w)
+		} else {
+			fmt.Fprintf(w, "\n📄 %s%s\n", p.bold(workflowPath), workflowTitles[workflowPath])
+		}
 		jobs := workflowMap[workflowPath]
 
 		safeJobs, warningJobs := classifyCandidates(jobs)
 
 		// Display safe jobs first
 		if len(safeJobs) > 0 {
-			fmt.Printf("  ✅ Safe to migrate (%d job(s)):\n", len(safeJobs))
+			fmt.Fprintf(w, "  ✅ %s\n", p.green(fmt.Sprintf("Safe to migrate (%d job(s)):", len(safeJobs))))
 			for _, job := range safeJobs {
-				jobLink := formatLocalLink(workflowPath, job.LineNumber)
-				fmt.Printf("     • \"%s\" (L%d) - Last execution time: %s\n", job.JobName, job.LineNumber, job.Duration)
-				fmt.Printf("       %s\n", jobLink)
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d) - Last execution time: %s\n", p.green(quoted(job.JobName)), job.LineNumber, job.Duration)
+				if job.Condition != "" {
+					fmt.Fprintf(w, "       ℹ️  runs only if: %s\n", job.Condition)
+				}
+				if len(job.SetupActions) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeSetupActions(job.SetupActions))
+				}
+				if len(job.OutdatedActions) > 0 {
+					fmt.Fprintf(w, "       ℹ️  pins old action versions that may not work on ubuntu-slim: %s\n", strings.Join(job.OutdatedActions, ", "))
+				}
+				if job.NoSteps {
+					fmt.Fprintf(w, "       ℹ️  has no steps, so it is trivially eligible\n")
+				}
+				if len(job.NonPOSIXShellSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeShellSteps(job.NonPOSIXShellSteps))
+				}
+				if len(job.LinuxSkippedDockerSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeLinuxSkippedDockerSteps(job.LinuxSkippedDockerSteps))
+				}
+				if len(job.DockerLayerCacheSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeDockerLayerCacheSteps(job.DockerLayerCacheSteps))
+				}
+				if len(job.RunsOnVariables) > 0 {
+					fmt.Fprintf(w, "       ℹ️  runs-on is set by %s; change its value to ubuntu-slim\n", strings.Join(job.RunsOnVariables, ", "))
+				}
+				if level == verbosityVerbose {
+					fmt.Fprintf(w, "       🎯 Confidence: %s\n", job.Confidence)
+				}
+				fmt.Fprintf(w, "       %s\n", jobLink)
 			}
 		}
 
 		// Display jobs with warnings
 		if len(warningJobs) > 0 {
-			fmt.Printf("  ⚠️  Can migrate but requires attention (%d job(s)):\n", len(warningJobs))
+			fmt.Fprintf(w, "  ⚠️  %s\n", p.yellow(fmt.Sprintf("Can migrate but requires attention (%d job(s)):", len(warningJobs))))
 			for _, job := range warningJobs {
 				duration := job.Duration
 				if duration == "" {
 					duration = "unknown"
 				}
-				jobLink := formatLocalLink(workflowPath, job.LineNumber)
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
 
 				// Build warning reasons in a single line
 				var reasons []string
 				if len(job.MissingCommands) > 0 {
-					commandsStr := ""
-					for i, cmd := range job.MissingCommands {
-						if i > 0 {
-							commandsStr += ", "
-						}
-						commandsStr += cmd
-					}
-					reasons = append(reasons, fmt.Sprintf("Setup may be required (%s)", commandsStr))
+					reasons = append(reasons, "requires installing: "+strings.Join(job.MissingCommands, ", "))
 				}
 				if duration == "unknown" {
-					reasons = append(reasons, "Last execution time: unknown")
+					reasons = append(reasons, "Last execution time: unknown"+estimatedDuration(job))
 				}
 
-				warningMsg := ""
-				if len(reasons) > 0 {
-					warningMsg = reasons[0]
-					for i := 1; i < len(reasons); i++ {
-						warningMsg += ", " + reasons[i]
-					}
-				}
+				warningMsg := strings.Join(reasons, "; ")
 
-				fmt.Printf("     • \"%s\" (L%d)\n", job.JobName, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d)\n", p.yellow(quoted(job.JobName)), job.LineNumber)
 				if warningMsg != "" {
-					fmt.Printf("       ⚠️  %s\n", warningMsg)
+					fmt.Fprintf(w, "       ⚠️  %s\n", warningMsg)
+				}
+				if job.MayIncreaseRuntime() {
+					fmt.Fprintf(w, "       ⚠️  %s\n", describeManyInstalls(job))
 				}
 				if duration != "unknown" {
-					fmt.Printf("       Last execution time: %s\n", duration)
+					fmt.Fprintf(w, "       Last execution time: %s\n", duration)
+				}
+				if job.Condition != "" {
+					fmt.Fprintf(w, "       ℹ️  runs only if: %s\n", job.Condition)
+				}
+				if len(job.SetupActions) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeSetupActions(job.SetupActions))
+				}
+				if len(job.OutdatedActions) > 0 {
+					fmt.Fprintf(w, "       ℹ️  pins old action versions that may not work on ubuntu-slim: %s\n", strings.Join(job.OutdatedActions, ", "))
+				}
+				if job.NoSteps {
+					fmt.Fprintf(w, "       ℹ️  has no steps, so it is trivially eligible\n")
+				}
+				if len(job.NonPOSIXShellSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeShellSteps(job.NonPOSIXShellSteps))
 				}
-				fmt.Printf("       %s\n", jobLink)
+				if len(job.LinuxSkippedDockerSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeLinuxSkippedDockerSteps(job.LinuxSkippedDockerSteps))
+				}
+				if len(job.DockerLayerCacheSteps) > 0 {
+					fmt.Fprintf(w, "       ℹ️  %s\n", describeDockerLayerCacheSteps(job.DockerLayerCacheSteps))
+				}
+				if len(job.RunsOnVariables) > 0 {
+					fmt.Fprintf(w, "       ℹ️  runs-on is set by %s; change its value to ubuntu-slim\n", strings.Join(job.RunsOnVariables, ", "))
+				}
+				if level == verbosityVerbose {
+					fmt.Fprintf(w, "       🎯 Confidence: %s\n", job.Confidence)
+				}
+				fmt.Fprintf(w, "       %s\n", jobLink)
 			}
 		}
 
 		// Display ineligible jobs
 		ineligibleJobsForWorkflow := ineligibleMap[workflowPath]
-		if len(ineligibleJobsForWorkflow) > 0 {
-			fmt.Printf("  ❌ Cannot migrate (%d job(s)):\n", len(ineligibleJobsForWorkflow))
+		if level == verbosityVerbose && len(ineligibleJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  ❌ %s\n", p.red(fmt.Sprintf("Cannot migrate (%d job(s)):", len(ineligibleJobsForWorkflow))))
 			for _, job := range ineligibleJobsForWorkflow {
-				jobLink := formatLocalLink(workflowPath, job.LineNumber)
-				reasonsStr := ""
-				if len(job.Reasons) > 0 {
-					reasonsStr = job.Reasons[0]
-					for i := 1; i < len(job.Reasons); i++ {
-						reasonsStr += ", " + job.Reasons[i]
-					}
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d)\n", p.red(quoted(job.JobName)), job.LineNumber)
+				for _, reason := range job.Reasons {
+					fmt.Fprintf(w, "       ❌ %s\n", reason)
+				}
+				if job.Justification != "" {
+					fmt.Fprintf(w, "       📝 slimify-ignore: %s\n", job.Justification)
+				}
+				if len(job.MissingCommands) > 0 {
+					fmt.Fprintf(w, "       ⚠️  requires installing: %s\n", strings.Join(job.MissingCommands, ", "))
 				}
-				fmt.Printf("     • \"%s\" (L%d)\n", job.JobName, job.LineNumber)
-				if reasonsStr != "" {
-					fmt.Printf("       ❌ %s\n", reasonsStr)
+				if job.PinnedLabel != "" {
+					fmt.Fprintf(w, "       💡 meets every other criterion; add --from %s to migrate it\n", job.PinnedLabel)
 				}
-				fmt.Printf("       %s\n", jobLink)
+				fmt.Fprintf(w, "       %s\n", jobLink)
+			}
+		}
+
+		// Display already slim jobs that no longer look safe
+		regressionsForWorkflow := regressionMap[workflowPath]
+		if len(regressionsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  🚨 %s\n", p.red(fmt.Sprintf("On ubuntu-slim but no longer safe (%d job(s)):", len(regressionsForWorkflow))))
+			for _, job := range regressionsForWorkflow {
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d)\n", p.red(quoted(job.JobName)), job.LineNumber)
+				for _, reason := range job.Reasons {
+					fmt.Fprintf(w, "       ❌ %s\n", reason)
+				}
+				fmt.Fprintf(w, "       %s\n", jobLink)
 			}
 		}
 
 		// Display already slim jobs
 		alreadySlimJobsForWorkflow := alreadySlimMap[workflowPath]
-		if len(alreadySlimJobsForWorkflow) > 0 {
-			fmt.Printf("  ✨ Already using ubuntu-slim (%d job(s)):\n", len(alreadySlimJobsForWorkflow))
+		if level == verbosityVerbose && len(alreadySlimJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  ✨ Already using ubuntu-slim (%d job(s)):\n", len(alreadySlimJobsForWorkflow))
 			for _, job := range alreadySlimJobsForWorkflow {
-				jobLink := formatLocalLink(workflowPath, job.LineNumber)
-				fmt.Printf("     • \"%s\" (L%d)\n", job.JobName, job.LineNumber)
-				fmt.Printf("       %s\n", jobLink)
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • \"%s\" (L%d)\n", job.JobName, job.LineNumber)
+				fmt.Fprintf(w, "       %s\n", jobLink)
+			}
+		}
+
+		// Display jobs on other operating systems
+		otherOSJobsForWorkflow := otherOSMap[workflowPath]
+		if level == verbosityVerbose && len(otherOSJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  🖥️  Different OS (not applicable) (%d job(s)):\n", len(otherOSJobsForWorkflow))
+			for _, job := range otherOSJobsForWorkflow {
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d) - runs on %s\n", quoted(job.JobName), job.LineNumber, strings.Join(job.OS, ", "))
+				fmt.Fprintf(w, "       %s\n", jobLink)
+			}
+		}
+
+		// Display candidates that have not run recently
+		staleJobsForWorkflow := staleMap[workflowPath]
+		if level == verbosityVerbose && len(staleJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  💤 Not run in the last %s (%d job(s)):\n", since, len(staleJobsForWorkflow))
+			for _, job := range staleJobsForWorkflow {
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d) - last run: %s\n", quoted(job.JobName), job.LineNumber, formatLastRun(job.LastRun))
+				fmt.Fprintf(w, "       %s\n", jobLink)
+			}
+		}
+
+		// Display jobs whose runs-on can't be determined
+		unresolvedJobsForWorkflow := unresolvedMap[workflowPath]
+		if len(unresolvedJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  ❓ runs-on uses variable expression; cannot determine (%d job(s)):\n", len(unresolvedJobsForWorkflow))
+			for _, job := range unresolvedJobsForWorkflow {
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • %s (L%d) - uses %s\n", quoted(job.JobName), job.LineNumber, strings.Join(job.Variables, ", "))
+				fmt.Fprintf(w, "       %s\n", jobLink)
 			}
 		}
-	}
 
-	// Summary
-	safeCount := 0
-	warningCount := 0
-	for _, jobs := range workflowMap {
-		safe, warning := classifyCandidates(jobs)
-		safeCount += len(safe)
-		warningCount += len(warning)
+		// Display ignored jobs
+		ignoredJobsForWorkflow := ignoredMap[workflowPath]
+		if len(ignoredJobsForWorkflow) > 0 {
+			fmt.Fprintf(w, "  🙈 Ignored by %s (%d job(s)):\n", scan.IgnoreFileName, len(ignoredJobsForWorkflow))
+			for _, job := range ignoredJobsForWorkflow {
+				jobLink := formatLocalLink(job.WorkflowPath, job.LineNumber)
+				fmt.Fprintf(w, "     • \"%s\" (L%d) - rule: %s\n", job.JobName, job.LineNumber, job.Rule)
+				fmt.Fprintf(w, "       %s\n", jobLink)
+			}
+		}
 	}
 
-	fmt.Println()
+	printRepeatedCandidates(w, repeated, level)
+	printScanSummary(w, result, level)
+}
+
+// printScanSummary prints the counts of jobs per status after the jobs listed
+// by printScanText, followed by the savings estimate and parse errors.
+func printScanSummary(w io.Writer, result *scan.ScanResult, level verbosity) {
+	candidates := result.AllCandidates()
+	ineligibleJobs := result.IneligibleJobs
+	alreadySlimJobs := result.AlreadySlimJobs
+	safeJobs, warningJobs := classifyCandidates(candidates)
+	safeCount, warningCount := len(safeJobs), len(warningJobs)
+
+	p := newPalette(w)
+	fmt.Fprintln(w)
 	if safeCount > 0 {
-		fmt.Printf("✅ %d job(s) can be safely migrated\n", safeCount)
+		fmt.Fprintf(w, "✅ %s\n", p.green(fmt.Sprintf("%d job(s) can be safely migrated", safeCount)))
 	}
 	if warningCount > 0 {
-		fmt.Printf("⚠️  %d job(s) can be migrated but require attention\n", warningCount)
+		fmt.Fprintf(w, "⚠️  %s\n", p.yellow(fmt.Sprintf("%d job(s) can be migrated but require attention", warningCount)))
 	}
 	if len(ineligibleJobs) > 0 {
-		fmt.Printf("❌ %d job(s) cannot be migrated\n", len(ineligibleJobs))
+		if level == verbosityVerbose || groupBy == groupByReason {
+			fmt.Fprintf(w, "❌ %s\n", p.red(fmt.Sprintf("%d job(s) cannot be migrated", len(ineligibleJobs))))
+		} else {
+			fmt.Fprintf(w, "❌ %s (use --verbose to see reasons)\n", p.red(fmt.Sprintf("%d job(s) cannot be migrated", len(ineligibleJobs))))
+		}
+		if count, flags := pinnedFromFlags(ineligibleJobs); count > 0 {
+			fmt.Fprintf(w, "💡 %d of them are blocked only by a pinned Ubuntu version; add %s to migrate them\n", count, flags)
+		}
 	}
 	if len(alreadySlimJobs) > 0 {
-		fmt.Printf("✨ %d job(s) already using ubuntu-slim\n", len(alreadySlimJobs))
+		fmt.Fprintf(w, "✨ %d job(s) already using ubuntu-slim\n", len(alreadySlimJobs))
+	}
+	if regressions := result.SlimRegressions(); len(regressions) > 0 {
+		fmt.Fprintf(w, "🚨 %s\n", p.red(fmt.Sprintf("%d job(s) on ubuntu-slim use features it doesn't support", len(regressions))))
+	}
+	if len(result.OtherOSJobs) > 0 {
+		fmt.Fprintf(w, "🖥️  %d job(s) run on a different OS (not applicable)\n", len(result.OtherOSJobs))
+	}
+	if len(result.StaleJobs) > 0 {
+		if level == verbosityVerbose {
+			fmt.Fprintf(w, "💤 %d eligible job(s) have not run in the last %s\n", len(result.StaleJobs), since)
+		} else {
+			fmt.Fprintf(w, "💤 %d eligible job(s) have not run in the last %s (use --verbose to see them)\n", len(result.StaleJobs), since)
+		}
+	}
+	if len(result.UnresolvedRunsOnJobs) > 0 {
+		fmt.Fprintf(w, "❓ %d job(s) use a variable in runs-on (use --resolve-var NAME=value to check them)\n", len(result.UnresolvedRunsOnJobs))
+	}
+	if len(result.IgnoredJobs) > 0 {
+		fmt.Fprintf(w, "🙈 %d job(s) ignored by %s\n", len(result.IgnoredJobs), scan.IgnoreFileName)
+	}
+	if setupJobs := result.UsingSetupActions(); len(setupJobs) > 0 {
+		fmt.Fprintf(w, "ℹ️  %d eligible job(s) use setup actions; verify the installed tools work on ubuntu-slim\n", len(setupJobs))
 	}
 	if len(candidates) > 0 {
-		fmt.Printf("📊 Total: %d job(s) eligible for migration\n", len(candidates))
+		fmt.Fprintf(w, "📊 Total: %d job(s) eligible for migration\n", len(candidates))
+	}
+	if savings := scan.EstimateSavings(candidates, scan.Pricing{Standard: priceStandard, Slim: priceSlim}); savings.Jobs > 0 || savings.TimeoutJobs > 0 {
+		fmt.Fprintf(w, "💰 Estimated savings on ubuntu-slim: ~$%.3f per run of %d job(s) (%d billable minute(s))", savings.Amount, savings.Jobs, savings.Minutes)
+		if savings.TimeoutJobs > 0 {
+			fmt.Fprintf(w, ", plus up to ~$%.3f for %d job(s) with unknown duration (est. ≤ %d min from timeout-minutes)", savings.MaxTimeoutAmount, savings.TimeoutJobs, savings.MaxTimeoutMinutes)
+		}
+		if savings.UnknownJobs > 0 {
+			fmt.Fprintf(w, ", excluding %d job(s) with unknown duration", savings.UnknownJobs)
+		}
+		fmt.Fprintln(w)
 	}
-	if len(candidates) == 0 && len(ineligibleJobs) == 0 && len(alreadySlimJobs) == 0 {
-		fmt.Println("No jobs found that can be safely migrated to ubuntu-slim.")
+	if len(candidates) == 0 && len(ineligibleJobs) == 0 && len(alreadySlimJobs) == 0 && len(result.IgnoredJobs) == 0 && len(result.OtherOSJobs) == 0 && len(result.UnresolvedRunsOnJobs) == 0 && len(result.StaleJobs) == 0 {
+		fmt.Fprintln(w, "No jobs found that can be safely migrated to ubuntu-slim.")
 	}
+	printParseErrors(w, result.ParseErrors)
 }
 
-func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, hasErrors bool) {
+// printParseErrors lists the workflow files that could not be parsed, after
+// the results of the others.
+func printParseErrors(w io.Writer, parseErrors []scan.FileError) {
+	if len(parseErrors) == 0 {
+		return
+	}
+	p := newPalette(w)
+	fmt.Fprintf(w, "\n⚠️  %s\n", p.yellow(fmt.Sprintf("Skipped %d workflow file(s) that could not be parsed:", len(parseErrors))))
+	for _, e := range parseErrors {
+		fmt.Fprintf(w, "     • %v\n", e)
+	}
+}
+
+func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, pullRequestURL string, hasErrors bool) {
 	var jobs []fixJobJSON
 	updatedCount := 0
 	skippedCount := 0
@@ -399,6 +961,7 @@ func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, hasErro
 				StatusDescription: "Updated to ubuntu-slim but has warnings. Review job configuration.",
 				RecommendedAction: "verify_workflow_carefully",
 				HasWarnings:       true,
+				InstalledPackages: r.installedPackages,
 			})
 			updatedCount++
 		} else {
@@ -410,6 +973,7 @@ func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, hasErro
 				Status:            "updated",
 				StatusDescription: "Successfully updated to ubuntu-slim.",
 				RecommendedAction: "verify_workflow",
+				InstalledPackages: r.installedPackages,
 			})
 			updatedCount++
 		}
@@ -440,6 +1004,7 @@ func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, hasErro
 			Skipped: skippedCount,
 			Errors:  errorCount,
 		},
+		PullRequestURL: pullRequestURL,
 	}
 
 	enc := json.NewEncoder(os.Stdout)
@@ -447,11 +1012,11 @@ func printFixJSON(results []updateResult, skippedJobs []*scan.Candidate, hasErro
 	enc.Encode(output)
 
 	if hasErrors {
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
 
-func printFixText(results []updateResult, updatedCount, errorCount int) {
+func printFixText(results []updateResult, updatedCount, fileCount, errorCount int) {
 	if errorCount > 0 {
 		fmt.Fprintf(os.Stderr, "✗ Update completed with errors\n")
 	} else {
@@ -478,14 +1043,110 @@ func printFixText(results []updateResult, updatedCount, errorCount int) {
 		} else {
 			fmt.Printf("  ✓ Updated job \"%s\" (L%d) → ubuntu-slim\n", r.jobName, r.lineNumber)
 		}
+		if !r.isError && len(r.installedPackages) > 0 {
+			fmt.Printf("     Added step installing: %s\n", strings.Join(r.installedPackages, ", "))
+		}
 	}
 	fmt.Println()
 
-	fmt.Printf("Successfully updated %d job(s) to use ubuntu-slim.\n", updatedCount)
+	fmt.Printf("Successfully updated %d job(s) in %d file(s) to use ubuntu-slim.\n", updatedCount, fileCount)
+	if backupFiles && updatedCount > 0 {
+		fmt.Printf("Backups saved as <file>%s. Run \"gh slimify revert\" to undo the changes.\n", workflow.BackupSuffix)
+	}
 	if errorCount > 0 {
 		fmt.Fprintf(os.Stderr, "Encountered %d error(s) during update.\n", errorCount)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+}
+
+// describeWorkflow formats a workflow's name and triggers to follow its path in
+// text output, e.g. " — CI (on: push, pull_request)". It returns "" if both are empty.
+func describeWorkflow(name string, triggers []string) string {
+	var desc string
+	if name != "" {
+		desc = " — " + name
+	}
+	if len(triggers) > 0 {
+		desc += " (on: " + strings.Join(triggers, ", ") + ")"
+	}
+	return desc
+}
+
+// quoted wraps a job name in double quotes for text output.
+func quoted(name string) string {
+	return "\"" + name + "\""
+}
+
+// callerString formats the caller of a job reached through a reusable workflow
+// call, or returns "" if there is none.
+func callerString(caller *scan.Caller) string {
+	if caller == nil {
+		return ""
+	}
+	return caller.String()
+}
+
+// describeSetupActions formats an informational note for setup actions,
+// e.g. "uses setup-node; verify node works on ubuntu-slim".
+func describeSetupActions(actions []string) string {
+	names := make([]string, len(actions))
+	tools := make([]string, len(actions))
+	for i, action := range actions {
+		names[i] = strings.TrimPrefix(action, "actions/")
+		tools[i] = strings.TrimPrefix(names[i], "setup-")
+	}
+	verb := "works"
+	if len(tools) > 1 {
+		verb = "work"
+	}
+	return fmt.Sprintf("uses %s; verify %s %s on ubuntu-slim", strings.Join(names, ", "), strings.Join(tools, ", "), verb)
+}
+
+// describeManyInstalls formats the warning for a candidate needing so many
+// installs on ubuntu-slim that migrating may make it slower.
+func describeManyInstalls(job *scan.Candidate) string {
+	return fmt.Sprintf("migration may increase runtime: %d packages to install on every run", job.InstallCount())
+}
+
+// describeDockerLayerCacheSteps formats an informational note for
+// actions/cache steps caching Docker layers, e.g. "step 2 caches Docker layers;
+// check that the job doesn't build images".
+func describeDockerLayerCacheSteps(steps []int) string {
+	if len(steps) == 1 {
+		return fmt.Sprintf("step %d caches Docker layers; check that the job doesn't build images", steps[0])
+	}
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = fmt.Sprint(step)
+	}
+	return fmt.Sprintf("steps %s cache Docker layers; check that the job doesn't build images", strings.Join(parts, ", "))
+}
+
+// describeShellSteps formats an informational note for run steps using a
+// non-POSIX shell, e.g. "step 2 runs with a non-POSIX shell and was not fully checked".
+func describeShellSteps(steps []int) string {
+	if len(steps) == 1 {
+		return fmt.Sprintf("step %d runs with a non-POSIX shell and was not fully checked", steps[0])
+	}
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = fmt.Sprint(step)
+	}
+	return fmt.Sprintf("steps %s run with a non-POSIX shell and were not fully checked", strings.Join(parts, ", "))
+}
+
+// describeLinuxSkippedDockerSteps formats an informational note for steps
+// using Docker that don't run on Linux, e.g. "step 3 uses Docker but its if:
+// condition is false on Linux".
+func describeLinuxSkippedDockerSteps(steps []int) string {
+	if len(steps) == 1 {
+		return fmt.Sprintf("step %d uses Docker but its if: condition is false on Linux", steps[0])
+	}
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = fmt.Sprint(step)
 	}
+	return fmt.Sprintf("steps %s use Docker but their if: conditions are false on Linux", strings.Join(parts, ", "))
 }
 
 // formatLocalLink formats a local file link with line number.
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+func TestPrintScanJSONSummary(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates:           []*scan.Candidate{{JobID: "lint"}, {JobID: "test"}},
+		NeedsSetup:           []*scan.Candidate{{JobID: "archive"}},
+		IneligibleJobs:       []*scan.IneligibleJob{{JobID: "image"}},
+		AlreadySlimJobs:      []*scan.AlreadySlimJob{{JobID: "fmt"}, {JobID: "notes"}},
+		IgnoredJobs:          []*scan.IgnoredJob{{JobID: "deploy"}},
+		OtherOSJobs:          []*scan.OtherOSJob{{JobID: "mac"}},
+		UnresolvedRunsOnJobs: []*scan.UnresolvedRunsOnJob{{JobID: "custom"}},
+		StaleJobs:            []*scan.Candidate{{JobID: "nightly"}},
+		Files:                3,
+	}
+
+	var buf bytes.Buffer
+	if err := printScanJSONSummary(&buf, result); err != nil {
+		t.Fatalf("printScanJSONSummary() error: %v", err)
+	}
+	want := `{
+  "eligible": 3,
+  "ineligible": 1,
+  "slim": 2,
+  "other": 4,
+  "files": 3
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printScanJSONSummary() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := printScanJSONSummary(&buf, &scan.ScanResult{}); err != nil {
+		t.Fatalf("printScanJSONSummary() error: %v", err)
+	}
+	if want := "{\n  \"eligible\": 0,\n  \"ineligible\": 0,\n  \"slim\": 0,\n  \"other\": 0,\n  \"files\": 0\n}\n"; buf.String() != want {
+		t.Errorf("printScanJSONSummary() of an empty result = %q, want %q", buf.String(), want)
+	}
+}
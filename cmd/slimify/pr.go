@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/git"
+)
+
+const prTitle = "Migrate eligible jobs to ubuntu-slim"
+
+// ensureCleanWorkingTree returns an error if the working tree has uncommitted changes.
+// --pr commits the files it modifies, so unrelated local changes must not be mixed in.
+func ensureCleanWorkingTree() error {
+	dirty, err := git.HasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check working tree: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("working tree has uncommitted changes. Commit or stash them before using --pr")
+	}
+	return nil
+}
+
+// openMigrationPR commits the updated workflow files on a new branch, pushes it
+// to origin and opens a pull request against the default branch.
+// The original branch is checked out again before returning. If the commit
+// fails, the new branch is deleted and the updated files are left uncommitted
+// on the original branch.
+func openMigrationPR(results []updateResult) (*api.PullRequest, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if r.isError || r.isNotFound || seen[r.workflowPath] {
+			continue
+		}
+		seen[r.workflowPath] = true
+		files = append(files, r.workflowPath)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no workflow files were updated")
+	}
+
+	host, owner, repo, err := api.GetRepoInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository info: %w", err)
+	}
+	if hostname != "" {
+		host = hostname
+	}
+	client, err := api.NewClient(host, owner, repo, apiRetries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	ctx := context.Background()
+	base, err := client.GetDefaultBranch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	originalBranch, err := git.CurrentBranch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	branch := fmt.Sprintf("slimify/ubuntu-slim-%s", time.Now().Format("20060102150405"))
+	if err := git.CreateBranch(branch); err != nil {
+		return nil, fmt.Errorf("failed to create branch: %w", err)
+	}
+	defer git.Checkout(originalBranch)
+	if err := git.Commit(prTitle, files...); err != nil {
+		if checkoutErr := git.Checkout(originalBranch); checkoutErr == nil {
+			_ = git.DeleteBranch(branch)
+		}
+		return nil, fmt.Errorf("failed to commit changes on %s: %w", branch, err)
+	}
+
+	if err := git.Push("origin", branch); err != nil {
+		return nil, fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+
+	pr, err := client.CreatePullRequest(ctx, prTitle, buildPRBody(results), branch, base)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// buildPRBody renders the pull request description listing the migrated jobs.
+func buildPRBody(results []updateResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This pull request migrates the following jobs to `%s` using [gh-slimify](https://github.com/fchimpan/gh-slimify).\n\n", slimLabel)
+	b.WriteString("| Workflow | Job | Line | Notes |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, r := range results {
+		if r.isError || r.isNotFound {
+			continue
+		}
+		notes := ""
+		if r.hasWarnings {
+			notes = "⚠️ has warnings, verify carefully"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | L%d | %s |\n", r.workflowPath, r.jobName, r.lineNumber, notes)
+	}
+	b.WriteString("\n> [!WARNING]\n> Migrating to `ubuntu-slim` may cause workflow instability or increased execution time. Please verify the workflows before merging.\n")
+	return b.String()
+}
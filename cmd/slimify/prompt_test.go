@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "y", input: "y\n", want: true},
+		{name: "yes in upper case", input: "YES\n", want: true},
+		{name: "empty answer", input: "\n", want: false},
+		{name: "n", input: "n\n", want: false},
+		{name: "other answer", input: "sure\n", want: false},
+		{name: "EOF", input: "", want: false},
+		{name: "y before EOF without a newline", input: "y", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := confirm(strings.NewReader(tt.input), &out, "Proceed?")
+			if err != nil {
+				t.Fatalf("confirm() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+			if !strings.HasPrefix(out.String(), "Proceed? [y/N] ") {
+				t.Errorf("confirm() wrote %q, want the question first", out.String())
+			}
+		})
+	}
+}
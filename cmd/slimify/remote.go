@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// enterRemoteRepo downloads the workflows of the repository given by --repo
+// into a temporary directory laid out like the repository, and makes it the
+// current directory, so that the scan runs as it would in a checkout.
+// Durations are skipped unless --with-duration is set, in which case they are
+// fetched for the remote repository. It returns the temporary directory.
+func enterRemoteRepo() (string, error) {
+	host, owner, repo, err := api.ParseRepo(remoteRepo)
+	if err != nil {
+		return "", err
+	}
+	if hostname != "" {
+		host = hostname
+	}
+	client, err := api.NewClient(host, owner, repo, apiRetries)
+	if err != nil {
+		return "", fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	// --output is given relative to where slimify runs, not the download
+	if outputPath != "" {
+		if outputPath, err = filepath.Abs(outputPath); err != nil {
+			return "", fmt.Errorf("--output: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "gh-slimify-remote-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	if err := downloadWorkflows(context.Background(), client, dir); err != nil {
+		os.RemoveAll(dir)
+		if errors.Is(err, api.ErrRateLimited) {
+			return "", fmt.Errorf("failed to fetch workflows of %s: %w. Wait for the limit to reset, or authenticate with gh auth login for a higher limit", remoteRepo, err)
+		}
+		return "", fmt.Errorf("failed to fetch workflows of %s: %w", remoteRepo, err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to change directory: %w", err)
+	}
+
+	if withDuration {
+		// Durations are looked up for the repository in GH_REPO, like gh does
+		os.Setenv("GH_REPO", remoteRepo)
+	} else {
+		skipDuration = true
+	}
+	return dir, nil
+}
+
+// downloadWorkflows downloads the workflow files of the default and --dir
+// workflow roots, the metadata of the local actions they use, and
+// .slimifyignore and .slimify.yml if the repository has them, into dir.
+func downloadWorkflows(ctx context.Context, client *api.Client, dir string) error {
+	roots := append([]string{workflow.DefaultWorkflowDir}, workflowDirs...)
+	for _, root := range roots {
+		if err := downloadDirectory(ctx, client, dir, filepath.ToSlash(filepath.Clean(root))); err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("workflow directory not found: %s", root)
+			}
+			return err
+		}
+	}
+	if err := downloadLocalActions(ctx, client, dir, roots); err != nil {
+		return err
+	}
+
+	for _, name := range []string{scan.IgnoreFileName, scan.ConfigFileName} {
+		content, err := client.GetFileContent(ctx, name)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadLocalActions downloads the metadata files of the local actions
+// (e.g. ./.github/actions/build) used by the workflows downloaded into dir
+// from roots, and of the local actions those use in turn, so that jobs using
+// them are classified as in a checkout. Workflows that can't be parsed are
+// left for the scan to report.
+func downloadLocalActions(ctx context.Context, client *api.Client, dir string, roots []string) error {
+	var rootDirs []string
+	for _, root := range roots {
+		rootDirs = append(rootDirs, filepath.Join(dir, root))
+	}
+	files, err := workflow.FindWorkflowFiles(rootDirs)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var queue []string
+	add := func(steps []workflow.Step) {
+		for _, step := range steps {
+			// Actions outside the repository can't be downloaded
+			if actionDir, ok := step.LocalActionPath(); ok && !seen[actionDir] && filepath.IsLocal(actionDir) {
+				seen[actionDir] = true
+				queue = append(queue, actionDir)
+			}
+		}
+	}
+	for _, file := range files {
+		wf, err := workflow.ParseFile(file)
+		if err != nil {
+			continue
+		}
+		for _, job := range wf.Jobs {
+			add(job.Steps)
+		}
+	}
+
+	for len(queue) > 0 {
+		actionDir := queue[0]
+		queue = queue[1:]
+		action, err := downloadLocalAction(ctx, client, dir, actionDir)
+		if err != nil {
+			return err
+		}
+		if action != nil {
+			add(action.Runs.Steps)
+		}
+	}
+	return nil
+}
+
+// downloadLocalAction downloads the action.yml or action.yaml file of the
+// local action in actionDir into the same path under dir, and returns the
+// action. It returns nil if the action has no metadata file or it is invalid,
+// which the scan skips as it does in a checkout.
+func downloadLocalAction(ctx context.Context, client *api.Client, dir, actionDir string) (*workflow.Action, error) {
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		remotePath := path.Join(filepath.ToSlash(actionDir), name)
+		content, err := client.GetFileContent(ctx, remotePath)
+		if errors.Is(err, api.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		localDir := filepath.Join(dir, actionDir)
+		if err := os.MkdirAll(localDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(localDir, name), content, 0644); err != nil {
+			return nil, err
+		}
+		action, err := workflow.LoadLocalAction(localDir)
+		if err != nil {
+			return nil, nil
+		}
+		return action, nil
+	}
+	return nil, nil
+}
+
+// downloadDirectory downloads the .yml and .yaml files under the repository
+// directory remoteDir, recursively, into the same path under dir.
+func downloadDirectory(ctx context.Context, client *api.Client, dir, remoteDir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(remoteDir)), 0755); err != nil {
+		return err
+	}
+
+	entries, err := client.ListDirectory(ctx, remoteDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		switch {
+		case entry.Type == "dir":
+			if err := downloadDirectory(ctx, client, dir, entry.Path); err != nil {
+				return err
+			}
+		case entry.Type == "file" && (strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml")):
+			content, err := client.GetFileContent(ctx, entry.Path)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(entry.Path)), content, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prefixRemotePaths prefixes the workflow paths in result with the repository
+// given by --repo (e.g. owner/repo/.github/workflows/ci.yml), so that the
+// output shows where the files are. It does nothing without --repo.
+func prefixRemotePaths(result *scan.ScanResult) {
+	if remoteRepo == "" {
+		return
+	}
+	_, owner, repo, err := api.ParseRepo(remoteRepo)
+	if err != nil {
+		return
+	}
+	// Jobs reached through one reusable workflow call share their Caller, so
+	// each caller is prefixed once
+	callers := make(map[*scan.Caller]bool)
+	prefix := func(workflowPath *string, caller *scan.Caller) {
+		*workflowPath = path.Join(owner, repo, filepath.ToSlash(*workflowPath))
+		if caller != nil && !callers[caller] {
+			callers[caller] = true
+			caller.WorkflowPath = path.Join(owner, repo, filepath.ToSlash(caller.WorkflowPath))
+		}
+	}
+
+	for _, c := range result.AllCandidates() {
+		prefix(&c.WorkflowPath, c.Caller)
+	}
+	for _, j := range result.IneligibleJobs {
+		prefix(&j.WorkflowPath, j.Caller)
+	}
+	for _, j := range result.AlreadySlimJobs {
+		prefix(&j.WorkflowPath, j.Caller)
+	}
+	for _, j := range result.IgnoredJobs {
+		prefix(&j.WorkflowPath, j.Caller)
+	}
+	for _, j := range result.OtherOSJobs {
+		prefix(&j.WorkflowPath, j.Caller)
+	}
+	for _, j := range result.UnresolvedRunsOnJobs {
+		prefix(&j.WorkflowPath, j.Caller)
+	}
+	for _, c := range result.StaleJobs {
+		prefix(&c.WorkflowPath, c.Caller)
+	}
+	for i := range result.ParseErrors {
+		prefix(&result.ParseErrors[i].Path, nil)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// roundTripFunc serves API requests in tests
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func TestDownloadWorkflows_LocalActions(t *testing.T) {
+	// Files of owner/repo served by the contents API
+	files := map[string]string{
+		".github/workflows/ci.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/build
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/setup
+      - run: make lint
+`,
+		".github/actions/build/action.yml": `runs:
+  using: composite
+  steps:
+    - uses: ./.github/actions/image
+`,
+		".github/actions/image/action.yaml": `runs:
+  using: composite
+  steps:
+    - run: docker build .
+      shell: bash
+`,
+		".github/actions/setup/action.yml": `runs:
+  using: composite
+  steps:
+    - run: make setup
+      shell: bash
+`,
+	}
+
+	prevTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) *http.Response {
+		respond := func(status int, body any) *http.Response {
+			data, _ := json.Marshal(body)
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(string(data))),
+				Request:    req,
+			}
+		}
+		filePath := strings.TrimPrefix(req.URL.Path, "/repos/owner/repo/contents/")
+		if filePath == ".github/workflows" {
+			return respond(http.StatusOK, []api.ContentEntry{{Name: "ci.yml", Path: ".github/workflows/ci.yml", Type: "file"}})
+		}
+		content, ok := files[filePath]
+		if !ok {
+			return respond(http.StatusNotFound, map[string]string{"message": "Not Found"})
+		}
+		return respond(http.StatusOK, map[string]string{"content": base64.StdEncoding.EncodeToString([]byte(content)), "encoding": "base64"})
+	})
+	t.Cleanup(func() { http.DefaultTransport = prevTransport })
+	t.Setenv("GH_TOKEN", "token")
+
+	client, err := api.NewClient("github.com", "owner", "repo", 0)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	dir := t.TempDir()
+	if err := downloadWorkflows(context.Background(), client, dir); err != nil {
+		t.Fatalf("downloadWorkflows() error: %v", err)
+	}
+
+	// The scan of the download classifies the jobs as in a checkout
+	t.Chdir(dir)
+	result, err := scan.Scan(scan.Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	var candidates, ineligible []string
+	for _, c := range result.AllCandidates() {
+		candidates = append(candidates, c.JobID)
+	}
+	for _, j := range result.IneligibleJobs {
+		ineligible = append(ineligible, j.JobID)
+	}
+	if strings.Join(candidates, ",") != "lint" || strings.Join(ineligible, ",") != "build" {
+		t.Errorf("candidates = %v, ineligible = %v, want [lint] and [build] using Docker through a local action", candidates, ineligible)
+	}
+}
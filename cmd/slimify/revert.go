@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+func runRevert(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText {
+		fmt.Fprintf(os.Stderr, "Error: revert does not support --format %s\n", outputFormat)
+		os.Exit(exitError)
+	}
+
+	var files []string
+	files = append(files, args...)
+	files = append(files, workflowFiles...)
+
+	// Without explicit files, restore every backed-up workflow
+	if len(files) == 0 {
+		found, err := workflow.FindWorkflowFiles(append([]string{workflow.DefaultWorkflowDir}, workflowDirs...))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		for _, path := range found {
+			if workflow.HasBackup(path) {
+				files = append(files, path)
+			}
+		}
+		if len(files) == 0 {
+			fmt.Printf("No backups found. Run fix with --backup to create them.\n")
+			return
+		}
+	}
+
+	errorCount := 0
+	for _, path := range files {
+		if err := workflow.Restore(path); err != nil {
+			fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+			errorCount++
+			continue
+		}
+		fmt.Printf("  ✓ Restored %s\n", path)
+	}
+
+	fmt.Println()
+	fmt.Printf("Restored %d file(s).\n", len(files)-errorCount)
+	if errorCount > 0 {
+		fmt.Fprintf(os.Stderr, "Encountered %d error(s) during revert.\n", errorCount)
+		os.Exit(exitError)
+	}
+}
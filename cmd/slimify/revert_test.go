@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestRevert_AfterRepeatedFix(t *testing.T) {
+	const content = `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`
+
+	prevSlimLabel, prevBackup, prevFormat := slimLabel, backupFiles, outputFormat
+	slimLabel, backupFiles, outputFormat = "ubuntu-slim", true, formatText
+	t.Cleanup(func() { slimLabel, backupFiles, outputFormat = prevSlimLabel, prevBackup, prevFormat })
+
+	path := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	// A second fix, such as with --force, migrates the job the first one left
+	for _, job := range []*scan.Candidate{
+		{WorkflowPath: path, JobID: "lint", JobName: "lint", LineNumber: 4, SourceLabel: "ubuntu-latest"},
+		{WorkflowPath: path, JobID: "test", JobName: "test", LineNumber: 8, SourceLabel: "ubuntu-latest"},
+	} {
+		for _, r := range updateWorkflowFile(path, []*scan.Candidate{job}) {
+			if r.isError {
+				t.Fatalf("updateWorkflowFile() error for %s: %s", job.JobID, r.errorMsg)
+			}
+		}
+	}
+
+	runRevert(nil, []string{path})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read workflow file: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("workflow after revert:\n%s\nwant:\n%s", data, content)
+	}
+	if workflow.HasBackup(path) {
+		t.Errorf("HasBackup() = true after revert")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// runReview scans workflows, lets the user pick the candidates to migrate in
+// a terminal UI and migrates the picked ones like fix.
+func runReview(cmd *cobra.Command, args []string) {
+	filesToScan := resolveFiles(args, "review")
+	threshold := parseMinConfidence()
+
+	if outputFormat != formatText {
+		fmt.Fprintf(os.Stderr, "Error: review does not support --format %s\n", outputFormat)
+		os.Exit(exitError)
+	}
+	// Check the terminal before scanning, which may spend API calls
+	if reason := reviewUnsupported(); reason != "" {
+		fmt.Fprintf(os.Stderr, "Error: %s. Use gh slimify fix to migrate jobs instead\n", reason)
+		os.Exit(exitError)
+	}
+
+	sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
+	sp.Suffix = " Scanning workflows..."
+	sp.Start()
+	result, err := scan.Scan(scanOptions(filesToScan, sp))
+	sp.Stop()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	checkStrict(result)
+	filterByConfidence(result, threshold)
+
+	candidates := result.AllCandidates()
+	if len(candidates) == 0 {
+		fmt.Printf("No jobs found that can be migrated to %s.\n", slimLabel)
+		checkParseErrors(result, false)
+		return
+	}
+
+	selected, err := tui.Review(reviewItems(candidates), slimLabel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(os.Stderr, "No jobs selected. No files were modified.")
+		return
+	}
+	applyFixes(selected, nil, false)
+	checkParseErrors(result, false)
+}
+
+// reviewUnsupported returns why the review UI can't run, or "" if it can:
+// it needs stdin and stdout to be a terminal of at least tui.MinWidth by
+// tui.MinHeight.
+func reviewUnsupported() string {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return "review needs an interactive terminal"
+	}
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width < tui.MinWidth || height < tui.MinHeight {
+		return fmt.Sprintf("review needs a terminal of at least %dx%d", tui.MinWidth, tui.MinHeight)
+	}
+	return ""
+}
+
+// reviewItems lists candidates for review, safe jobs first and selected.
+// Jobs that require attention are listed with their warnings and left
+// unselected, like fix leaves them without --force.
+func reviewItems(candidates []*scan.Candidate) []tui.Item {
+	safeJobs, warningJobs := classifyCandidates(candidates)
+	if installMissing {
+		safeJobs, warningJobs = promoteInstallable(safeJobs, warningJobs)
+	}
+
+	var items []tui.Item
+	for _, job := range safeJobs {
+		items = append(items, tui.Item{Candidate: job, Selected: true})
+	}
+	for _, job := range warningJobs {
+		var reasons []string
+		if len(job.MissingCommands) > 0 {
+			reasons = append(reasons, "requires installing: "+strings.Join(job.MissingCommands, ", "))
+		}
+		if job.MayIncreaseRuntime() {
+			reasons = append(reasons, describeManyInstalls(job))
+		}
+		if job.Duration == "" || job.Duration == "unknown" {
+			reasons = append(reasons, "execution time unknown")
+		}
+		items = append(items, tui.Item{Candidate: job, Warning: strings.Join(reasons, "; ")})
+	}
+	return items
+}
@@ -2,24 +2,109 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"runtime"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/fchimpan/gh-slimify/internal/api"
+	"github.com/fchimpan/gh-slimify/internal/ghapi"
+	"github.com/fchimpan/gh-slimify/internal/report"
 	"github.com/fchimpan/gh-slimify/internal/scan"
 	"github.com/fchimpan/gh-slimify/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
 var (
-	workflowFiles []string
-	scanAll       bool
-	skipDuration  bool
-	verbose       bool
-	force         bool
-	jsonOutput    bool
+	workflowFiles      []string
+	scanAll            bool
+	skipDuration       bool
+	hostname           string
+	apiRetries         int
+	debugTiming        bool
+	verbose            bool
+	quiet              bool
+	force              bool
+	installMissing     bool
+	assumeYes          bool
+	createPR           bool
+	backupFiles        bool
+	minConfidence      string
+	since              string
+	repoRoot           string
+	jsonOutput         bool
+	outputFormat       string
+	groupBy            string
+	dedupe             bool
+	watch              bool
+	sourceLabels       []string
+	slimLabel          string
+	workflowDirs       []string
+	concurrency        int
+	hasCommands        []string
+	outputPath         string
+	noColor            bool
+	dockerActions      []string
+	minActionVersions  []string
+	resolveVars        []string
+	noCache            bool
+	remoteRepo         string
+	withDuration       bool
+	priceStandard      float64
+	priceSlim          float64
+	failIneligible     bool
+	exitCode           bool
+	strict             bool
+	includeAlreadySlim bool
+	logLevel           string
+	readStdin          bool
+	stdinFilename      string
+	needsGraph         bool
+	baseRef            string
 )
 
+// Output formats supported by --format.
+const (
+	formatText        = "text"
+	formatJSON        = "json"
+	formatJSONSummary = "json-summary" // Only the totals of a scan
+	formatSARIF       = "sarif"
+	formatGitHub      = "github"
+	formatMarkdown    = "markdown"
+	formatCSV         = "csv"
+	formatDOT         = "dot" // Only with --needs-graph
+)
+
+// outputFormats lists the values accepted by --format.
+var outputFormats = []string{formatText, formatJSON, formatJSONSummary, formatSARIF, formatGitHub, formatMarkdown, formatCSV, formatDOT}
+
+// verbosity controls how much of the scan result is printed in text format.
+type verbosity int
+
+const (
+	// verbosityQuiet prints only the total number of eligible jobs.
+	verbosityQuiet verbosity = iota
+	// verbosityNormal prints migration candidates and ignored jobs.
+	verbosityNormal
+	// verbosityVerbose also prints ineligible jobs with reasons and already-slim jobs.
+	verbosityVerbose
+)
+
+// outputVerbosity returns the verbosity selected by --quiet and --verbose.
+func outputVerbosity() verbosity {
+	switch {
+	case quiet:
+		return verbosityQuiet
+	case verbose:
+		return verbosityVerbose
+	default:
+		return verbosityNormal
+	}
+}
+
 func newRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "slimify [flags] [workflow-file...]",
@@ -28,16 +113,62 @@ func newRootCmd() *cobra.Command {
 eligible ubuntu-latest jobs to ubuntu-slim.
 
 By default, you must specify workflow file(s) to process. Use --all to scan all
-workflows in .github/workflows/*.yml.`,
-		Run:  runScan,
-		Args: cobra.ArbitraryArgs,
+workflows in .github/workflows, and --dir to add more workflow directories
+(e.g. in monorepos).`,
+		Run:               runScan,
+		Args:              cobra.ArbitraryArgs,
+		PersistentPreRunE: preRun,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
 	}
 
+	rootCmd.PersistentFlags().StringVar(&repoRoot, "repo-root", "", "Repository root to run in instead of the current directory. It need not be a git checkout. Workflow paths, .slimifyignore and --output are resolved from it")
 	rootCmd.PersistentFlags().StringArrayVarP(&workflowFiles, "file", "f", []string{}, "Specify workflow file(s) to process. Can be specified multiple times (e.g., -f .github/workflows/ci.yml -f .github/workflows/test.yml)")
-	rootCmd.PersistentFlags().BoolVar(&scanAll, "all", false, "Scan all workflow files in .github/workflows/*.yml")
+	rootCmd.PersistentFlags().BoolVar(&scanAll, "all", false, "Scan all workflow files (*.yml, *.yaml) in .github/workflows")
+	rootCmd.PersistentFlags().StringArrayVar(&workflowDirs, "dir", []string{}, "Additional directory to scan recursively for workflow files, besides .github/workflows. Can be specified multiple times. Implies --all")
+	rootCmd.PersistentFlags().StringArrayVar(&hasCommands, "has-command", []string{}, "Command available on your ubuntu-slim runners that is not installed by default (e.g. jq). Not reported as missing. Can be specified multiple times")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Don't reuse or store the results of unchanged workflow files (cached in .git/slimify-cache)")
+	rootCmd.PersistentFlags().StringArrayVar(&minActionVersions, "min-action-version", []string{}, "Oldest version of an action expected to work on ubuntu-slim, overriding the built-in one (e.g. actions/checkout@v4). Candidates pinning an older major version get a note. Can be specified multiple times")
+	rootCmd.PersistentFlags().StringArrayVar(&resolveVars, "resolve-var", []string{}, "Value of a variable used in runs-on, as NAME=value (e.g. LINUX_RUNNER=ubuntu-latest), substituted for ${{ vars.NAME }} and ${{ env.NAME }}. Jobs using other variables in runs-on are reported as unresolved. Can be specified multiple times")
+	rootCmd.PersistentFlags().StringArrayVar(&dockerActions, "docker-action", []string{}, "Action that needs a Docker daemon, besides the built-in list (e.g. my-org/scan-image). Matches sub-actions too; a value ending in / matches every action of an owner. Can be specified multiple times")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Stop before writing any output if a workflow file can't be parsed, instead of skipping it and reporting it with the results")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Maximum number of workflow files to parse in parallel")
 	rootCmd.PersistentFlags().BoolVar(&skipDuration, "skip-duration", false, "Skip fetching job execution durations from GitHub API to avoid unnecessary API calls")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output including debug warnings")
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	rootCmd.PersistentFlags().StringVar(&hostname, "hostname", "", "GitHub host to send API requests to, such as a GitHub Enterprise Server (e.g. ghe.example.com). Defaults to the host of the repository, or GH_HOST or gh's default host when it is not known")
+	rootCmd.PersistentFlags().IntVar(&apiRetries, "api-retries", ghapi.DefaultRetries, "Number of times a GitHub API request rejected by a rate limit is retried, waiting as long as the API asks or with exponential backoff. 0 disables retries")
+	rootCmd.PersistentFlags().BoolVar(&debugTiming, "debug-timing", false, "Print to stderr how long each phase of the scan took, with the number of files and jobs")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Level of the diagnostic logs written to stderr (debug, info, warn, error). debug traces how each job was classified")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output including debug warnings, ineligible jobs and already-slim jobs")
+	rootCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print the total number of jobs eligible for migration")
+	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored text output. Color is also disabled when NO_COLOR is set or the output is not a terminal")
+	rootCmd.Flags().Float64Var(&priceStandard, "price-standard", scan.DefaultPricing.Standard, "Per-minute price in USD of the runners jobs are migrated from, used to estimate savings")
+	rootCmd.Flags().Float64Var(&priceSlim, "price-slim", scan.DefaultPricing.Slim, "Per-minute price in USD of ubuntu-slim runners, used to estimate savings")
+	rootCmd.Flags().BoolVar(&failIneligible, "fail-on-ineligible", false, "Exit with status 1 if an ineligible job on a migration source label has no \"# slimify-ignore: <reason>\" comment explaining why it can't migrate")
+	rootCmd.Flags().StringVar(&baseRef, "base-ref", "", "Only report candidates that are not candidates at this git ref as well (e.g. origin/main), so that a pull request check flags only the jobs it introduces")
+	rootCmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with status 1 if any job can be migrated to ubuntu-slim, or a job on ubuntu-slim no longer looks safe with --include-already-slim")
+	rootCmd.Flags().BoolVar(&includeAlreadySlim, "include-already-slim", false, "Check jobs already on ubuntu-slim and report those using features it doesn't support (e.g. newly added Docker commands) as regressions")
+	rootCmd.Flags().BoolVar(&readStdin, "stdin", false, "Read a single workflow from stdin instead of files and print the results as JSON unless --format is set")
+	rootCmd.Flags().StringVar(&stdinFilename, "filename", "<stdin>", "Path to label the workflow read with --stdin in the output (e.g. .github/workflows/ci.yml)")
+	rootCmd.Flags().StringVarP(&remoteRepo, "repo", "R", "", "Scan the workflows of a remote repository ([HOST/]OWNER/REPO) through the GitHub API, without cloning it")
+	rootCmd.Flags().BoolVar(&withDuration, "with-duration", false, "Fetch job durations for --repo, which are skipped by default")
+	rootCmd.MarkFlagsMutuallyExclusive("repo", "repo-root")
+	rootCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Scan again whenever a workflow file changes, reprinting the results, until interrupted with Ctrl+C")
+	rootCmd.MarkFlagsMutuallyExclusive("repo", "stdin")
+	rootCmd.MarkFlagsMutuallyExclusive("watch", "repo")
+	rootCmd.MarkFlagsMutuallyExclusive("watch", "stdin")
+	rootCmd.Flags().StringVar(&minConfidence, "min-confidence", string(scan.ConfidenceLow), "Only report candidates with at least this confidence (low, medium, high)")
+	rootCmd.Flags().StringVar(&since, "since", "", "Report candidates whose last successful run is older than this window (e.g. 90d, 2w, 12h) as stale instead of as candidates. Needs job durations, so it can't be combined with --skip-duration")
+	rootCmd.Flags().StringVar(&groupBy, "group-by", groupByFile, fmt.Sprintf("How to group jobs in text output (%s). reason lists ineligible jobs under each reason that blocks them", strings.Join(groupByValues, ", ")))
+	rootCmd.Flags().BoolVar(&needsGraph, "needs-graph", false, "Print the needs: dependency graph of each workflow's jobs with their migration status instead of the scan results. Leaf jobs, which no other job needs, are the lowest risk to migrate. Supports --format text, json and dot")
+	rootCmd.MarkFlagsMutuallyExclusive("needs-graph", "watch")
+	rootCmd.MarkFlagsMutuallyExclusive("needs-graph", "stdin")
+	rootCmd.Flags().BoolVar(&dedupe, "dedupe", false, "In text output, list jobs with the same name and steps in several workflows (e.g. copied from a template) once, with the number of workflows they appear in")
+	rootCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Write the results to a file instead of stdout, creating parent directories if needed")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output results as JSON (shorthand for --format json)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", formatText, fmt.Sprintf("Output format (%s)", strings.Join(outputFormats, ", ")))
+	rootCmd.PersistentFlags().StringVar(&slimLabel, "slim-label", workflow.DefaultSlimLabel, "runs-on label of your slim runners (e.g. self-hosted-slim). Jobs on it count as already migrated, and fix writes it")
+	rootCmd.PersistentFlags().StringArrayVar(&sourceLabels, "from", []string{}, "Runner label to consider as a migration source. Can be specified multiple times (e.g., --from ubuntu-latest --from ubuntu-24.04). Defaults to ubuntu-latest")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
 
 	fixCmd := &cobra.Command{
 		Use:   "fix [flags] [workflow-file...]",
@@ -46,17 +177,153 @@ workflows in .github/workflows/*.yml.`,
 all migration criteria. By default, only safe jobs (no missing commands and known execution time)
 are updated. Use --force to also update jobs with warnings.
 
+Before any file is modified, the jobs to be migrated are listed and you are
+asked to confirm. Use --yes to skip the prompt in non-interactive environments.
+
+Use --pr to commit the changes on a new branch, push it to origin and open a
+pull request against the default branch. The working tree must be clean.
+
+Use --backup to save a copy of each workflow before it is modified, and the
+revert command to restore the copies.
+
 By default, you must specify workflow file(s) to process. Use --all to scan all
-workflows in .github/workflows/*.yml.`,
+workflows (*.yml, *.yaml) in .github/workflows.`,
 		Run:  runFix,
 		Args: cobra.ArbitraryArgs,
 	}
 	fixCmd.Flags().BoolVar(&force, "force", false, "Also update jobs with warnings (missing commands or unknown execution time)")
+	fixCmd.Flags().BoolVar(&installMissing, "install-missing", false, "Add a step installing commands missing in ubuntu-slim with apt-get to each migrated job. Jobs whose only warning is missing commands are updated without --force")
+	fixCmd.Flags().BoolVar(&createPR, "pr", false, "Commit the changes on a new branch, push it and open a pull request")
+	fixCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Apply changes without asking for confirmation (required when stdin is not a terminal)")
+	fixCmd.Flags().StringVar(&minConfidence, "min-confidence", string(scan.ConfidenceLow), "Only update candidates with at least this confidence (low, medium, high)")
+	fixCmd.Flags().BoolVar(&backupFiles, "backup", false, "Save a copy of each workflow as <file>"+workflow.BackupSuffix+" before modifying it, so that revert can restore it")
+	fixCmd.MarkFlagsMutuallyExclusive("backup", "pr")
+
+	statsCmd := &cobra.Command{
+		Use:   "stats [flags] [workflow-file...]",
+		Short: "Summarize migration progress across workflows",
+		Long: `Scan workflows and print aggregate counts: total jobs, jobs eligible for
+migration, ineligible jobs broken down by reason, jobs already using ubuntu-slim,
+and the percentage of jobs already migrated.
+
+Use --format json to feed the numbers into dashboards.
+
+By default, you must specify workflow file(s) to process. Use --all to scan all
+workflows in .github/workflows.`,
+		Run:  runStats,
+		Args: cobra.ArbitraryArgs,
+	}
+
+	explainCmd := &cobra.Command{
+		Use:   "explain [flags] <workflow-file> <job-id>",
+		Short: "Explain why a single job can or cannot be migrated",
+		Long: `Print a detailed eligibility breakdown for a single job: the labels its
+runs-on resolves to, the outcome of every migration criterion, commands
+missing in ubuntu-slim and the final verdict.
+
+The job is evaluated with the same checks as a scan. Unlike a scan, every
+check is reported even if an earlier one already failed.`,
+		Example: "  gh slimify explain .github/workflows/ci.yml build",
+		Run:     runExplain,
+		Args:    cobra.ExactArgs(2),
+	}
+
+	reviewCmd := &cobra.Command{
+		Use:   "review [flags] [workflow-file...]",
+		Short: "Pick the jobs to migrate to ubuntu-slim interactively",
+		Long: `Scan workflows and list the jobs that can be migrated with a checkbox
+each. Safe jobs start selected and jobs with warnings unselected. Toggle jobs
+with space, select all with a, and press enter to migrate the selected jobs
+like fix does, or q to quit without modifying any file.
+
+review needs an interactive terminal. In scripts and CI, use fix instead.
+
+By default, you must specify workflow file(s) to process. Use --all to scan all
+workflows in .github/workflows.`,
+		Run:  runReview,
+		Args: cobra.ArbitraryArgs,
+	}
+	reviewCmd.Flags().BoolVar(&installMissing, "install-missing", false, "Add a step installing commands missing in ubuntu-slim with apt-get to each migrated job, and select jobs whose only warning is missing commands")
+	reviewCmd.Flags().StringVar(&minConfidence, "min-confidence", string(scan.ConfidenceLow), "Only list candidates with at least this confidence (low, medium, high)")
+	reviewCmd.Flags().BoolVar(&backupFiles, "backup", false, "Save a copy of each workflow as <file>"+workflow.BackupSuffix+" before modifying it, so that revert can restore it")
+
+	revertCmd := &cobra.Command{
+		Use:   "revert [flags] [workflow-file...]",
+		Short: "Restore workflows backed up by fix --backup",
+		Long: `Undo a fix run with --backup by restoring each workflow from the
+<file>` + workflow.BackupSuffix + ` copy saved next to it, leaving the file exactly as it was
+before the fix. Restored backups are removed.
+
+Without arguments, every backed-up workflow in .github/workflows and any --dir
+is restored.`,
+		Example: "  gh slimify fix --all --backup\n  gh slimify revert",
+		Run:     runRevert,
+		Args:    cobra.ArbitraryArgs,
+	}
 
 	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(revertCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(newInitActionCmd())
+	rootCmd.AddCommand(newCompletionCmd())
+	rootCmd.AddCommand(newManCmd())
+	registerCompletions(rootCmd, fixCmd, reviewCmd, statsCmd, explainCmd)
 	return rootCmd
 }
 
+// preRun runs before every command. It switches to --repo-root, so that
+// workflows, .slimifyignore, local actions and the git remote are all found
+// relative to it, and resolves the output format.
+func preRun(cmd *cobra.Command, args []string) error {
+	if err := setupLogger(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(slimLabel) == "" {
+		return fmt.Errorf("--slim-label must not be empty")
+	}
+	if apiRetries < 0 {
+		return fmt.Errorf("--api-retries must not be negative")
+	}
+	if repoRoot != "" {
+		if err := os.Chdir(repoRoot); err != nil {
+			return fmt.Errorf("--repo-root: %w", err)
+		}
+	}
+	return resolveOutputFormat(cmd, args)
+}
+
+// resolveOutputFormat validates --format and reconciles it with the --json shorthand.
+// The scan command defaults to GitHub annotations when run in GitHub Actions.
+// After it runs, jsonOutput is true if and only if the output format is JSON.
+func resolveOutputFormat(cmd *cobra.Command, _ []string) error {
+	// Surface scan results as annotations when running in GitHub Actions,
+	// unless a format was chosen explicitly
+	if cmd == cmd.Root() && !jsonOutput && !cmd.Flags().Changed("format") && os.Getenv("GITHUB_ACTIONS") == "true" {
+		outputFormat = formatGitHub
+	}
+
+	// Editor integrations piping a workflow in expect JSON back
+	if cmd == cmd.Root() && readStdin && !jsonOutput && !cmd.Flags().Changed("format") {
+		outputFormat = formatJSON
+	}
+
+	if jsonOutput {
+		if cmd.Flags().Changed("format") && outputFormat != formatJSON {
+			return fmt.Errorf("--json cannot be combined with --format %s", outputFormat)
+		}
+		outputFormat = formatJSON
+	}
+
+	if !slices.Contains(outputFormats, outputFormat) {
+		return fmt.Errorf("invalid --format %q: must be one of %s", outputFormat, strings.Join(outputFormats, ", "))
+	}
+
+	jsonOutput = outputFormat == formatJSON
+	return nil
+}
+
 // resolveFiles collects workflow files from args and flags, validates input,
 // and returns the list of files to scan.
 // subcommand should be "" for the root command or the subcommand name (e.g. "fix").
@@ -65,7 +332,9 @@ func resolveFiles(args []string, subcommand string) []string {
 	files = append(files, args...)
 	files = append(files, workflowFiles...)
 
-	if !scanAll && len(files) == 0 {
+	scanDirs := scanAll || len(workflowDirs) > 0
+
+	if !scanDirs && len(files) == 0 {
 		prefix := ""
 		if subcommand != "" {
 			prefix = subcommand + " "
@@ -73,80 +342,412 @@ func resolveFiles(args []string, subcommand string) []string {
 		fmt.Fprintf(os.Stderr, "Error: no workflow files specified. Use --all to scan all workflows, or specify workflow file(s) as arguments or with --file flag.\n")
 		fmt.Fprintf(os.Stderr, "Example: gh slimify %s.github/workflows/ci.yml\n", prefix)
 		fmt.Fprintf(os.Stderr, "Example: gh slimify %s--all\n", prefix)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
-	if scanAll {
+	if scanAll || len(files) == 0 {
 		return []string{}
 	}
 	return files
 }
 
+// spinnerProgress returns a scan progress callback that shows which job's
+// duration is being fetched in the suffix of sp, e.g. "Scanning job 12/45...".
+// The spinner only draws to a terminal and erases its line when stopped, so
+// nothing is left in the output. It returns nil if sp is nil.
+func spinnerProgress(sp *spinner.Spinner) func(done, total int) {
+	if sp == nil {
+		return nil
+	}
+	return func(done, total int) {
+		sp.Lock()
+		sp.Suffix = fmt.Sprintf(" Scanning job %d/%d...", done, total)
+		sp.Unlock()
+	}
+}
+
 func runScan(cmd *cobra.Command, args []string) {
+	if priceStandard < 0 || priceSlim < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --price-standard and --price-slim must not be negative\n")
+		os.Exit(exitError)
+	}
+
+	threshold := parseMinConfidence()
+	window := parseSince()
+	checkGroupBy()
+	checkNeedsGraph()
+	checkBaseRef()
+
+	if readStdin {
+		if window > 0 {
+			fmt.Fprintf(os.Stderr, "Error: --since cannot be combined with --stdin, which doesn't fetch job durations\n")
+			os.Exit(exitError)
+		}
+		runScanStdin(args, threshold)
+		return
+	}
+	if withDuration && remoteRepo == "" {
+		fmt.Fprintf(os.Stderr, "Error: --with-duration requires --repo\n")
+		os.Exit(exitError)
+	}
 	filesToScan := resolveFiles(args, "")
+	if remoteRepo != "" {
+		dir, err := enterRemoteRepo()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		defer os.RemoveAll(dir)
+	}
+	if window > 0 && skipDuration {
+		fmt.Fprintf(os.Stderr, "Error: --since needs job durations; it cannot be combined with --skip-duration, or with --repo unless --with-duration is set\n")
+		os.Exit(exitError)
+	}
+	if watch {
+		runWatch(filesToScan, threshold, window)
+		return
+	}
 
-	if !jsonOutput {
-		sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
-		sp.Suffix = " Scanning workflows..."
-		sp.Start()
+	if outputFormat == formatText {
+		level := outputVerbosity()
 
-		result, err := scan.Scan(skipDuration, verbose, filesToScan...)
-		sp.Stop()
+		var sp *spinner.Spinner
+		if level > verbosityQuiet {
+			sp = spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
+			sp.Suffix = " Scanning workflows..."
+			sp.Start()
+		}
+
+		result, err := scan.Scan(scanOptions(filesToScan, sp))
+		if sp != nil {
+			sp.Stop()
+		}
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "✗ Scan failed\n")
+			if level > verbosityQuiet {
+				fmt.Fprintf(os.Stderr, "✗ Scan failed\n")
+			}
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 
-		fmt.Fprintf(os.Stderr, "✓ Scan complete\n")
-		printScanText(result)
+		if level > verbosityQuiet {
+			fmt.Fprintf(os.Stderr, "✓ Scan complete\n")
+		}
+		checkStrict(result)
+		filterBaseRef(result, filesToScan)
+		filterByConfidence(result, threshold)
+		separateStale(result, window)
+		filterSlimRegressions(result)
+		if needsGraph {
+			writeNeedsGraph(result)
+			return
+		}
+		prefixRemotePaths(result)
+		if err := writeScanResult(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		checkResult(result)
 		return
 	}
 
-	// JSON output path
-	result, err := scan.Scan(skipDuration, verbose, filesToScan...)
+	// Machine-readable output path
+	result, err := scan.Scan(scanOptions(filesToScan, nil))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	checkStrict(result)
+	filterBaseRef(result, filesToScan)
+	filterByConfidence(result, threshold)
+	separateStale(result, window)
+	filterSlimRegressions(result)
+	if needsGraph {
+		writeNeedsGraph(result)
+		return
+	}
+	prefixRemotePaths(result)
+	if err := writeScanResult(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	checkResult(result)
+}
+
+// scanOptions returns the scan options set by the flags, for the workflow
+// files in paths. sp, if non-nil, is the spinner shown during the scan, which
+// reports progress fetching durations.
+func scanOptions(paths []string, sp *spinner.Spinner) scan.Options {
+	return scan.Options{
+		Paths:             paths,
+		Dirs:              workflowDirs,
+		SkipDuration:      skipDuration,
+		Verbose:           verbose,
+		Hostname:          hostname,
+		APIRetries:        apiRetries,
+		SourceLabels:      sourceLabels,
+		SlimLabel:         slimLabel,
+		Concurrency:       concurrency,
+		AvailableCommands: hasCommands,
+		DockerActions:     dockerActions,
+		MinActionVersions: parseMinActionVersions(),
+		ResolveVars:       parseResolveVars(),
+		Cache:             scanCache(),
+		Progress:          spinnerProgress(sp),
+		Timing:            timingReporter(sp),
+	}
+}
+
+// runScanStdin scans the single workflow piped to stdin with --stdin.
+func runScanStdin(args []string, threshold scan.Confidence) {
+	if len(args) > 0 || len(workflowFiles) > 0 || scanAll || len(workflowDirs) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: --stdin cannot be combined with workflow files, --all or --dir\n")
+		os.Exit(exitError)
+	}
+
+	result, err := scan.ScanReader(os.Stdin, stdinFilename, scanOptions(nil, nil))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	filterByConfidence(result, threshold)
+	filterSlimRegressions(result)
+	if err := writeScanResult(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	checkResult(result)
+}
+
+// parseMinActionVersions parses --min-action-version into minimum major
+// versions by action, exiting on an invalid value.
+func parseMinActionVersions() map[string]int {
+	versions := make(map[string]int, len(minActionVersions))
+	for _, v := range minActionVersions {
+		action, major, err := workflow.ParseMinActionVersion(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --min-action-version: %v\n", err)
+			os.Exit(exitError)
+		}
+		versions[action] = major
+	}
+	return versions
+}
+
+// parseResolveVars parses --resolve-var into values by variable name, exiting
+// on an invalid value.
+func parseResolveVars() map[string]string {
+	values := make(map[string]string, len(resolveVars))
+	for _, v := range resolveVars {
+		name, value, ok := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			fmt.Fprintf(os.Stderr, "Error: --resolve-var: invalid value %q, expected NAME=value (e.g. LINUX_RUNNER=ubuntu-latest)\n", v)
+			os.Exit(exitError)
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// parseMinConfidence parses --min-confidence, exiting on an invalid level.
+func parseMinConfidence() scan.Confidence {
+	threshold, err := scan.ParseConfidence(minConfidence)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --min-confidence: %v\n", err)
+		os.Exit(exitError)
+	}
+	return threshold
+}
+
+// filterByConfidence removes candidates below threshold from result, noting
+// how many were removed in text output.
+func filterByConfidence(result *scan.ScanResult, threshold scan.Confidence) {
+	removed := result.FilterByConfidence(threshold)
+	if removed > 0 && outputFormat == formatText && outputVerbosity() > verbosityQuiet {
+		fmt.Fprintf(os.Stderr, "ℹ️  %d candidate(s) below --min-confidence %s are not shown\n", removed, threshold)
+	}
+}
+
+// parseSince parses --since, exiting on an invalid window. It returns 0 if
+// --since is not set.
+func parseSince() time.Duration {
+	if since == "" {
+		return 0
+	}
+	window, err := scan.ParseSince(since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --since: %v\n", err)
+		os.Exit(exitError)
+	}
+	return window
+}
+
+// separateStale moves candidates that have not run within window to the
+// result's stale jobs. A zero window keeps every candidate.
+func separateStale(result *scan.ScanResult, window time.Duration) {
+	if window > 0 {
+		result.SeparateStale(time.Now().Add(-window))
+	}
+}
+
+// filterSlimRegressions drops the regressions found in jobs already on
+// ubuntu-slim unless --include-already-slim is set.
+func filterSlimRegressions(result *scan.ScanResult) {
+	if includeAlreadySlim {
+		return
+	}
+	for _, j := range result.AlreadySlimJobs {
+		j.Reasons = nil
+	}
+}
+
+// writeScanResult writes scan results in the selected output format.
+func writeScanResult(result *scan.ScanResult) error {
+	return writeOutput(func(w io.Writer) error {
+		switch outputFormat {
+		case formatText:
+			printScanText(w, result, outputVerbosity())
+			return nil
+		case formatSARIF:
+			return report.WriteSARIF(w, result)
+		case formatGitHub:
+			return report.WriteGitHubAnnotations(w, result)
+		case formatMarkdown:
+			return report.WriteMarkdown(w, result, markdownLinkBase())
+		case formatCSV:
+			return report.WriteCSV(w, result)
+		case formatJSONSummary:
+			return printScanJSONSummary(w, result)
+		}
+		return printScanJSON(w, result)
+	})
+}
+
+// markdownLinkBase returns the URL prefix of workflow file links in Markdown
+// output. In GitHub Actions, links point at the files in the commit being
+// built, so that they work in pull request comments; elsewhere they are
+// relative to the repository root.
+func markdownLinkBase() string {
+	server, repo, sha := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_SHA")
+	if server == "" || repo == "" || sha == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/blob/%s/", server, repo, sha)
+}
+
+// checkResult exits once the results of a scan are written: with exitError if
+// some workflow files could not be parsed, and with exitFindings for findings
+// the user asked to fail on with --fail-on-ineligible or --exit-code, which
+// includes regressions in jobs already on ubuntu-slim.
+func checkResult(result *scan.ScanResult) {
+	checkParseErrors(result, true)
+	checkJustifications(result)
+	if exitCode && (len(result.AllCandidates()) > 0 || len(result.SlimRegressions()) > 0) {
+		os.Exit(exitFindings)
+	}
+}
+
+// checkStrict exits with exitError before any output is written if --strict
+// is set and some workflow files could not be parsed, listing them on stderr.
+func checkStrict(result *scan.ScanResult) {
+	if strict && len(result.ParseErrors) > 0 {
+		checkParseErrors(result, false)
+	}
+}
+
+// checkParseErrors exits with exitError if some workflow files could not be
+// parsed. listed tells whether the output already lists their errors;
+// otherwise they are listed on stderr.
+func checkParseErrors(result *scan.ScanResult, listed bool) {
+	if len(result.ParseErrors) == 0 {
+		return
+	}
+	if !listed {
+		for _, e := range result.ParseErrors {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", e)
+		}
 	}
+	fmt.Fprintf(os.Stderr, "Error: %d workflow file(s) could not be parsed\n", len(result.ParseErrors))
+	os.Exit(exitError)
+}
 
-	printScanJSON(result)
+// checkJustifications exits with exitFindings when --fail-on-ineligible is set and
+// an ineligible job on a migration source label lacks a slimify-ignore comment,
+// listing those jobs on stderr.
+func checkJustifications(result *scan.ScanResult) {
+	if !failIneligible {
+		return
+	}
+	jobs := result.UnjustifiedJobs()
+	if len(jobs) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %d ineligible job(s) have no \"# slimify-ignore: <reason>\" comment:\n", len(jobs))
+	for _, job := range jobs {
+		fmt.Fprintf(os.Stderr, "  %s %q - %s\n", formatLocalLink(job.WorkflowPath, job.LineNumber), job.JobName, strings.Join(job.Reasons, "; "))
+	}
+	os.Exit(exitFindings)
 }
 
 func runFix(cmd *cobra.Command, args []string) {
 	filesToScan := resolveFiles(args, "fix")
+	threshold := parseMinConfidence()
+
+	if outputFormat != formatText && outputFormat != formatJSON {
+		fmt.Fprintf(os.Stderr, "Error: --format %s is not supported by fix\n", outputFormat)
+		os.Exit(exitError)
+	}
+
+	if createPR {
+		if err := ensureCleanWorkingTree(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
 
 	// Scan phase
 	if !jsonOutput {
 		sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
 		sp.Suffix = " Scanning workflows..."
 		sp.Start()
-		result, err := scan.Scan(skipDuration, verbose, filesToScan...)
+		result, err := scan.Scan(scanOptions(filesToScan, sp))
 		sp.Stop()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "✗ Scan failed\n")
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 		fmt.Fprintf(os.Stderr, "✓ Scan complete\n")
+		checkStrict(result)
+		filterByConfidence(result, threshold)
 		runFixWithResult(result, false)
+		checkParseErrors(result, false)
 		return
 	}
 
 	// JSON output path
-	result, err := scan.Scan(skipDuration, verbose, filesToScan...)
+	result, err := scan.Scan(scanOptions(filesToScan, nil))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
+	checkStrict(result)
+	filterByConfidence(result, threshold)
 	runFixWithResult(result, true)
+	checkParseErrors(result, false)
 }
 
 func runFixWithResult(result *scan.ScanResult, asJSON bool) {
-	candidates := result.Candidates
+	candidates := result.AllCandidates()
 
 	safeJobs, warningJobs := classifyCandidates(candidates)
+	if installMissing {
+		safeJobs, warningJobs = promoteInstallable(safeJobs, warningJobs)
+	}
 
 	var jobsToUpdate []*scan.Candidate
 	var skippedJobs []*scan.Candidate
@@ -160,7 +761,7 @@ func runFixWithResult(result *scan.ScanResult, asJSON bool) {
 
 	if len(jobsToUpdate) == 0 {
 		if asJSON {
-			printFixJSON(nil, skippedJobs, false)
+			printFixJSON(nil, skippedJobs, "", false)
 		} else if len(skippedJobs) > 0 {
 			fmt.Printf("No safe jobs to update. %d job(s) have warnings and were skipped.\n", len(skippedJobs))
 			fmt.Println("Use --force to update jobs with warnings.")
@@ -170,11 +771,23 @@ func runFixWithResult(result *scan.ScanResult, asJSON bool) {
 		return
 	}
 
+	if !assumeYes {
+		proceed, err := confirmUpdate(jobsToUpdate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		if !proceed {
+			fmt.Fprintln(os.Stderr, "Aborted. No files were modified.")
+			return
+		}
+	}
+
 	if !asJSON {
 		if force {
-			fmt.Println("Updating workflows to use ubuntu-slim (including jobs with warnings)...")
+			fmt.Printf("Updating workflows to use %s (including jobs with warnings)...\n", slimLabel)
 		} else {
-			fmt.Println("Updating workflows to use ubuntu-slim (safe jobs only)...")
+			fmt.Printf("Updating workflows to use %s (safe jobs only)...\n", slimLabel)
 			if len(skippedJobs) > 0 {
 				fmt.Printf("Skipping %d job(s) with warnings. Use --force to update them.\n", len(skippedJobs))
 			}
@@ -182,14 +795,27 @@ func runFixWithResult(result *scan.ScanResult, asJSON bool) {
 		fmt.Println()
 	}
 
-	// Group jobs by workflow file
+	applyFixes(jobsToUpdate, skippedJobs, asJSON)
+}
+
+// applyFixes migrates jobsToUpdate to --slim-label, honoring --backup,
+// --install-missing and --pr, and prints the results. skippedJobs are the
+// candidates left as is, listed in JSON output.
+func applyFixes(jobsToUpdate, skippedJobs []*scan.Candidate, asJSON bool) {
+	// Group jobs by workflow file. A job of a reusable workflow is reported
+	// once for each caller but updated only once
 	workflowMap := make(map[string][]*scan.Candidate)
+	seen := make(map[string]bool)
 	for _, c := range jobsToUpdate {
-		workflowMap[c.WorkflowPath] = append(workflowMap[c.WorkflowPath], c)
+		if key := c.WorkflowPath + ":" + c.JobID; !seen[key] {
+			seen[key] = true
+			workflowMap[c.WorkflowPath] = append(workflowMap[c.WorkflowPath], c)
+		}
 	}
 
 	updatedCount := 0
 	errorCount := 0
+	updatedFiles := make(map[string]bool)
 
 	var updateSpinner *spinner.Spinner
 	if !asJSON {
@@ -202,72 +828,289 @@ func runFixWithResult(result *scan.ScanResult, asJSON bool) {
 
 	// Update each workflow file
 	for workflowPath, jobs := range workflowMap {
-		for _, job := range jobs {
-			wf, err := workflow.LoadWorkflow(workflowPath)
-			if err != nil {
-				results = append(results, updateResult{
-					workflowPath: workflowPath,
-					jobID:        job.JobID,
-					jobName:      job.JobName,
-					lineNumber:   job.LineNumber,
-					isError:      true,
-					errorMsg:     fmt.Sprintf("Error loading workflow %s: %v", workflowPath, err),
-				})
+		for _, r := range updateWorkflowFile(workflowPath, jobs) {
+			switch {
+			case r.isError:
 				errorCount++
-				continue
+			case !r.isNotFound:
+				updatedCount++
+				updatedFiles[workflowPath] = true
 			}
+			results = append(results, r)
+		}
+	}
 
-			if _, ok := wf.Jobs[job.JobID]; !ok {
-				results = append(results, updateResult{
-					workflowPath: workflowPath,
-					jobID:        job.JobID,
-					jobName:      job.JobName,
-					lineNumber:   job.LineNumber,
-					isNotFound:   true,
-					errorMsg:     fmt.Sprintf("job %s (ID: %s) not found in %s", job.JobName, job.JobID, workflowPath),
-				})
-				continue
-			}
+	if updateSpinner != nil {
+		updateSpinner.Stop()
+	}
+
+	var pr *api.PullRequest
+	var prErr error
+	if createPR && updatedCount > 0 && errorCount == 0 {
+		pr, prErr = openMigrationPR(results)
+	}
+
+	if asJSON {
+		prURL := ""
+		if pr != nil {
+			prURL = pr.HTMLURL
+		}
+		printFixJSON(results, skippedJobs, prURL, errorCount > 0)
+	} else {
+		printFixText(results, updatedCount, len(updatedFiles), errorCount)
+		if pr != nil {
+			fmt.Printf("Opened pull request #%d: %s\n", pr.Number, pr.HTMLURL)
+		}
+	}
+
+	if prErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to open pull request: %v\n", prErr)
+		os.Exit(exitError)
+	}
+}
+
+// updateWorkflowFile migrates jobs of a single workflow file and returns the
+// result of each. If the modified file no longer parses or a job doesn't run
+// on --slim-label afterwards, the original file is restored and every job
+// updated in it is reported as an error.
+func updateWorkflowFile(workflowPath string, jobs []*scan.Candidate) []updateResult {
+	var results []updateResult
+	var updatedJobs []*scan.Candidate
+	var updatedResults []int
 
-			if err := workflow.UpdateRunsOn(workflowPath, job.JobID, "ubuntu-slim"); err != nil {
+	// Keep the original and its mode to restore if the modified file fails verification
+	info, err := os.Stat(workflowPath)
+	var original []byte
+	if err == nil {
+		original, err = os.ReadFile(workflowPath)
+	}
+	if err != nil {
+		for _, job := range jobs {
+			results = append(results, updateResult{
+				workflowPath: workflowPath,
+				jobID:        job.JobID,
+				jobName:      job.JobName,
+				lineNumber:   job.LineNumber,
+				isError:      true,
+				errorMsg:     fmt.Sprintf("Error reading workflow %s: %v", workflowPath, err),
+			})
+		}
+		return results
+	}
+
+	// A backup left by an earlier fix holds the workflow from before it, and
+	// is kept when this update is undone
+	backedUp := backupFiles && !workflow.HasBackup(workflowPath)
+	if backupFiles {
+		if err := workflow.Backup(workflowPath); err != nil {
+			for _, job := range jobs {
 				results = append(results, updateResult{
 					workflowPath: workflowPath,
 					jobID:        job.JobID,
 					jobName:      job.JobName,
 					lineNumber:   job.LineNumber,
 					isError:      true,
-					errorMsg:     fmt.Sprintf("Error updating job %s (ID: %s) in %s: %v", job.JobName, job.JobID, workflowPath, err),
+					errorMsg:     fmt.Sprintf("Error backing up %s: %v", workflowPath, err),
 				})
-				errorCount++
-				continue
 			}
+			return results
+		}
+	}
 
-			duration := job.Duration
-			if duration == "" {
-				duration = "unknown"
-			}
-			hasMissingCommands := len(job.MissingCommands) > 0
-			hasUnknownDuration := duration == "unknown"
+	for _, job := range jobs {
+		wf, err := workflow.LoadWorkflow(workflowPath)
+		if err != nil {
+			results = append(results, updateResult{
+				workflowPath: workflowPath,
+				jobID:        job.JobID,
+				jobName:      job.JobName,
+				lineNumber:   job.LineNumber,
+				isError:      true,
+				errorMsg:     fmt.Sprintf("Error loading workflow %s: %v", workflowPath, err),
+			})
+			continue
+		}
+
+		if _, ok := wf.Jobs[job.JobID]; !ok {
+			results = append(results, updateResult{
+				workflowPath: workflowPath,
+				jobID:        job.JobID,
+				jobName:      job.JobName,
+				lineNumber:   job.LineNumber,
+				isNotFound:   true,
+				errorMsg:     fmt.Sprintf("job %s (ID: %s) not found in %s", job.JobName, job.JobID, workflowPath),
+			})
+			continue
+		}
 
+		if err := rewriteRunsOn(workflowPath, job); err != nil {
 			results = append(results, updateResult{
 				workflowPath: workflowPath,
 				jobID:        job.JobID,
 				jobName:      job.JobName,
 				lineNumber:   job.LineNumber,
-				hasWarnings:  hasMissingCommands || hasUnknownDuration,
+				isError:      true,
+				errorMsg:     fmt.Sprintf("Error updating job %s (ID: %s) in %s: %v", job.JobName, job.JobID, workflowPath, err),
 			})
-			updatedCount++
+			continue
+		}
+
+		duration := job.Duration
+		if duration == "" {
+			duration = "unknown"
 		}
+		hasMissingCommands := len(job.MissingCommands) > 0
+		hasUnknownDuration := duration == "unknown"
+
+		results = append(results, updateResult{
+			workflowPath: workflowPath,
+			jobID:        job.JobID,
+			jobName:      job.JobName,
+			lineNumber:   job.LineNumber,
+			hasWarnings:  hasMissingCommands || hasUnknownDuration,
+		})
+		updatedJobs = append(updatedJobs, job)
+		updatedResults = append(updatedResults, len(results)-1)
 	}
 
-	if updateSpinner != nil {
-		updateSpinner.Stop()
+	// Insert install steps only after every runs-on line in the file has been
+	// rewritten, since inserting lines shifts the line numbers of later jobs
+	if installMissing {
+		for i, job := range updatedJobs {
+			r := &results[updatedResults[i]]
+			packages, err := insertInstallStep(workflowPath, job)
+			if err != nil {
+				r.isError = true
+				r.errorMsg = fmt.Sprintf("Error adding install step to job %s (ID: %s) in %s: %v", job.JobName, job.JobID, workflowPath, err)
+				continue
+			}
+			r.installedPackages = packages
+			r.hasWarnings = needsAttention(job, installMissing)
+		}
 	}
 
-	if asJSON {
-		printFixJSON(results, skippedJobs, errorCount > 0)
-		return
+	if len(updatedJobs) > 0 {
+		if err := verifyFix(workflowPath, updatedJobs); err != nil {
+			msg := fmt.Sprintf("Error verifying %s after the update, restored the original: %v", workflowPath, err)
+			if restoreErr := restoreOriginal(workflowPath, original, info.Mode().Perm()); restoreErr != nil {
+				msg = fmt.Sprintf("Error verifying %s after the update: %v; failed to restore the original: %v", workflowPath, err, restoreErr)
+			} else if backedUp {
+				// The backup is identical to the restored file
+				backupPath := workflowPath + workflow.BackupSuffix
+				if removeErr := os.Remove(backupPath); removeErr != nil {
+					msg = fmt.Sprintf("%s (backup left behind at %s: %v)", msg, backupPath, removeErr)
+				}
+			}
+			for _, i := range updatedResults {
+				results[i].isError = true
+				results[i].errorMsg = msg
+			}
+		}
+	}
+
+	return results
+}
+
+// restoreOriginal writes the original content back to the workflow with its original mode.
+// The mode is set explicitly since a rewrite may have replaced the file.
+func restoreOriginal(workflowPath string, original []byte, mode os.FileMode) error {
+	if err := os.WriteFile(workflowPath, original, mode); err != nil {
+		return err
+	}
+	return os.Chmod(workflowPath, mode)
+}
+
+// installStepName is the name of the step added by --install-missing
+const installStepName = "Install tools missing in ubuntu-slim"
+
+// insertInstallStep prepends an apt-get step installing the job's missing commands.
+// It returns the installed packages, or nil if there is nothing apt can install.
+func insertInstallStep(workflowPath string, job *scan.Candidate) ([]string, error) {
+	packages, _ := workflow.AptPackagesFor(job.MissingCommands)
+	if len(packages) == 0 {
+		return nil, nil
+	}
+
+	run := "sudo apt-get update && sudo apt-get install -y " + strings.Join(packages, " ")
+	if err := workflow.PrependRunStep(workflowPath, job.JobID, installStepName, run); err != nil {
+		return nil, err
 	}
+	return packages, nil
+}
+
+// needsAttention reports whether a candidate still has warnings.
+// With --install-missing, missing commands that apt can install are not a warning.
+func needsAttention(job *scan.Candidate, installMissing bool) bool {
+	if job.Duration == "" || job.Duration == "unknown" {
+		return true
+	}
+	if len(job.MissingCommands) == 0 {
+		return false
+	}
+	if !installMissing {
+		return true
+	}
+	_, unavailable := workflow.AptPackagesFor(job.MissingCommands)
+	return len(unavailable) > 0
+}
 
-	printFixText(results, updatedCount, errorCount)
+// promoteInstallable moves warning jobs that no longer need attention once
+// their missing commands are installed to the safe jobs.
+func promoteInstallable(safe, warning []*scan.Candidate) ([]*scan.Candidate, []*scan.Candidate) {
+	var remaining []*scan.Candidate
+	for _, job := range warning {
+		if needsAttention(job, true) {
+			remaining = append(remaining, job)
+		} else {
+			safe = append(safe, job)
+		}
+	}
+	return safe, remaining
+}
+
+// confirmUpdate lists the jobs that will be migrated and asks the user to confirm.
+// The listing and prompt go to stderr so that JSON output on stdout stays valid.
+// Returns an error instead of prompting when stdin is not a terminal, so that
+// non-interactive runs fail fast rather than hang.
+func confirmUpdate(jobs []*scan.Candidate) (bool, error) {
+	if !isTerminal(os.Stdin) {
+		return false, fmt.Errorf("refusing to modify files without confirmation: stdin is not a terminal. Use --yes to apply changes non-interactively")
+	}
+
+	fmt.Fprintf(os.Stderr, "The following %d job(s) will be migrated to %s:\n", len(jobs), slimLabel)
+	for _, job := range jobs {
+		fmt.Fprintf(os.Stderr, "  • \"%s\" (%s)\n", job.JobName, formatLocalLink(job.WorkflowPath, job.LineNumber))
+	}
+
+	return confirm(os.Stdin, os.Stderr, "Proceed?")
+}
+
+// updateJobRunsOn rewrites the runs-on label of a candidate job in place to
+// --slim-label.
+// The candidate's line number is used to target the exact runs-on line so that
+// comments, quoting and anchors elsewhere in the file are left untouched.
+// Falls back to searching by job ID when the line number is unknown.
+// Jobs whose runs-on is a matrix expression have their matrix values rewritten instead.
+// Jobs whose runs-on label is set in a variable are not rewritten, since the
+// variable's value would have to change instead.
+func updateJobRunsOn(workflowPath string, job *scan.Candidate) error {
+	if len(job.RunsOnVariables) > 0 {
+		return fmt.Errorf("runs-on uses %s; set it to %s instead of editing the workflow", strings.Join(job.RunsOnVariables, ", "), slimLabel)
+	}
+	if job.RunsOnMatrix {
+		labels := sourceLabels
+		if len(labels) == 0 {
+			labels = scan.DefaultSourceLabels
+		}
+		return workflow.UpdateRunsOnMatrix(workflowPath, job.JobID, labels, slimLabel)
+	}
+
+	sourceLabel := job.SourceLabel
+	if sourceLabel == "" {
+		sourceLabel = "ubuntu-latest"
+	}
+	if job.LineNumber > 0 {
+		return workflow.UpdateRunsOnAtLine(workflowPath, job.LineNumber, sourceLabel, slimLabel)
+	}
+	return workflow.UpdateRunsOn(workflowPath, job.JobID, slimLabel)
 }
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepoRoot(t *testing.T) {
+	// Run from a directory without workflows, outside any git repository
+	t.Chdir(t.TempDir())
+
+	root := t.TempDir()
+	workflowDir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	output := filepath.Join(t.TempDir(), "result.json")
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"--repo-root", root, "--all", "--skip-duration", "--format", "json", "--output", output})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var result scanOutputJSON
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+	if len(result.Jobs) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(result.Jobs))
+	}
+	job := result.Jobs[0]
+	if want := filepath.Join(".github", "workflows", "ci.yml"); job.WorkflowPath != want || job.LineNumber != 4 {
+		t.Errorf("job at %s:%d, want %s:4", job.WorkflowPath, job.LineNumber, want)
+	}
+	if got := formatLocalLink(job.WorkflowPath, job.LineNumber); got != ".github/workflows/ci.yml:4" {
+		t.Errorf("formatLocalLink() = %q, want %q", got, ".github/workflows/ci.yml:4")
+	}
+}
+
+func TestFixSlimLabel(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  test:
+    runs-on: self-hosted-slim
+    steps:
+      - run: make test
+`
+	path := filepath.Join(workflowDir, "ci.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"fix", "--all", "--yes", "--skip-duration", "--force", "--slim-label", "self-hosted-slim"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read workflow file: %v", err)
+	}
+	if got := strings.Count(string(data), "runs-on: self-hosted-slim"); got != 2 || strings.Contains(string(data), "ubuntu-slim") {
+		t.Errorf("fix --slim-label self-hosted-slim wrote:\n%s\nwant both jobs on self-hosted-slim", data)
+	}
+}
+
+func TestFixFromPinnedLabel(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: make lint
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`
+	path := filepath.Join(workflowDir, "ci.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"fix", "--all", "--yes", "--skip-duration", "--force", "--from", "ubuntu-22.04"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read workflow file: %v", err)
+	}
+	want := strings.Replace(content, "ubuntu-22.04", "ubuntu-slim", 1)
+	if string(data) != want {
+		t.Errorf("fix --from ubuntu-22.04 wrote:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	// Run as the CLI in a subprocess, since it exits with os.Exit
+	if args := os.Getenv("SLIMIFY_TEST_ARGS"); args != "" {
+		os.Args = append([]string{"gh-slimify"}, strings.Fields(args)...)
+		main()
+		os.Exit(0)
+	}
+
+	workflows := map[string]string{
+		"slim.yml": `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make lint
+`,
+		"ci.yml": `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`,
+		"slim-docker.yml": `on: push
+jobs:
+  image:
+    runs-on: ubuntu-slim
+    steps:
+      - run: docker build .
+`,
+		"broken.yml": "jobs: [\n",
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		args  string
+		want  int
+	}{
+		{name: "no candidates", files: []string{"slim.yml"}, args: "--all --exit-code", want: 0},
+		{name: "candidates without --exit-code", files: []string{"ci.yml"}, args: "--all", want: 0},
+		{name: "candidates with --exit-code", files: []string{"ci.yml"}, args: "--all --exit-code --format json", want: exitFindings},
+		{name: "slim regression without --include-already-slim", files: []string{"slim-docker.yml"}, args: "--all --exit-code", want: 0},
+		{name: "slim regression with --include-already-slim", files: []string{"slim-docker.yml"}, args: "--all --exit-code --include-already-slim", want: exitFindings},
+		{name: "malformed file among others", files: []string{"ci.yml", "broken.yml"}, args: "--all --format json", want: exitError},
+		{name: "malformed file with --strict", files: []string{"ci.yml", "broken.yml"}, args: "--all --strict --exit-code", want: exitError},
+		{name: "malformed file given explicitly", files: []string{"broken.yml"}, args: ".github/workflows/broken.yml", want: exitError},
+		{name: "missing workflow directory", files: []string{"ci.yml"}, args: "--all --dir missing", want: exitError},
+		{name: "invalid flag", files: []string{"ci.yml"}, args: "--all --min-confidence unknown", want: exitError},
+		{name: "invalid log level", files: []string{"ci.yml"}, args: "--all --log-level trace", want: exitError},
+		{name: "invalid resolved variable", files: []string{"ci.yml"}, args: "--all --resolve-var LINUX_RUNNER", want: exitError},
+		{name: "invalid group-by", files: []string{"ci.yml"}, args: "--all --group-by workflow", want: exitError},
+		{name: "invalid since window", files: []string{"ci.yml"}, args: "--all --since 3mo", want: exitError},
+		{name: "since without durations", files: []string{"ci.yml"}, args: "--all --skip-duration --since 90d", want: exitError},
+		{name: "review without a terminal", files: []string{"ci.yml"}, args: "review --all", want: exitError},
+		{name: "needs graph", files: []string{"ci.yml"}, args: "--all --exit-code --needs-graph --format dot", want: 0},
+		{name: "dot without --needs-graph", files: []string{"ci.yml"}, args: "--all --format dot", want: exitError},
+		{name: "needs graph as CSV", files: []string{"ci.yml"}, args: "--all --needs-graph --format csv", want: exitError},
+		{name: "base ref outside a git repository", files: []string{"ci.yml"}, args: "--all --base-ref main", want: exitError},
+		{name: "base ref with stdin", files: []string{"ci.yml"}, args: "--stdin --base-ref main", want: exitError},
+		{name: "json summary", files: []string{"ci.yml"}, args: "--all --exit-code --format json-summary", want: exitFindings},
+		{name: "json summary of stats", files: []string{"ci.yml"}, args: "stats --all --format json-summary", want: exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			workflowDir := filepath.Join(root, ".github", "workflows")
+			if err := os.MkdirAll(workflowDir, 0755); err != nil {
+				t.Fatalf("Failed to create workflow directory: %v", err)
+			}
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(workflows[name]), 0644); err != nil {
+					t.Fatalf("Failed to write workflow file: %v", err)
+				}
+			}
+
+			cmd := exec.Command(os.Args[0], "-test.run=^TestExitCodes$")
+			cmd.Dir = root
+			cmd.Env = append(os.Environ(), "SLIMIFY_TEST_ARGS=--skip-duration --no-cache "+tt.args)
+			output, err := cmd.CombinedOutput()
+
+			got := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				got = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("Failed to run: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("exit code = %d, want %d\noutput:\n%s", got, tt.want, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/spf13/cobra"
+)
+
+// reasonLabels are the human-readable names of ineligibility reason categories.
+var reasonLabels = map[string]string{
+	scan.ReasonDisabled:             "disabled by if: false",
+	scan.ReasonRunsOn:               "runs-on is not a migration source",
+	scan.ReasonDockerCommands:       "Docker commands",
+	scan.ReasonLocalCluster:         "local Kubernetes clusters",
+	scan.ReasonContainerActions:     "container-based GitHub Actions",
+	scan.ReasonCrossArchBuild:       "cross-architecture builds",
+	scan.ReasonDockerActions:        "actions that need Docker",
+	scan.ReasonLocalActions:         "local actions that use Docker",
+	scan.ReasonServices:             "service containers",
+	scan.ReasonContainer:            "container syntax",
+	scan.ReasonPrivilegedOperations: "privileged operations",
+	scan.ReasonReusableWorkflow:     "unresolvable reusable workflows",
+	scan.ReasonOther:                "other",
+}
+
+// sortedCategories returns the reason categories of counts, most common first
+// and then by name for stable output.
+func sortedCategories(counts map[string]int) []string {
+	categories := make([]string, 0, len(counts))
+	for category := range counts {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		ci, cj := counts[categories[i]], counts[categories[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return categories[i] < categories[j]
+	})
+	return categories
+}
+
+// JSON output type for stats command
+type statsJSON struct {
+	TotalJobs          int            `json:"total_jobs"`
+	Eligible           int            `json:"eligible"`
+	NeedsSetup         int            `json:"needs_setup"`
+	Ineligible         int            `json:"ineligible"`
+	IneligibleByReason map[string]int `json:"ineligible_by_reason"`
+	AlreadySlim        int            `json:"already_slim"`
+	Ignored            int            `json:"ignored"`
+	OtherOS            int            `json:"other_os"`
+	UnresolvedRunsOn   int            `json:"unresolved_runs_on"`
+	MigratedPercent    float64        `json:"migrated_percent"`
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	if outputFormat == formatSARIF || outputFormat == formatGitHub || outputFormat == formatMarkdown || outputFormat == formatCSV || outputFormat == formatDOT || outputFormat == formatJSONSummary {
+		fmt.Fprintf(os.Stderr, "Error: stats does not support --format %s\n", outputFormat)
+		os.Exit(exitError)
+	}
+
+	filesToScan := resolveFiles(args, "stats")
+
+	// Durations don't affect the stats, so don't spend API calls on them
+	opts := scanOptions(filesToScan, nil)
+	opts.SkipDuration = true
+	result, err := scan.Scan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	checkStrict(result)
+
+	stats := scan.Summarize(result)
+	if jsonOutput {
+		printStatsJSON(stats)
+	} else {
+		printStatsText(stats)
+	}
+	checkParseErrors(result, false)
+}
+
+func printStatsJSON(stats scan.Stats) {
+	output := statsJSON{
+		TotalJobs:          stats.TotalJobs,
+		Eligible:           stats.Eligible,
+		NeedsSetup:         stats.NeedsSetup,
+		Ineligible:         stats.Ineligible,
+		IneligibleByReason: stats.IneligibleByReason,
+		AlreadySlim:        stats.AlreadySlim,
+		Ignored:            stats.Ignored,
+		OtherOS:            stats.OtherOS,
+		UnresolvedRunsOn:   stats.UnresolvedRunsOn,
+		MigratedPercent:    math.Round(stats.MigratedPercent*100) / 100,
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(output)
+}
+
+func printStatsText(stats scan.Stats) {
+	fmt.Printf("📊 Total jobs: %d\n", stats.TotalJobs)
+	fmt.Printf("✨ Already using ubuntu-slim: %d (%.1f%% migrated)\n", stats.AlreadySlim, stats.MigratedPercent)
+	fmt.Printf("✅ Eligible for migration: %d", stats.Eligible)
+	if stats.NeedsSetup > 0 {
+		fmt.Printf(" (%d need setup)", stats.NeedsSetup)
+	}
+	fmt.Println()
+	fmt.Printf("❌ Cannot migrate: %d\n", stats.Ineligible)
+
+	for _, category := range sortedCategories(stats.IneligibleByReason) {
+		fmt.Printf("     • %s: %d\n", reasonLabels[category], stats.IneligibleByReason[category])
+	}
+
+	if stats.OtherOS > 0 {
+		fmt.Printf("🖥️  Different OS (not applicable): %d\n", stats.OtherOS)
+	}
+	if stats.UnresolvedRunsOn > 0 {
+		fmt.Printf("❓ runs-on uses an unresolved variable: %d\n", stats.UnresolvedRunsOn)
+	}
+	if stats.Ignored > 0 {
+		fmt.Printf("🙈 Ignored by %s: %d\n", scan.IgnoreFileName, stats.Ignored)
+	}
+}
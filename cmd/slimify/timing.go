@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// timingReporter returns a scan timing callback that prints how long each
+// phase took to stderr with --debug-timing, or nil without it. The spinner sp,
+// if running, is paused and its line cleared so the two don't mix.
+func timingReporter(sp *spinner.Spinner) func(scan.Timings) {
+	if !debugTiming {
+		return nil
+	}
+	return func(t scan.Timings) {
+		if sp != nil && sp.Active() {
+			sp.Lock()
+			defer sp.Unlock()
+			fmt.Fprint(os.Stderr, "\r\033[K")
+		}
+		printTimings(os.Stderr, t)
+	}
+}
+
+// printTimings writes the timings of a scan to w, one phase per line.
+func printTimings(w io.Writer, t scan.Timings) {
+	durations := fmt.Sprintf("%d job(s)", t.DurationJobs)
+	if t.Durations == 0 && t.DurationJobs == 0 {
+		durations = "skipped"
+	}
+	fmt.Fprintf(w, "⏱️  Scan timing:\n")
+	fmt.Fprintf(w, "   %-16s %10s  %d file(s), %d from cache\n", "discovery", roundTiming(t.Discovery), t.Files, t.CachedFiles)
+	fmt.Fprintf(w, "   %-16s %10s  %d file(s)\n", "parsing", roundTiming(t.Parsing), t.Files-t.CachedFiles)
+	fmt.Fprintf(w, "   %-16s %10s  %d job(s)\n", "eligibility", roundTiming(t.Classification), t.Jobs)
+	fmt.Fprintf(w, "   %-16s %10s  %s\n", "durations", roundTiming(t.Durations), durations)
+	fmt.Fprintf(w, "   %-16s %10s\n", "total", roundTiming(t.Total))
+}
+
+// roundTiming rounds d to a precision readable at a glance.
+func roundTiming(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(time.Microsecond)
+}
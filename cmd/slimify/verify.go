@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// rewriteRunsOn rewrites the runs-on of a candidate job in a workflow file.
+// It is a variable so that tests can inject a faulty rewrite.
+var rewriteRunsOn = updateJobRunsOn
+
+// verifyFix re-reads a workflow modified by fix and checks that it still
+// parses and that each of jobs now runs on --slim-label, so that a faulty
+// rewrite is caught before it leaves a corrupted workflow behind.
+func verifyFix(workflowPath string, jobs []*scan.Candidate) error {
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		return err
+	}
+	for _, c := range jobs {
+		job, ok := wf.Jobs[c.JobID]
+		if !ok {
+			return fmt.Errorf("job %s is missing", c.JobID)
+		}
+		if !job.IsSlim(slimLabel) {
+			return fmt.Errorf("job %s does not run on %s", c.JobID, slimLabel)
+		}
+	}
+	return nil
+}
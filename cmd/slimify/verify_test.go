@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestUpdateWorkflowFile_Verify(t *testing.T) {
+	const content = `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+
+	tests := []struct {
+		name        string
+		rewrite     func(string, *scan.Candidate) error
+		backup      bool
+		wantError   bool
+		wantContent string
+	}{
+		{
+			name:        "rewrite succeeds",
+			rewrite:     updateJobRunsOn,
+			wantContent: strings.Replace(content, "ubuntu-latest", "ubuntu-slim", 1),
+		},
+		{
+			name: "rewrite corrupts the workflow",
+			rewrite: func(path string, _ *scan.Candidate) error {
+				return os.WriteFile(path, []byte("jobs:\n  lint: [\n"), 0644)
+			},
+			wantError:   true,
+			wantContent: content,
+		},
+		{
+			name: "rewrite leaves runs-on unchanged",
+			rewrite: func(path string, _ *scan.Candidate) error {
+				return os.WriteFile(path, []byte(content+"# touched\n"), 0644)
+			},
+			wantError:   true,
+			wantContent: content,
+		},
+		{
+			name: "rewrite replaces the file with --backup",
+			rewrite: func(path string, _ *scan.Candidate) error {
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+				return os.WriteFile(path, []byte("jobs:\n  lint: [\n"), 0644)
+			},
+			backup:      true,
+			wantError:   true,
+			wantContent: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prevSlimLabel, prevRewrite, prevBackup := slimLabel, rewriteRunsOn, backupFiles
+			slimLabel, rewriteRunsOn, backupFiles = "ubuntu-slim", tt.rewrite, tt.backup
+			t.Cleanup(func() { slimLabel, rewriteRunsOn, backupFiles = prevSlimLabel, prevRewrite, prevBackup })
+
+			path := filepath.Join(t.TempDir(), "ci.yml")
+			if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write workflow file: %v", err)
+			}
+			job := &scan.Candidate{WorkflowPath: path, JobID: "lint", JobName: "lint", LineNumber: 4, SourceLabel: "ubuntu-latest"}
+
+			results := updateWorkflowFile(path, []*scan.Candidate{job})
+			if len(results) != 1 {
+				t.Fatalf("updateWorkflowFile() returned %d results, want 1", len(results))
+			}
+			if got := results[0].isError; got != tt.wantError {
+				t.Errorf("isError = %v, want %v (%s)", got, tt.wantError, results[0].errorMsg)
+			}
+			if tt.wantError && !strings.Contains(results[0].errorMsg, "restored the original") {
+				t.Errorf("errorMsg = %q, want it to mention the restore", results[0].errorMsg)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read workflow file: %v", err)
+			}
+			if string(data) != tt.wantContent {
+				t.Errorf("workflow after fix:\n%s\nwant:\n%s", data, tt.wantContent)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Failed to stat workflow file: %v", err)
+			}
+			if got := info.Mode().Perm(); got != 0600 {
+				t.Errorf("workflow mode after fix = %v, want %v", got, os.FileMode(0600))
+			}
+			if tt.wantError && tt.backup {
+				if _, err := os.Stat(path + workflow.BackupSuffix); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("backup still exists after restoring the original (stat error: %v)", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long --watch waits after a workflow file changes
+// before scanning again, so that the several writes editors often make when
+// saving a file result in a single scan.
+const watchDebounce = 200 * time.Millisecond
+
+// runWatch scans the workflows, then scans them again whenever a workflow file
+// changes, until interrupted. Each scan clears the terminal and reprints the
+// results. --exit-code and --fail-on-ineligible don't apply, since the
+// results change over time.
+func runWatch(filesToScan []string, threshold scan.Confidence, window time.Duration) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dirs := watchDirs(filesToScan)
+	watcher, err := newWorkflowWatcher(dirs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to watch workflows: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer watcher.close()
+
+	rescan := func() {
+		if isTerminal(os.Stdout) {
+			// Move the cursor home and clear the screen
+			fmt.Print("\033[H\033[2J")
+		}
+		result, err := scan.Scan(scanOptions(filesToScan, nil))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			filterByConfidence(result, threshold)
+			separateStale(result, window)
+			filterSlimRegressions(result)
+			if err := writeScanResult(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "\n👀 Watching %s for changes (press Ctrl+C to stop)\n", strings.Join(dirs, ", "))
+	}
+
+	rescan()
+	if err := watcher.run(ctx, watchDebounce, rescan); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+}
+
+// watchDirs returns the directories to watch for the scanned workflows: the
+// directories of the workflow files given, or else the default and --dir
+// workflow roots.
+func watchDirs(filesToScan []string) []string {
+	if len(filesToScan) == 0 {
+		return append([]string{workflow.DefaultWorkflowDir}, workflowDirs...)
+	}
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, file := range filesToScan {
+		dir := filepath.Dir(file)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// workflowWatcher reports changes to workflow files in a set of directories
+// and their subdirectories.
+type workflowWatcher struct {
+	watcher *fsnotify.Watcher
+}
+
+// newWorkflowWatcher starts watching dirs recursively. Changes made after it
+// returns are reported by run.
+func newWorkflowWatcher(dirs []string) (*workflowWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &workflowWatcher{watcher: watcher}
+	for _, dir := range dirs {
+		if err := w.addTree(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// addTree watches dir and every directory under it.
+func (w *workflowWatcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return w.watcher.Add(path)
+	})
+}
+
+// run calls onChange once workflow files have stopped changing for debounce,
+// until ctx is done. Directories created while running are watched too.
+func (w *workflowWatcher) run(ctx context.Context, debounce time.Duration, onChange func()) error {
+	var timer *time.Timer
+	var fire <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.addTree(event.Name); err != nil {
+						slog.Warn("failed to watch directory", "path", event.Name, "err", err)
+					}
+					continue
+				}
+			}
+			if event.Op == fsnotify.Chmod || !isWorkflowFile(event.Name) {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				timer.Reset(debounce)
+			}
+			fire = timer.C
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("error watching workflows", "err", err)
+		case <-fire:
+			fire = nil
+			onChange()
+		}
+	}
+}
+
+// close stops watching.
+func (w *workflowWatcher) close() error {
+	return w.watcher.Close()
+}
+
+// isWorkflowFile reports whether path has the extension of a workflow file.
+func isWorkflowFile(path string) bool {
+	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkflowWatcher(t *testing.T) {
+	dir := t.TempDir()
+	workflowPath := filepath.Join(dir, "ci.yml")
+	if err := os.WriteFile(workflowPath, []byte("on: push\n"), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	watcher, err := newWorkflowWatcher([]string{dir})
+	if err != nil {
+		t.Fatalf("newWorkflowWatcher() error: %v", err)
+	}
+	defer watcher.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rescans := make(chan struct{}, 10)
+	done := make(chan error)
+	go func() {
+		done <- watcher.run(ctx, 50*time.Millisecond, func() { rescans <- struct{}{} })
+	}()
+
+	// Editors often write a file more than once when saving it
+	for range 2 {
+		if err := os.WriteFile(workflowPath, []byte("on: pull_request\n"), 0644); err != nil {
+			t.Fatalf("Failed to write workflow file: %v", err)
+		}
+	}
+	// Files other than workflows don't trigger a scan
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	select {
+	case <-rescans:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no rescan after a workflow file was written")
+	}
+	select {
+	case <-rescans:
+		t.Error("successive writes triggered more than one rescan")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not return after the context was canceled")
+	}
+}
@@ -1,34 +1,65 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/fchimpan/gh-slimify/internal/ghapi"
 )
 
+// ErrNotFound is returned when the requested resource does not exist or is not accessible
+var ErrNotFound = errors.New("not found")
+
+// ErrRateLimited is returned when the GitHub API rejects a request because of rate limiting
+var ErrRateLimited = errors.New("GitHub API rate limit exceeded")
+
 // Client wraps GitHub API client for Actions API
 type Client struct {
 	restClient *api.RESTClient
 	host       string
 	owner      string
 	repo       string
+
+	// runsCache and jobsCache avoid refetching the same workflow runs and run jobs
+	// when several jobs of one workflow are looked up
+	runsCache map[string][]workflowRun
+	jobsCache map[int64][]job
 }
 
 // NewClient creates a new GitHub API client
-// If host is empty, it defaults to github.com
-func NewClient(host, owner, repo string) (*Client, error) {
+// If host is empty, it defaults to DefaultHost(). Requests go to the API of
+// host, so a GitHub Enterprise Server host is served by its /api/v3 endpoint.
+// Requests rejected by a rate limit are retried up to retries times, see
+// ghapi.NewRetryTransport.
+func NewClient(host, owner, repo string, retries int) (*Client, error) {
+	return newClient(host, owner, repo, retries, nil)
+}
+
+// newClient creates a client whose requests are sent through transport, or
+// through the default transport if nil.
+func newClient(host, owner, repo string, retries int, transport http.RoundTripper) (*Client, error) {
 	if host == "" {
-		host = "github.com"
+		host = DefaultHost()
 	}
 
-	// Create REST client with automatic authentication from gh CLI
-	restClient, err := api.DefaultRESTClient()
+	// Create REST client with automatic authentication from gh CLI for the host
+	restClient, err := api.NewRESTClient(api.ClientOptions{
+		Host:      host,
+		Transport: ghapi.NewRetryTransport(transport, retries),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create REST client: %w", err)
+		return nil, fmt.Errorf("failed to create REST client for %s: %w", host, err)
 	}
 
 	return &Client{
@@ -36,20 +67,32 @@ func NewClient(host, owner, repo string) (*Client, error) {
 		host:       host,
 		owner:      owner,
 		repo:       repo,
+		runsCache:  make(map[string][]workflowRun),
+		jobsCache:  make(map[int64][]job),
 	}, nil
 }
 
+// DefaultHost returns the host used when none is known from the repository,
+// like gh does: the GH_HOST environment variable, else the default host in
+// the gh configuration, else github.com.
+func DefaultHost() string {
+	host, _ := auth.DefaultHost()
+	return host
+}
+
 // JobDuration represents job execution duration information
 type JobDuration struct {
-	JobName  string
-	Duration time.Duration
+	JobName     string
+	Duration    time.Duration
+	CompletedAt time.Time // When the job run completed
 }
 
 // GetJobDuration gets the latest execution duration for a specific job in a workflow
+// Only successful runs are considered. If branch is non-empty, only runs on that branch are considered.
 // jobID is the key in the jobs map, jobDisplayName is the custom display name or job ID if not specified
-func (c *Client) GetJobDuration(ctx context.Context, workflowPath, jobID, jobDisplayName string) (*JobDuration, error) {
+func (c *Client) GetJobDuration(ctx context.Context, workflowPath, branch, jobID, jobDisplayName string) (*JobDuration, error) {
 	// Get workflow runs
-	runs, err := c.getWorkflowRuns(ctx, workflowPath)
+	runs, err := c.getWorkflowRuns(ctx, workflowPath, branch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workflow runs: %w", err)
 	}
@@ -66,6 +109,10 @@ func (c *Client) GetJobDuration(ctx context.Context, workflowPath, jobID, jobDis
 
 		duration, err := c.getJobDurationFromRun(ctx, run.ID, jobID, jobDisplayName)
 		if err != nil {
+			// Stop early when rate limited, further requests would fail too
+			if errors.Is(err, ErrRateLimited) {
+				return nil, err
+			}
 			// Continue to next run if job not found in this run
 			continue
 		}
@@ -103,12 +150,17 @@ type jobsResponse struct {
 // getJobDurationFromRun gets the duration of a specific job from a workflow run
 // jobID is the key in the jobs map, jobDisplayName is the custom display name or job ID if not specified
 func (c *Client) getJobDurationFromRun(ctx context.Context, runID int64, jobID, jobDisplayName string) (*JobDuration, error) {
-	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs", c.owner, c.repo, runID)
+	jobs, ok := c.jobsCache[runID]
+	if !ok {
+		path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs", c.owner, c.repo, runID)
 
-	var response jobsResponse
-	err := c.restClient.Get(path, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch jobs: %w", err)
+		var response jobsResponse
+		err := c.restClient.Get(path, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jobs: %w", classifyError(err))
+		}
+		jobs = response.Jobs
+		c.jobsCache[runID] = jobs
 	}
 
 	// GitHub API returns jobs with their display name in the "name" field.
@@ -119,7 +171,7 @@ func (c *Client) getJobDurationFromRun(ctx context.Context, runID int64, jobID,
 	// Since we need to match by display name (what appears in GitHub Actions UI),
 	// we try the display name first, then fallback to the job ID in case the job
 	// doesn't have a custom name field set.
-	for _, j := range response.Jobs {
+	for _, j := range jobs {
 		// Match by display name (case-insensitive)
 		if strings.EqualFold(j.Name, jobDisplayName) {
 			return parseJobDuration(&j, jobDisplayName)
@@ -154,13 +206,20 @@ func parseJobDuration(j *job, jobDisplayName string) (*JobDuration, error) {
 	duration := completedTime.Sub(startTime)
 
 	return &JobDuration{
-		JobName:  jobDisplayName,
-		Duration: duration,
+		JobName:     jobDisplayName,
+		Duration:    duration,
+		CompletedAt: completedTime,
 	}, nil
 }
 
-// GetRepoInfo gets repository owner and name from git remote
+// GetRepoInfo gets repository owner and name from git remote.
+// Like gh commands, it uses the repository in the GH_REPO environment variable
+// instead when set.
 func GetRepoInfo() (host, owner, repo string, err error) {
+	if ghRepo := os.Getenv("GH_REPO"); ghRepo != "" {
+		return ParseRepo(ghRepo)
+	}
+
 	// Try to get from git remote
 	cmd := exec.Command("git", "remote", "get-url", "origin")
 	output, err := cmd.Output()
@@ -208,19 +267,126 @@ func GetRepoInfo() (host, owner, repo string, err error) {
 	return host, owner, repo, nil
 }
 
-// getWorkflowRuns gets workflow runs for a specific workflow file
-func (c *Client) getWorkflowRuns(_ context.Context, workflowPath string) ([]workflowRun, error) {
+// ParseRepo parses a repository written as OWNER/REPO or HOST/OWNER/REPO.
+// The host defaults to DefaultHost().
+func ParseRepo(s string) (host, owner, repo string, err error) {
+	parts := strings.Split(s, "/")
+	switch {
+	case len(parts) == 2:
+		host, owner, repo = DefaultHost(), parts[0], parts[1]
+	case len(parts) == 3:
+		host, owner, repo = parts[0], parts[1], parts[2]
+	}
+	if host == "" || owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("invalid repository %q: want OWNER/REPO or HOST/OWNER/REPO", s)
+	}
+	return host, owner, repo, nil
+}
+
+// getWorkflowRuns gets successful workflow runs for a specific workflow file
+// If branch is non-empty, only runs on that branch are returned.
+// A workflow that has never run (404) yields no runs rather than an error.
+func (c *Client) getWorkflowRuns(_ context.Context, workflowPath, branch string) ([]workflowRun, error) {
+	cacheKey := workflowPath + "@" + branch
+	if runs, ok := c.runsCache[cacheKey]; ok {
+		return runs, nil
+	}
+
 	// Use the full workflow path (e.g., ".github/workflows/ci.yaml")
 	// GitHub API accepts both workflow ID and workflow path
 	// URL encode the path for the API call
 	encodedPath := strings.ReplaceAll(workflowPath, "/", "%2F")
-	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/runs?per_page=10", c.owner, c.repo, encodedPath)
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/runs?per_page=10&status=success", c.owner, c.repo, encodedPath)
+	if branch != "" {
+		path += "&branch=" + url.QueryEscape(branch)
+	}
 
 	var response workflowRunsResponse
 	err := c.restClient.Get(path, &response)
 	if err != nil {
+		err = classifyError(err)
+		if errors.Is(err, ErrNotFound) {
+			c.runsCache[cacheKey] = nil
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to fetch workflow runs: %w", err)
 	}
 
+	c.runsCache[cacheKey] = response.WorkflowRuns
 	return response.WorkflowRuns, nil
 }
+
+// classifyError wraps GitHub API errors that callers handle specially with
+// ErrNotFound or ErrRateLimited. Other errors are returned unchanged.
+func classifyError(err error) error {
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+
+	switch {
+	case httpErr.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	case httpErr.StatusCode == http.StatusTooManyRequests,
+		httpErr.StatusCode == http.StatusForbidden && httpErr.Headers.Get("X-RateLimit-Remaining") == "0",
+		httpErr.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(httpErr.Message), "rate limit"):
+		return fmt.Errorf("%w: %v", ErrRateLimited, err)
+	}
+	return err
+}
+
+// repository represents the subset of the repository API response we need
+type repository struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// GetDefaultBranch gets the name of the repository's default branch
+func (c *Client) GetDefaultBranch(_ context.Context) (string, error) {
+	path := fmt.Sprintf("repos/%s/%s", c.owner, c.repo)
+
+	var response repository
+	if err := c.restClient.Get(path, &response); err != nil {
+		return "", fmt.Errorf("failed to fetch repository: %w", err)
+	}
+	if response.DefaultBranch == "" {
+		return "", fmt.Errorf("repository %s/%s has no default branch", c.owner, c.repo)
+	}
+
+	return response.DefaultBranch, nil
+}
+
+// PullRequest represents a created pull request
+type PullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// createPullRequestRequest represents the request body for the create pull request API
+type createPullRequestRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+// CreatePullRequest opens a pull request that merges head into base
+func (c *Client) CreatePullRequest(_ context.Context, title, body, head, base string) (*PullRequest, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls", c.owner, c.repo)
+
+	payload, err := json.Marshal(createPullRequestRequest{
+		Title: title,
+		Body:  body,
+		Head:  head,
+		Base:  base,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pull request: %w", err)
+	}
+
+	var response PullRequest
+	if err := c.restClient.Post(path, bytes.NewReader(payload), &response); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return &response, nil
+}
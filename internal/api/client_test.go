@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+		wantLimited  bool
+	}{
+		{
+			name:         "not found",
+			err:          &api.HTTPError{StatusCode: http.StatusNotFound},
+			wantNotFound: true,
+		},
+		{
+			name:        "too many requests",
+			err:         &api.HTTPError{StatusCode: http.StatusTooManyRequests},
+			wantLimited: true,
+		},
+		{
+			name: "primary rate limit",
+			err: &api.HTTPError{
+				StatusCode: http.StatusForbidden,
+				Headers:    http.Header{"X-Ratelimit-Remaining": []string{"0"}},
+			},
+			wantLimited: true,
+		},
+		{
+			name: "secondary rate limit",
+			err: &api.HTTPError{
+				StatusCode: http.StatusForbidden,
+				Message:    "You have exceeded a secondary rate limit",
+			},
+			wantLimited: true,
+		},
+		{
+			name: "forbidden without rate limit",
+			err: &api.HTTPError{
+				StatusCode: http.StatusForbidden,
+				Headers:    http.Header{"X-Ratelimit-Remaining": []string{"42"}},
+				Message:    "Resource not accessible by integration",
+			},
+		},
+		{
+			name: "server error",
+			err:  &api.HTTPError{StatusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "non-HTTP error",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err)
+			if errors.Is(got, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("errors.Is(classifyError(), ErrNotFound) = %v, want %v", !tt.wantNotFound, tt.wantNotFound)
+			}
+			if errors.Is(got, ErrRateLimited) != tt.wantLimited {
+				t.Errorf("errors.Is(classifyError(), ErrRateLimited) = %v, want %v", !tt.wantLimited, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestNewClient_Host(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		ghHost   string
+		wantHost string
+		wantPath string
+	}{
+		{
+			name:     "github.com",
+			host:     "github.com",
+			wantHost: "api.github.com",
+			wantPath: "/repos/owner/repo",
+		},
+		{
+			name:     "enterprise server",
+			host:     "ghe.example.com",
+			wantHost: "ghe.example.com",
+			wantPath: "/api/v3/repos/owner/repo",
+		},
+		{
+			name:     "GH_HOST when no host is given",
+			ghHost:   "ghe.example.com",
+			wantHost: "ghe.example.com",
+			wantPath: "/api/v3/repos/owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_HOST", tt.ghHost)
+			t.Setenv("GH_TOKEN", "token")
+			t.Setenv("GH_ENTERPRISE_TOKEN", "token")
+
+			var got *http.Request
+			client, err := newClient(tt.host, "owner", "repo", 0, roundTripFunc(func(req *http.Request) *http.Response {
+				got = req
+				return jsonResponse(req, http.StatusOK, nil, `{"default_branch": "main"}`)
+			}))
+			if err != nil {
+				t.Fatalf("newClient() error: %v", err)
+			}
+			if _, err := client.GetDefaultBranch(context.Background()); err != nil {
+				t.Fatalf("GetDefaultBranch() error: %v", err)
+			}
+
+			if got.URL.Host != tt.wantHost || got.URL.Path != tt.wantPath {
+				t.Errorf("request sent to %s%s, want %s%s", got.URL.Host, got.URL.Path, tt.wantHost, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestNewClient_RetriesRateLimited(t *testing.T) {
+	t.Setenv("GH_TOKEN", "token")
+
+	calls := 0
+	client, err := newClient("github.com", "owner", "repo", 1, roundTripFunc(func(req *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			return jsonResponse(req, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}}, `{"message": "You have exceeded a secondary rate limit"}`)
+		}
+		return jsonResponse(req, http.StatusOK, nil, `{"default_branch": "main"}`)
+	}))
+	if err != nil {
+		t.Fatalf("newClient() error: %v", err)
+	}
+	branch, err := client.GetDefaultBranch(context.Background())
+	if err != nil {
+		t.Fatalf("GetDefaultBranch() error: %v", err)
+	}
+	if branch != "main" || calls != 2 {
+		t.Errorf("GetDefaultBranch() = %q after %d requests, want main after 2", branch, calls)
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ContentEntry is a file or directory listed by the repository contents API
+type ContentEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"` // "file", "dir", "symlink" or "submodule"
+}
+
+// fileContent represents the response from the contents API for a file
+type fileContent struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// ListDirectory lists the entries of a directory in the repository's default branch.
+// Returns an error wrapping ErrNotFound if the directory does not exist.
+func (c *Client) ListDirectory(ctx context.Context, dir string) ([]ContentEntry, error) {
+	path := fmt.Sprintf("repos/%s/%s/contents/%s?per_page=100", c.owner, c.repo, escapePath(dir))
+
+	var entries []ContentEntry
+	for path != "" {
+		resp, err := c.restClient.RequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, classifyError(err))
+		}
+
+		var page []ContentEntry
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %s is not a directory or the response is invalid: %w", dir, dir, err)
+		}
+		entries = append(entries, page...)
+		path = nextPageURL(resp.Header.Get("Link"))
+	}
+
+	return entries, nil
+}
+
+// GetFileContent gets the content of a file in the repository's default branch.
+// Returns an error wrapping ErrNotFound if the file does not exist.
+func (c *Client) GetFileContent(_ context.Context, filePath string) ([]byte, error) {
+	path := fmt.Sprintf("repos/%s/%s/contents/%s", c.owner, c.repo, escapePath(filePath))
+
+	var response fileContent
+	if err := c.restClient.Get(path, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", filePath, classifyError(err))
+	}
+
+	return decodeContent(filePath, &response)
+}
+
+// decodeContent decodes the content of a file returned by the contents API.
+// Files over 1 MB come without content, which is reported as an error.
+func decodeContent(filePath string, content *fileContent) ([]byte, error) {
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("failed to fetch %s: unsupported encoding %q (files over 1 MB are not supported)", filePath, content.Encoding)
+	}
+	// The content is wrapped at 60 characters
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
+	}
+	return data, nil
+}
+
+// escapePath escapes each segment of a repository path for use in a URL
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// linkNext matches the URL of the next page in a Link response header, e.g.
+// <https://api.github.com/...&page=2>; rel="next"
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPageURL returns the URL of the next page from a Link response header,
+// or an empty string on the last page.
+func nextPageURL(link string) string {
+	m := linkNext.FindStringSubmatch(link)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// roundTripFunc serves API requests in tests
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// newTestClient returns a client for owner/repo whose requests are served by serve
+func newTestClient(t *testing.T, serve roundTripFunc) *Client {
+	t.Helper()
+	restClient, err := api.NewRESTClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: serve,
+	})
+	if err != nil {
+		t.Fatalf("failed to create REST client: %v", err)
+	}
+	return &Client{restClient: restClient, owner: "owner", repo: "repo"}
+}
+
+// jsonResponse returns a response with the given status, headers and JSON body
+func jsonResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestClient_ListDirectory(t *testing.T) {
+	const nextPage = "https://api.github.com/repositories/1/contents/.github/workflows?per_page=100&page=2"
+	var requested []string
+	client := newTestClient(t, func(req *http.Request) *http.Response {
+		requested = append(requested, req.URL.String())
+		if req.URL.Query().Get("page") == "2" {
+			return jsonResponse(req, http.StatusOK, nil, `[{"name": "sub", "path": ".github/workflows/sub", "type": "dir"}]`)
+		}
+		header := http.Header{"Link": []string{`<` + nextPage + `>; rel="next", <` + nextPage + `>; rel="last"`}}
+		return jsonResponse(req, http.StatusOK, header, `[{"name": "ci.yml", "path": ".github/workflows/ci.yml", "type": "file"}]`)
+	})
+
+	entries, err := client.ListDirectory(context.Background(), ".github/workflows")
+	if err != nil {
+		t.Fatalf("ListDirectory() error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path != ".github/workflows/ci.yml" || entries[1].Type != "dir" {
+		t.Errorf("ListDirectory() = %+v, want ci.yml and sub from both pages", entries)
+	}
+	want := []string{"https://api.github.com/repos/owner/repo/contents/.github/workflows?per_page=100", nextPage}
+	if strings.Join(requested, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %v, want %v", requested, want)
+	}
+}
+
+func TestClient_ListDirectory_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		header       http.Header
+		wantNotFound bool
+		wantLimited  bool
+	}{
+		{
+			name:         "missing directory",
+			status:       http.StatusNotFound,
+			wantNotFound: true,
+		},
+		{
+			name:        "rate limited",
+			status:      http.StatusForbidden,
+			header:      http.Header{"X-Ratelimit-Remaining": []string{"0"}},
+			wantLimited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) *http.Response {
+				return jsonResponse(req, tt.status, tt.header, `{"message": "error"}`)
+			})
+			_, err := client.ListDirectory(context.Background(), ".github/workflows")
+			if err == nil {
+				t.Fatal("ListDirectory() expected error")
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound || errors.Is(err, ErrRateLimited) != tt.wantLimited {
+				t.Errorf("ListDirectory() error = %v, want not found %v and rate limited %v", err, tt.wantNotFound, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestClient_GetFileContent(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) *http.Response {
+		if req.URL.Path != "/repos/owner/repo/contents/.github/workflows/ci.yml" {
+			return jsonResponse(req, http.StatusNotFound, nil, `{"message": "Not Found"}`)
+		}
+		// "on: push\n" wrapped like the API does
+		return jsonResponse(req, http.StatusOK, nil, `{"content": "b24\n6IHB1c2gK\n", "encoding": "base64"}`)
+	})
+
+	content, err := client.GetFileContent(context.Background(), ".github/workflows/ci.yml")
+	if err != nil || string(content) != "on: push\n" {
+		t.Errorf("GetFileContent() = (%q, %v), want (\"on: push\\n\", nil)", content, err)
+	}
+	if _, err := client.GetFileContent(context.Background(), ".slimifyignore"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetFileContent() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDecodeContent_TooLarge(t *testing.T) {
+	if _, err := decodeContent("big.yml", &fileContent{Encoding: "none"}); err == nil {
+		t.Error("decodeContent() expected error for a file without content")
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		repo                      string
+		ghHost                    string
+		wantHost, wantOwner, want string
+		wantErr                   bool
+	}{
+		{repo: "cli/cli", wantHost: "github.com", wantOwner: "cli", want: "cli"},
+		{repo: "org/app", ghHost: "ghe.example.com", wantHost: "ghe.example.com", wantOwner: "org", want: "app"},
+		{repo: "ghe.example.com/org/app", wantHost: "ghe.example.com", wantOwner: "org", want: "app"},
+		{repo: "cli", wantErr: true},
+		{repo: "cli/", wantErr: true},
+		{repo: "a/b/c/d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ghHost := tt.ghHost
+		if ghHost == "" {
+			ghHost = "github.com"
+		}
+		t.Setenv("GH_HOST", ghHost)
+
+		host, owner, repo, err := ParseRepo(tt.repo)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRepo(%q) error = %v, wantErr %v", tt.repo, err, tt.wantErr)
+			continue
+		}
+		if host != tt.wantHost || owner != tt.wantOwner || repo != tt.want {
+			t.Errorf("ParseRepo(%q) = (%q, %q, %q), want (%q, %q, %q)", tt.repo, host, owner, repo, tt.wantHost, tt.wantOwner, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package ghapi holds helpers shared by the features of slimify that call the
+// GitHub API.
+package ghapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetries is the number of times a rate limited request is retried
+// unless another number is configured.
+const DefaultRetries = 3
+
+const (
+	// initialBackoff is the wait before the first retry when the response
+	// doesn't say how long to wait. It doubles with each further retry.
+	initialBackoff = time.Second
+	// maxWait is the longest wait before a retry. Responses asking to wait
+	// longer, as when the primary rate limit resets in an hour, are returned
+	// as they are.
+	maxWait = time.Minute
+)
+
+// sleep waits for d, or until ctx is done. It is replaced in tests.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryTransport retries requests rejected by rate limiting.
+type retryTransport struct {
+	base    http.RoundTripper
+	retries int
+}
+
+// NewRetryTransport returns a transport sending requests through base, or
+// through http.DefaultTransport if nil, that retries a request up to retries
+// times while it is rejected by a rate limit: with status 429, or with status
+// 403 and a Retry-After header as for secondary rate limits. It waits as long
+// as Retry-After says, else with exponential backoff starting at one second.
+// Requests whose body can't be sent again are not retried.
+func NewRetryTransport(base http.RoundTripper, retries int) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, retries: retries}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || attempt >= t.retries || !isRateLimited(resp) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := retryAfter(resp, time.Now())
+		if !ok {
+			wait = backoff
+			backoff *= 2
+		}
+		if wait > maxWait {
+			return resp, nil
+		}
+
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// isRateLimited reports whether resp rejects its request because of a rate
+// limit worth waiting for.
+func isRateLimited(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// retryAfter returns how long resp asks to wait before retrying, from its
+// Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,203 @@
+package ghapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc serves requests in tests
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func response(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// recordSleeps replaces sleep for the test, recording the waits instead.
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := sleep
+	sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &waits
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		retries    int
+		responses  []*http.Response
+		wantStatus int
+		wantWaits  []time.Duration
+	}{
+		{
+			name:    "429 then 200",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, nil, ""),
+				response(http.StatusOK, nil, "ok"),
+			},
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{time.Second},
+		},
+		{
+			name:    "honors Retry-After",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusForbidden, http.Header{"Retry-After": []string{"7"}}, "secondary rate limit"),
+				response(http.StatusOK, nil, "ok"),
+			},
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{7 * time.Second},
+		},
+		{
+			name:    "backs off exponentially",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, nil, ""),
+				response(http.StatusTooManyRequests, nil, ""),
+				response(http.StatusTooManyRequests, nil, ""),
+				response(http.StatusOK, nil, "ok"),
+			},
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:    "gives up after retries",
+			retries: 1,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, nil, ""),
+				response(http.StatusTooManyRequests, nil, ""),
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantWaits:  []time.Duration{time.Second},
+		},
+		{
+			name:    "retries disabled",
+			retries: 0,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, nil, ""),
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:    "Retry-After too long",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3600"}}, ""),
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:    "forbidden without Retry-After",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusForbidden, http.Header{"X-Ratelimit-Remaining": []string{"0"}}, ""),
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:    "server error",
+			retries: 3,
+			responses: []*http.Response{
+				response(http.StatusInternalServerError, nil, ""),
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits := recordSleeps(t)
+			calls := 0
+			transport := NewRetryTransport(roundTripFunc(func(req *http.Request) *http.Response {
+				if calls >= len(tt.responses) {
+					t.Fatalf("unexpected request %d", calls+1)
+				}
+				calls++
+				return tt.responses[calls-1]
+			}), tt.retries)
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error: %v", err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if calls != len(tt.responses) {
+				t.Errorf("sent %d requests, want %d", calls, len(tt.responses))
+			}
+			if !reflect.DeepEqual(*waits, tt.wantWaits) {
+				t.Errorf("waited %v, want %v", *waits, tt.wantWaits)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_ResendsBody(t *testing.T) {
+	recordSleeps(t)
+	var bodies []string
+	transport := NewRetryTransport(roundTripFunc(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return response(http.StatusTooManyRequests, nil, "")
+		}
+		return response(http.StatusCreated, nil, "")
+	}), DefaultRetries)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/owner/repo/pulls", strings.NewReader(`{"title":"t"}`))
+	if err != nil {
+		t.Fatalf("NewRequest() error: %v", err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if want := []string{`{"title":"t"}`, `{"title":"t"}`}; !reflect.DeepEqual(bodies, want) {
+		t.Errorf("sent bodies %q, want %q", bodies, want)
+	}
+}
+
+func TestRetryTransport_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	transport := NewRetryTransport(roundTripFunc(func(req *http.Request) *http.Response {
+		return response(http.StatusTooManyRequests, nil, "")
+	}), DefaultRetries)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error: %v", err)
+	}
+	if _, err := transport.RoundTrip(req); err != context.Canceled {
+		t.Errorf("RoundTrip() error = %v, want %v", err, context.Canceled)
+	}
+}
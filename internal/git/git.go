@@ -0,0 +1,137 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// run executes a git command in the current working directory and returns its
+// trimmed stdout. On failure, the error includes git's stderr output.
+func run(args ...string) (string, error) {
+	out, err := output(args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// output executes a git command in the current working directory and returns
+// its stdout as is. On failure, the error includes git's stderr output.
+func output(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// HasUncommittedChanges reports whether the working tree has staged, unstaged
+// or untracked changes.
+func HasUncommittedChanges() (bool, error) {
+	out, err := run("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// CurrentBranch returns the name of the currently checked out branch.
+func CurrentBranch() (string, error) {
+	return run("rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// CreateBranch creates a new branch from HEAD and checks it out.
+// Uncommitted changes in the working tree are carried over to the new branch.
+func CreateBranch(name string) error {
+	_, err := run("checkout", "-b", name)
+	return err
+}
+
+// Checkout switches to an existing branch.
+func Checkout(name string) error {
+	_, err := run("checkout", name)
+	return err
+}
+
+// DeleteBranch deletes a local branch, even if it is not merged.
+func DeleteBranch(name string) error {
+	_, err := run("branch", "-D", name)
+	return err
+}
+
+// Commit stages the given paths and records a commit with message.
+func Commit(message string, paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths to commit")
+	}
+
+	addArgs := append([]string{"add", "--"}, paths...)
+	if _, err := run(addArgs...); err != nil {
+		return err
+	}
+
+	_, err := run("commit", "-m", message)
+	return err
+}
+
+// Push pushes branch to remote and sets it as the upstream.
+func Push(remote, branch string) error {
+	_, err := run("push", "--set-upstream", remote, branch)
+	return err
+}
+
+// DefaultBranch returns the default branch of remote as recorded in the local
+// clone (refs/remotes/<remote>/HEAD), without contacting the remote. Returns an
+// error if it is not recorded, e.g. in a repository that was not cloned.
+func DefaultBranch(remote string) (string, error) {
+	ref, err := run("symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(ref, remote+"/"), nil
+}
+
+// TreeFile is a regular file in the tree of a commit.
+type TreeFile struct {
+	Path   string // Relative to the current working directory
+	Object string // ID of the file's blob, for ReadBlob
+}
+
+// ListFiles returns the regular files under paths, files or directories
+// relative to the current working directory, in the tree of the commit ref.
+// Paths that don't exist at ref are ignored. Symlinks and submodules are left
+// out, since they have no content of their own.
+func ListFiles(ref string, paths ...string) ([]TreeFile, error) {
+	out, err := output(append([]string{"ls-tree", "-r", "-z", ref, "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []TreeFile
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// Each entry is "<mode> <type> <object>\t<path>"
+		info, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 3 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		files = append(files, TreeFile{Path: path, Object: fields[2]})
+	}
+	return files, nil
+}
+
+// ReadBlob returns the content of the blob with the given object ID.
+func ReadBlob(object string) ([]byte, error) {
+	return output("cat-file", "blob", object)
+}
@@ -0,0 +1,237 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupRepo creates a git repository with one commit in a temporary directory
+// and changes the working directory to it for the duration of the test.
+func setupRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	for _, args := range [][]string{
+		{"init", "--initial-branch=main"},
+		{"config", "user.name", "test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if _, err := run(args...); err != nil {
+			t.Fatalf("Failed to set up repository: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "ci.yml"), []byte("runs-on: ubuntu-latest\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := Commit("initial", "ci.yml"); err != nil {
+		t.Fatalf("Failed to create initial commit: %v", err)
+	}
+
+	return tmpDir
+}
+
+func TestHasUncommittedChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, dir string)
+		want   bool
+	}{
+		{
+			name:   "clean working tree",
+			modify: func(t *testing.T, dir string) {},
+			want:   false,
+		},
+		{
+			name: "modified tracked file",
+			modify: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte("runs-on: ubuntu-slim\n"), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			},
+			want: true,
+		},
+		{
+			name: "untracked file",
+			modify: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "new.yml"), []byte("x\n"), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupRepo(t)
+			tt.modify(t, dir)
+
+			got, err := HasUncommittedChanges()
+			if err != nil {
+				t.Fatalf("HasUncommittedChanges() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasUncommittedChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateBranchAndCommit(t *testing.T) {
+	dir := setupRepo(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte("runs-on: ubuntu-slim\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := CreateBranch("slimify/test"); err != nil {
+		t.Fatalf("CreateBranch() error: %v", err)
+	}
+	branch, err := CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch() error: %v", err)
+	}
+	if branch != "slimify/test" {
+		t.Errorf("CurrentBranch() = %q, want %q", branch, "slimify/test")
+	}
+
+	if err := Commit("Migrate eligible jobs to ubuntu-slim", "ci.yml"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+	dirty, err := HasUncommittedChanges()
+	if err != nil {
+		t.Fatalf("HasUncommittedChanges() error: %v", err)
+	}
+	if dirty {
+		t.Error("working tree should be clean after Commit()")
+	}
+
+	if err := Checkout("main"); err != nil {
+		t.Fatalf("Checkout() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "ci.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "runs-on: ubuntu-latest\n" {
+		t.Errorf("main branch content = %q, want original content", data)
+	}
+}
+
+func TestDeleteBranch(t *testing.T) {
+	setupRepo(t)
+
+	if err := CreateBranch("slimify/test"); err != nil {
+		t.Fatalf("CreateBranch() error: %v", err)
+	}
+	if err := Checkout("main"); err != nil {
+		t.Fatalf("Checkout() error: %v", err)
+	}
+	if err := DeleteBranch("slimify/test"); err != nil {
+		t.Fatalf("DeleteBranch() error: %v", err)
+	}
+	if err := Checkout("slimify/test"); err == nil {
+		t.Error("Checkout() of a deleted branch expected error")
+	}
+}
+
+func TestCommit_NoPaths(t *testing.T) {
+	setupRepo(t)
+	if err := Commit("empty"); err == nil {
+		t.Error("Commit() expected error when no paths are given")
+	}
+}
+
+func TestDefaultBranch(t *testing.T) {
+	setupRepo(t)
+
+	if _, err := DefaultBranch("origin"); err == nil {
+		t.Error("DefaultBranch() expected error without a recorded remote HEAD")
+	}
+
+	for _, args := range [][]string{
+		{"update-ref", "refs/remotes/origin/trunk", "HEAD"},
+		{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk"},
+	} {
+		if _, err := run(args...); err != nil {
+			t.Fatalf("Failed to record remote HEAD: %v", err)
+		}
+	}
+
+	got, err := DefaultBranch("origin")
+	if err != nil {
+		t.Fatalf("DefaultBranch() error: %v", err)
+	}
+	if got != "trunk" {
+		t.Errorf("DefaultBranch() = %q, want %q", got, "trunk")
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	dir := setupRepo(t)
+
+	workflows := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflows, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	content := "on: push\n  \n"
+	if err := os.WriteFile(filepath.Join(workflows, "build.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink("build.yml", filepath.Join(workflows, "link.yml")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := Commit("add workflows", ".github"); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	// Working tree changes are not part of the commit
+	if err := os.WriteFile(filepath.Join(workflows, "build.yml"), []byte("changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	files, err := ListFiles("HEAD", ".github/workflows", "missing.yml")
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != ".github/workflows/build.yml" {
+		t.Fatalf("ListFiles() = %+v, want only .github/workflows/build.yml", files)
+	}
+	data, err := ReadBlob(files[0].Object)
+	if err != nil {
+		t.Fatalf("ReadBlob() error: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("ReadBlob() = %q, want %q", data, content)
+	}
+
+	// Paths are relative to the current directory
+	t.Chdir(workflows)
+	files, err = ListFiles("HEAD~1", ".")
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("ListFiles(HEAD~1) = %+v, want no files", files)
+	}
+	files, err = ListFiles("HEAD", ".")
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "build.yml" {
+		t.Errorf("ListFiles() from the workflow directory = %+v, want build.yml", files)
+	}
+
+	if _, err := ListFiles("no-such-ref", "."); err == nil {
+		t.Error("ListFiles() expected error for an unknown ref")
+	}
+}
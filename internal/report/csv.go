@@ -0,0 +1,93 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// Categories of jobs in CSV output.
+const (
+	csvEligible   = "eligible"
+	csvIneligible = "ineligible"
+	csvSlim       = "slim"
+	csvOther      = "other"
+)
+
+// csvHeader is the header row of CSV output.
+var csvHeader = []string{"workflow_path", "job_id", "job_name", "category", "reason", "line", "duration"}
+
+// csvRow is a job in CSV output.
+type csvRow struct {
+	path     string
+	jobID    string
+	jobName  string
+	category string
+	reason   string
+	line     int
+	duration string
+}
+
+// WriteCSV writes scan results to w as CSV with a header row, one row per job,
+// e.g. to track migration in a spreadsheet. Each job has a category: eligible
+// for candidates, ineligible, slim for jobs already on ubuntu-slim, and other
+// for jobs on other operating systems, ignored jobs and jobs whose runs-on
+// can't be resolved. The reason column explains the category; a job's
+// reasons are joined with "; ". Rows are sorted by file and line.
+func WriteCSV(w io.Writer, result *scan.ScanResult) error {
+	var rows []csvRow
+	for _, c := range result.AllCandidates() {
+		var reason string
+		if len(c.MissingCommands) > 0 {
+			reason = "requires installing: " + strings.Join(c.MissingCommands, ", ")
+		}
+		rows = append(rows, csvRow{c.WorkflowPath, c.JobID, c.JobName, csvEligible, reason, c.LineNumber, c.Duration})
+	}
+	for _, c := range result.StaleJobs {
+		reason := "has not run recently"
+		if !c.LastRun.IsZero() {
+			reason += " (last run: " + c.LastRun.UTC().Format("2006-01-02") + ")"
+		}
+		rows = append(rows, csvRow{c.WorkflowPath, c.JobID, c.JobName, csvEligible, reason, c.LineNumber, c.Duration})
+	}
+	for _, j := range result.IneligibleJobs {
+		rows = append(rows, csvRow{j.WorkflowPath, j.JobID, j.JobName, csvIneligible, strings.Join(j.Reasons, "; "), j.LineNumber, ""})
+	}
+	for _, j := range result.AlreadySlimJobs {
+		rows = append(rows, csvRow{j.WorkflowPath, j.JobID, j.JobName, csvSlim, strings.Join(j.Reasons, "; "), j.LineNumber, ""})
+	}
+	for _, j := range result.OtherOSJobs {
+		rows = append(rows, csvRow{j.WorkflowPath, j.JobID, j.JobName, csvOther, "runs on " + strings.Join(j.OS, ", "), j.LineNumber, ""})
+	}
+	for _, j := range result.IgnoredJobs {
+		rows = append(rows, csvRow{j.WorkflowPath, j.JobID, j.JobName, csvOther, "ignored by " + scan.IgnoreFileName + " rule " + j.Rule, j.LineNumber, ""})
+	}
+	for _, j := range result.UnresolvedRunsOnJobs {
+		rows = append(rows, csvRow{j.WorkflowPath, j.JobID, j.JobName, csvOther, "runs-on uses " + strings.Join(j.Variables, ", "), j.LineNumber, ""})
+	}
+	sort.SliceStable(rows, func(i, k int) bool {
+		if rows[i].path != rows[k].path {
+			return rows[i].path < rows[k].path
+		}
+		if rows[i].line != rows[k].line {
+			return rows[i].line < rows[k].line
+		}
+		return rows[i].jobID < rows[k].jobID
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write([]string{r.path, r.jobID, r.jobName, r.category, r.reason, strconv.Itoa(r.line), r.duration}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
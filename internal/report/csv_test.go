@@ -0,0 +1,48 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// TestWriteCSV compares the CSV report with a golden file in testdata.
+func TestWriteCSV(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "lint", JobName: "Lint, fast", LineNumber: 12, Duration: "1m30s"},
+			{WorkflowPath: ".github/workflows/build.yml", JobID: "build", JobName: "build", LineNumber: 8},
+		},
+		NeedsSetup: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "archive", JobName: `archive "dist"`, LineNumber: 4, Duration: "45s", MissingCommands: []string{"zip", "unzip"}},
+		},
+		IneligibleJobs: []*scan.IneligibleJob{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "docker", JobName: "docker", LineNumber: 20, Reasons: []string{"uses Docker commands in steps 1, 2", "requires services: redis"}},
+		},
+		AlreadySlimJobs: []*scan.AlreadySlimJob{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "fmt", JobName: "fmt", LineNumber: 30},
+		},
+		OtherOSJobs: []*scan.OtherOSJob{
+			{WorkflowPath: ".github/workflows/release.yml", JobID: "mac", JobName: "mac", LineNumber: 5, OS: []string{"macOS"}},
+		},
+		UnresolvedRunsOnJobs: []*scan.UnresolvedRunsOnJob{
+			{WorkflowPath: ".github/workflows/release.yml", JobID: "notes", JobName: "notes", LineNumber: 9, Variables: []string{"vars.RUNNER"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, result); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "report.golden.csv"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("WriteCSV() =\n%s\nwant:\n%s", got, want)
+	}
+}
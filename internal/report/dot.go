@@ -0,0 +1,52 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// dotColors are the fill colors of jobs in DOT output, by status.
+var dotColors = map[scan.GraphStatus]string{
+	scan.GraphEligible:   "palegreen",
+	scan.GraphIneligible: "lightcoral",
+	scan.GraphSlim:       "lightblue",
+	scan.GraphOther:      "lightgray",
+}
+
+// WriteDOT writes job dependency graphs to w in the Graphviz DOT language, for
+// rendering with e.g. dot -Tsvg. Each workflow is a cluster, each job a node
+// filled with the color of its status, and each needs: an edge from the
+// needed job to the job that needs it.
+func WriteDOT(w io.Writer, graphs []*scan.JobGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph needs {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=filled];\n")
+	for i, g := range graphs {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", strconv.Quote(g.WorkflowPath))
+		for _, j := range g.Jobs {
+			label := strconv.Quote(j.JobName + "\n" + string(j.Status))
+			fmt.Fprintf(&b, "    %s [label=%s, fillcolor=%s];\n", dotNodeID(g, j.JobID), label, dotColors[j.Status])
+		}
+		for _, j := range g.Jobs {
+			for _, need := range j.Needs {
+				fmt.Fprintf(&b, "    %s -> %s;\n", dotNodeID(g, need), dotNodeID(g, j.JobID))
+			}
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotNodeID returns the quoted ID of a job's node, unique across workflows.
+func dotNodeID(g *scan.JobGraph, jobID string) string {
+	return strconv.Quote(g.WorkflowPath + ":" + jobID)
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// TestWriteDOT compares the DOT graph with a golden file in testdata.
+func TestWriteDOT(t *testing.T) {
+	graphs := []*scan.JobGraph{
+		{
+			WorkflowPath: ".github/workflows/ci.yml",
+			Jobs: []*scan.GraphJob{
+				{JobID: "lint", JobName: "lint", LineNumber: 4, Status: scan.GraphEligible, Dependents: []string{"build"}},
+				{JobID: "build", JobName: "build", LineNumber: 8, Status: scan.GraphIneligible, Needs: []string{"lint"}, Dependents: []string{"test"}},
+				{JobID: "test", JobName: `Unit "fast" tests`, LineNumber: 14, Status: scan.GraphSlim, Needs: []string{"build"}},
+			},
+		},
+		{
+			WorkflowPath: ".github/workflows/release.yml",
+			Jobs: []*scan.GraphJob{
+				{JobID: "mac", JobName: "mac", LineNumber: 5, Status: scan.GraphOther},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, graphs); err != nil {
+		t.Fatalf("WriteDOT() error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "graph.golden.dot"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("WriteDOT() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// WriteGitHubAnnotations writes scan results to w as GitHub Actions workflow
+// commands, so that each migration candidate shows up as a warning annotation
+// on its runs-on line in the workflow file, followed by an error annotation
+// for each job on ubuntu-slim that uses features it doesn't support and each
+// workflow file that could not be parsed.
+// See https://docs.github.com/actions/reference/workflow-commands-for-github-actions
+// Annotations are sorted by file and line so the output is deterministic.
+func WriteGitHubAnnotations(w io.Writer, result *scan.ScanResult) error {
+	candidates := result.AllCandidates()
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].WorkflowPath != candidates[j].WorkflowPath {
+			return candidates[i].WorkflowPath < candidates[j].WorkflowPath
+		}
+		return candidates[i].LineNumber < candidates[j].LineNumber
+	})
+
+	for _, c := range candidates {
+		properties := "file=" + escapeProperty(filepath.ToSlash(c.WorkflowPath))
+		if c.LineNumber > 0 {
+			properties += fmt.Sprintf(",line=%d", c.LineNumber)
+		}
+		message := fmt.Sprintf("Job %q can migrate to ubuntu-slim", c.JobName)
+		if len(c.MissingCommands) > 0 {
+			message += fmt.Sprintf(". Requires installing: %s", strings.Join(c.MissingCommands, ", "))
+		}
+		if _, err := fmt.Fprintf(w, "::warning %s::%s\n", properties, escapeData(message)); err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+
+	for _, job := range result.SlimRegressions() {
+		properties := "file=" + escapeProperty(filepath.ToSlash(job.WorkflowPath))
+		if job.LineNumber > 0 {
+			properties += fmt.Sprintf(",line=%d", job.LineNumber)
+		}
+		message := fmt.Sprintf("Job %q runs on ubuntu-slim but %s", job.JobName, strings.Join(job.Reasons, "; "))
+		if _, err := fmt.Fprintf(w, "::error %s::%s\n", properties, escapeData(message)); err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+
+	for _, e := range result.ParseErrors {
+		properties := "file=" + escapeProperty(filepath.ToSlash(e.Path))
+		if line := e.Line(); line > 0 {
+			properties += fmt.Sprintf(",line=%d", line)
+		}
+		if _, err := fmt.Fprintf(w, "::error %s::%s\n", properties, escapeData(e.Error())); err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+	return nil
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
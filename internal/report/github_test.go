@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "lint", JobName: "Lint", LineNumber: 12},
+			{WorkflowPath: ".github/workflows/build.yml", JobID: "build", JobName: "build", LineNumber: 8},
+		},
+		NeedsSetup: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "archive", JobName: "archive", LineNumber: 4, MissingCommands: []string{"zip"}},
+		},
+		IneligibleJobs: []*scan.IneligibleJob{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "docker", JobName: "docker", LineNumber: 20, Reasons: []string{"uses Docker commands in step 2"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, result); err != nil {
+		t.Fatalf("WriteGitHubAnnotations() error: %v", err)
+	}
+
+	want := `::warning file=.github/workflows/build.yml,line=8::Job "build" can migrate to ubuntu-slim
+::warning file=.github/workflows/ci.yml,line=4::Job "archive" can migrate to ubuntu-slim. Requires installing: zip
+::warning file=.github/workflows/ci.yml,line=12::Job "Lint" can migrate to ubuntu-slim
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteGitHubAnnotations() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteGitHubAnnotations_Escaping(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: "ci,v2.yml", JobID: "test", JobName: "100%\nlint", LineNumber: 0},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, result); err != nil {
+		t.Fatalf("WriteGitHubAnnotations() error: %v", err)
+	}
+
+	want := "::warning file=ci%2Cv2.yml::Job \"100%25\\nlint\" can migrate to ubuntu-slim\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteGitHubAnnotations() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteGitHubAnnotations_ParseErrors(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "test", JobName: "test", LineNumber: 4},
+		},
+		ParseErrors: []scan.FileError{
+			{Path: ".github/workflows/broken.yml", Err: &workflow.ParseError{Path: ".github/workflows/broken.yml", Line: 3, Err: errors.New("found a tab")}},
+			{Path: ".github/workflows/unreadable.yml", Err: errors.New("failed to read .github/workflows/unreadable.yml: permission denied")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, result); err != nil {
+		t.Fatalf("WriteGitHubAnnotations() error: %v", err)
+	}
+
+	want := `::warning file=.github/workflows/ci.yml,line=4::Job "test" can migrate to ubuntu-slim
+::error file=.github/workflows/broken.yml,line=3::failed to parse YAML .github/workflows/broken.yml:3: found a tab
+::error file=.github/workflows/unreadable.yml::failed to read .github/workflows/unreadable.yml: permission denied
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteGitHubAnnotations() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteGitHubAnnotations_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, &scan.ScanResult{}); err != nil {
+		t.Fatalf("WriteGitHubAnnotations() error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteGitHubAnnotations() = %q, want no output", buf.String())
+	}
+}
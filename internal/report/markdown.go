@@ -0,0 +1,161 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// WriteMarkdown writes scan results to w as GitHub-flavored Markdown, e.g. for
+// a pull request comment. Jobs are grouped by workflow: migration candidates
+// in a table, and ineligible and already-slim jobs in collapsed <details>
+// sections. Jobs on ubuntu-slim that use features it doesn't support are listed
+// in their own table, and workflow files that could not be parsed at the end.
+// Each line number links to linkBase followed by the workflow path
+// and a #L<line> anchor; with an empty linkBase the links are relative to the
+// repository root.
+func WriteMarkdown(w io.Writer, result *scan.ScanResult, linkBase string) error {
+	candidates := make(map[string][]*scan.Candidate)
+	ineligible := make(map[string][]*scan.IneligibleJob)
+	alreadySlim := make(map[string][]*scan.AlreadySlimJob)
+	regressions := make(map[string][]*scan.AlreadySlimJob)
+	paths := make(map[string]bool)
+
+	all := result.AllCandidates()
+	for _, c := range all {
+		candidates[c.WorkflowPath] = append(candidates[c.WorkflowPath], c)
+		paths[c.WorkflowPath] = true
+	}
+	for _, j := range result.IneligibleJobs {
+		ineligible[j.WorkflowPath] = append(ineligible[j.WorkflowPath], j)
+		paths[j.WorkflowPath] = true
+	}
+	for _, j := range result.AlreadySlimJobs {
+		if len(j.Reasons) > 0 {
+			regressions[j.WorkflowPath] = append(regressions[j.WorkflowPath], j)
+		} else {
+			alreadySlim[j.WorkflowPath] = append(alreadySlim[j.WorkflowPath], j)
+		}
+		paths[j.WorkflowPath] = true
+	}
+
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	var b strings.Builder
+	b.WriteString("## ubuntu-slim migration report\n\n")
+	if len(sortedPaths) == 0 {
+		b.WriteString("No jobs found.\n")
+	} else {
+		safe := 0
+		for _, c := range all {
+			if len(c.MissingCommands) == 0 && c.Duration != "" {
+				safe++
+			}
+		}
+		fmt.Fprintf(&b, "**%d** job(s) can migrate to `ubuntu-slim` (%d safe, %d need attention), %d cannot, %d already use it.\n",
+			len(all), safe, len(all)-safe, len(result.IneligibleJobs), len(result.AlreadySlimJobs))
+	}
+
+	for _, path := range sortedPaths {
+		fmt.Fprintf(&b, "\n### `%s`\n", filepath.ToSlash(path))
+
+		if jobs := candidates[path]; len(jobs) > 0 {
+			sortByLine(jobs, func(c *scan.Candidate) int { return c.LineNumber })
+			b.WriteString("\n| Job | Line | Duration | Status |\n|-----|------|----------|--------|\n")
+			for _, c := range jobs {
+				duration := c.Duration
+				if duration == "" {
+					duration = "unknown"
+				}
+				fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", escapeCell(c.JobName), lineLink(linkBase, path, c.LineNumber), duration, escapeCell(candidateStatus(c)))
+			}
+		}
+
+		if jobs := regressions[path]; len(jobs) > 0 {
+			sortByLine(jobs, func(j *scan.AlreadySlimJob) int { return j.LineNumber })
+			fmt.Fprintf(&b, "\n🚨 On `ubuntu-slim` but no longer safe (%d job(s)):\n\n", len(jobs))
+			b.WriteString("| Job | Line | Reason |\n|-----|------|--------|\n")
+			for _, j := range jobs {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", escapeCell(j.JobName), lineLink(linkBase, path, j.LineNumber), escapeCell(strings.Join(j.Reasons, "; ")))
+			}
+		}
+
+		if jobs := ineligible[path]; len(jobs) > 0 {
+			sortByLine(jobs, func(j *scan.IneligibleJob) int { return j.LineNumber })
+			fmt.Fprintf(&b, "\n<details>\n<summary>❌ Cannot migrate (%d job(s))</summary>\n\n", len(jobs))
+			b.WriteString("| Job | Line | Reason |\n|-----|------|--------|\n")
+			for _, j := range jobs {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", escapeCell(j.JobName), lineLink(linkBase, path, j.LineNumber), escapeCell(strings.Join(j.Reasons, "; ")))
+			}
+			b.WriteString("\n</details>\n")
+		}
+
+		if jobs := alreadySlim[path]; len(jobs) > 0 {
+			sortByLine(jobs, func(j *scan.AlreadySlimJob) int { return j.LineNumber })
+			fmt.Fprintf(&b, "\n<details>\n<summary>✨ Already using ubuntu-slim (%d job(s))</summary>\n\n", len(jobs))
+			b.WriteString("| Job | Line |\n|-----|------|\n")
+			for _, j := range jobs {
+				fmt.Fprintf(&b, "| %s | %s |\n", escapeCell(j.JobName), lineLink(linkBase, path, j.LineNumber))
+			}
+			b.WriteString("\n</details>\n")
+		}
+	}
+
+	if len(result.ParseErrors) > 0 {
+		fmt.Fprintf(&b, "\n### ⚠️ Workflows that could not be parsed\n\n")
+		for _, e := range result.ParseErrors {
+			fmt.Fprintf(&b, "- %s: %s\n", lineLink(linkBase, e.Path, e.Line()), escapeCell(e.Error()))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+// candidateStatus describes whether a candidate is safe to migrate or why it
+// needs attention.
+func candidateStatus(c *scan.Candidate) string {
+	var warnings []string
+	if len(c.MissingCommands) > 0 {
+		warnings = append(warnings, "requires installing: "+strings.Join(c.MissingCommands, ", "))
+	}
+	if c.MayIncreaseRuntime() {
+		warnings = append(warnings, fmt.Sprintf("migration may increase runtime: %d packages to install", c.InstallCount()))
+	}
+	if c.Duration == "" {
+		warnings = append(warnings, "last execution time unknown")
+	}
+	if len(warnings) == 0 {
+		return "✅ Safe to migrate"
+	}
+	return "⚠️ " + strings.Join(warnings, "; ")
+}
+
+// lineLink formats a line number as a link to the line in the workflow file.
+func lineLink(linkBase, path string, line int) string {
+	if line <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("[L%d](%s%s#L%d)", line, linkBase, filepath.ToSlash(path), line)
+}
+
+// escapeCell escapes text for a Markdown table cell, which can't contain
+// pipes or line breaks.
+func escapeCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r", "", "\n", " ").Replace(s)
+}
+
+// sortByLine sorts jobs of a single workflow by line number.
+func sortByLine[T any](jobs []T, line func(T) int) {
+	sort.SliceStable(jobs, func(i, j int) bool { return line(jobs[i]) < line(jobs[j]) })
+}
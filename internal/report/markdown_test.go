@@ -0,0 +1,71 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// TestWriteMarkdown compares the Markdown report with golden files in testdata.
+func TestWriteMarkdown(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "lint", JobName: "Lint", LineNumber: 12, Duration: "1m30s"},
+			{WorkflowPath: ".github/workflows/build.yml", JobID: "build", JobName: "build", LineNumber: 8},
+		},
+		NeedsSetup: []*scan.Candidate{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "archive", JobName: "archive | zip", LineNumber: 4, Duration: "45s", MissingCommands: []string{"zip"}},
+		},
+		IneligibleJobs: []*scan.IneligibleJob{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "docker", JobName: "docker", LineNumber: 20, Reasons: []string{"uses Docker commands in step 2", "requires services: redis"}},
+		},
+		AlreadySlimJobs: []*scan.AlreadySlimJob{
+			{WorkflowPath: ".github/workflows/ci.yml", JobID: "fmt", JobName: "fmt", LineNumber: 30},
+			{WorkflowPath: ".github/workflows/release.yml", JobID: "notes", JobName: "notes", LineNumber: 5},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		result   *scan.ScanResult
+		linkBase string
+		golden   string
+	}{
+		{
+			name:   "relative links",
+			result: result,
+			golden: "report.golden.md",
+		},
+		{
+			name:     "links to a commit",
+			result:   result,
+			linkBase: "https://github.com/owner/repo/blob/0123abc/",
+			golden:   "report-commit-links.golden.md",
+		},
+		{
+			name:   "no jobs",
+			result: &scan.ScanResult{},
+			golden: "empty.golden.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteMarkdown(&buf, tt.result, tt.linkBase); err != nil {
+				t.Fatalf("WriteMarkdown() error: %v", err)
+			}
+
+			want, err := os.ReadFile(filepath.Join("testdata", tt.golden))
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("WriteMarkdown() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
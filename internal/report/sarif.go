@@ -0,0 +1,200 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// RuleCandidate is the SARIF rule ID for jobs that can migrate to ubuntu-slim.
+	RuleCandidate = "slimify/ubuntu-slim-candidate"
+	// RuleIneligible is the SARIF rule ID for jobs that cannot migrate to ubuntu-slim.
+	RuleIneligible = "slimify/ubuntu-slim-ineligible"
+	// RuleSlimRegression is the SARIF rule ID for jobs on ubuntu-slim that use
+	// features it does not support.
+	RuleSlimRegression = "slimify/ubuntu-slim-regression"
+	// RuleParseError is the SARIF rule ID for workflow files that could not be parsed.
+	RuleParseError = "slimify/workflow-parse-error"
+)
+
+// sarifLog is the top-level SARIF document
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifRules describes the rules referenced by results
+var sarifRules = []sarifRule{
+	{
+		ID:               RuleCandidate,
+		ShortDescription: sarifMessage{Text: "Job can migrate to ubuntu-slim"},
+		FullDescription:  sarifMessage{Text: "The job meets all ubuntu-slim migration criteria and can switch its runs-on label to ubuntu-slim."},
+	},
+	{
+		ID:               RuleIneligible,
+		ShortDescription: sarifMessage{Text: "Job cannot migrate to ubuntu-slim"},
+		FullDescription:  sarifMessage{Text: "The job uses features that ubuntu-slim does not support, such as Docker, services or containers."},
+	},
+	{
+		ID:               RuleSlimRegression,
+		ShortDescription: sarifMessage{Text: "Job on ubuntu-slim uses unsupported features"},
+		FullDescription:  sarifMessage{Text: "The job already runs on ubuntu-slim but uses features it does not support, such as Docker, services or containers, so it will likely fail."},
+	},
+	{
+		ID:               RuleParseError,
+		ShortDescription: sarifMessage{Text: "Workflow file could not be parsed"},
+		FullDescription:  sarifMessage{Text: "The workflow file could not be read or is not valid YAML, so its jobs were not checked."},
+	},
+}
+
+// WriteSARIF writes scan results to w as a SARIF 2.1.0 log.
+// Each migration candidate is reported as a warning at its runs-on line.
+// Ineligible jobs are reported as notes with the rejection reasons in the message.
+// Jobs on ubuntu-slim that use features it does not support, and workflow
+// files that could not be parsed, are reported as errors.
+// Results are sorted by file and line so the output is deterministic.
+func WriteSARIF(w io.Writer, result *scan.ScanResult) error {
+	var results []sarifResult
+
+	for _, c := range result.AllCandidates() {
+		text := fmt.Sprintf("Job %q can migrate to ubuntu-slim.", c.JobName)
+		if len(c.MissingCommands) > 0 {
+			text += fmt.Sprintf(" Requires installing: %s.", strings.Join(c.MissingCommands, ", "))
+		}
+		results = append(results, newSARIFResult(RuleCandidate, "warning", text, c.WorkflowPath, c.LineNumber))
+	}
+
+	for _, job := range result.IneligibleJobs {
+		text := fmt.Sprintf("Job %q cannot migrate to ubuntu-slim: %s.", job.JobName, strings.Join(job.Reasons, "; "))
+		results = append(results, newSARIFResult(RuleIneligible, "note", text, job.WorkflowPath, job.LineNumber))
+	}
+
+	for _, job := range result.SlimRegressions() {
+		text := fmt.Sprintf("Job %q runs on ubuntu-slim but %s.", job.JobName, strings.Join(job.Reasons, "; "))
+		results = append(results, newSARIFResult(RuleSlimRegression, "error", text, job.WorkflowPath, job.LineNumber))
+	}
+
+	for _, e := range result.ParseErrors {
+		results = append(results, newSARIFResult(RuleParseError, "error", e.Error(), e.Path, e.Line()))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		li, lj := results[i].Locations[0].PhysicalLocation, results[j].Locations[0].PhysicalLocation
+		if li.ArtifactLocation.URI != lj.ArtifactLocation.URI {
+			return li.ArtifactLocation.URI < lj.ArtifactLocation.URI
+		}
+		return regionLine(li.Region) < regionLine(lj.Region)
+	})
+
+	if results == nil {
+		results = []sarifResult{}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "gh-slimify",
+						InformationURI: "https://github.com/fchimpan/gh-slimify",
+						Rules:          sarifRules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return nil
+}
+
+// newSARIFResult builds a result located at the given workflow file and line.
+// The region is omitted when the line number is unknown (0).
+func newSARIFResult(ruleID, level, text, path string, line int) sarifResult {
+	location := sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)},
+	}
+	if line > 0 {
+		location.Region = &sarifRegion{StartLine: line}
+	}
+	return sarifResult{
+		RuleID:    ruleID,
+		Level:     level,
+		Message:   sarifMessage{Text: text},
+		Locations: []sarifLocation{{PhysicalLocation: location}},
+	}
+}
+
+// regionLine returns the start line of a region, or 0 if the region is nil.
+func regionLine(r *sarifRegion) int {
+	if r == nil {
+		return 0
+	}
+	return r.StartLine
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+func TestWriteSARIF_RoundTrip(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{
+				WorkflowPath: ".github/workflows/ci.yml",
+				JobID:        "lint",
+				JobName:      "Lint",
+				LineNumber:   12,
+			},
+			{
+				WorkflowPath:    ".github/workflows/build.yml",
+				JobID:           "build",
+				JobName:         "build",
+				LineNumber:      8,
+				MissingCommands: []string{"zip", "jq"},
+			},
+		},
+		IneligibleJobs: []*scan.IneligibleJob{
+			{
+				WorkflowPath: ".github/workflows/ci.yml",
+				JobID:        "docker",
+				JobName:      "docker",
+				LineNumber:   20,
+				Reasons:      []string{"uses Docker commands in step 2", "requires services: postgres"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, result); err != nil {
+		t.Fatalf("WriteSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() produced invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Errorf("version = %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("len(runs) = %d, want 1", len(log.Runs))
+	}
+
+	results := log.Runs[0].Results
+	want := []struct {
+		ruleID      string
+		level       string
+		uri         string
+		line        int
+		messagePart string
+	}{
+		{RuleCandidate, "warning", ".github/workflows/build.yml", 8, "Requires installing: zip, jq."},
+		{RuleCandidate, "warning", ".github/workflows/ci.yml", 12, `Job "Lint" can migrate to ubuntu-slim.`},
+		{RuleIneligible, "note", ".github/workflows/ci.yml", 20, "uses Docker commands in step 2; requires services: postgres"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(want))
+	}
+
+	for i, w := range want {
+		got := results[i]
+		if got.RuleID != w.ruleID {
+			t.Errorf("results[%d].ruleId = %q, want %q", i, got.RuleID, w.ruleID)
+		}
+		if got.Level != w.level {
+			t.Errorf("results[%d].level = %q, want %q", i, got.Level, w.level)
+		}
+		loc := got.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != w.uri {
+			t.Errorf("results[%d].uri = %q, want %q", i, loc.ArtifactLocation.URI, w.uri)
+		}
+		if loc.Region == nil || loc.Region.StartLine != w.line {
+			t.Errorf("results[%d].region = %+v, want startLine %d", i, loc.Region, w.line)
+		}
+		if !strings.Contains(got.Message.Text, w.messagePart) {
+			t.Errorf("results[%d].message = %q, want it to contain %q", i, got.Message.Text, w.messagePart)
+		}
+	}
+}
+
+func TestWriteSARIF_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, &scan.ScanResult{}); err != nil {
+		t.Fatalf("WriteSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() produced invalid JSON: %v", err)
+	}
+	if log.Runs[0].Results == nil || len(log.Runs[0].Results) != 0 {
+		t.Errorf("results = %v, want empty array", log.Runs[0].Results)
+	}
+	if !strings.Contains(buf.String(), `"results": []`) {
+		t.Errorf("empty results should be encoded as [], got:\n%s", buf.String())
+	}
+}
+
+func TestWriteSARIF_UnknownLine(t *testing.T) {
+	result := &scan.ScanResult{
+		Candidates: []*scan.Candidate{
+			{WorkflowPath: "ci.yml", JobID: "test", JobName: "test", LineNumber: 0},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, result); err != nil {
+		t.Fatalf("WriteSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() produced invalid JSON: %v", err)
+	}
+	if region := log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region; region != nil {
+		t.Errorf("region = %+v, want nil for unknown line", region)
+	}
+}
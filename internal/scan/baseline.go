@@ -0,0 +1,31 @@
+package scan
+
+import "slices"
+
+// FilterExisting removes the candidates that base also reports as candidates
+// from Candidates and NeedsSetup, and returns how many were removed, so that
+// only the candidates new since base remain. base is typically the scan of
+// the same workflows at an earlier commit. Jobs are matched by workflow path
+// and job ID, and jobs of reusable workflows also by their calling job; a job
+// that was ineligible in base and became a candidate is new.
+func (r *ScanResult) FilterExisting(base *ScanResult) int {
+	existing := make(map[string]bool)
+	for _, c := range slices.Concat(base.AllCandidates(), base.StaleJobs) {
+		existing[candidateKey(c)] = true
+	}
+
+	old := func(c *Candidate) bool { return existing[candidateKey(c)] }
+	before := len(r.Candidates) + len(r.NeedsSetup)
+	r.Candidates = slices.DeleteFunc(r.Candidates, old)
+	r.NeedsSetup = slices.DeleteFunc(r.NeedsSetup, old)
+	return before - len(r.Candidates) - len(r.NeedsSetup)
+}
+
+// candidateKey identifies a candidate across scans of different commits.
+func candidateKey(c *Candidate) string {
+	key := c.WorkflowPath + "\x00" + c.JobID
+	if c.Caller != nil {
+		key += "\x00" + c.Caller.String()
+	}
+	return key
+}
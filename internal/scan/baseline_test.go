@@ -0,0 +1,76 @@
+package scan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanResult_FilterExisting(t *testing.T) {
+	before := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build -t app .
+  zip:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+`
+	// The pull request adds docs, makes build eligible by dropping Docker, and
+	// moves lint and zip around
+	after := `on: push
+jobs:
+  docs:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make docs
+  zip:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: go build ./...
+  lint:
+    name: Lint
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+	opts := Options{SkipDuration: true}
+	base, err := ScanReader(strings.NewReader(before), ".github/workflows/ci.yml", opts)
+	if err != nil {
+		t.Fatalf("ScanReader(before) error: %v", err)
+	}
+	head, err := ScanReader(strings.NewReader(after), ".github/workflows/ci.yml", opts)
+	if err != nil {
+		t.Fatalf("ScanReader(after) error: %v", err)
+	}
+
+	if removed := head.FilterExisting(base); removed != 2 {
+		t.Errorf("FilterExisting() = %d, want 2", removed)
+	}
+	var ids []string
+	for _, c := range head.AllCandidates() {
+		ids = append(ids, c.JobID)
+	}
+	if want := []string{"docs", "build"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("candidates after FilterExisting() = %v, want %v", ids, want)
+	}
+
+	// The same job in another workflow file is a different candidate
+	other, err := ScanReader(strings.NewReader(before), ".github/workflows/other.yml", opts)
+	if err != nil {
+		t.Fatalf("ScanReader(other) error: %v", err)
+	}
+	if removed := other.FilterExisting(base); removed != 0 {
+		t.Errorf("FilterExisting() of another workflow = %d, want 0", removed)
+	}
+}
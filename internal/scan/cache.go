@@ -0,0 +1,208 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// Cache stores the classification of workflow files on disk, so that files
+// unchanged since a previous scan are not parsed and analyzed again, e.g. when
+// scanning repeatedly in a watch loop. Entries are keyed by a hash of the
+// file's path and content and of the scan options, and entries written by
+// another version are ignored and replaced. Workflows that use local actions
+// or call local reusable workflows depend on other files, as do reusable
+// workflows, which are reported through their callers, so they are never
+// cached. Durations are not cached; they are fetched on every scan.
+type Cache struct {
+	dir     string
+	version string
+
+	// hits and misses count the lookups of this cache, for tests
+	hits, misses int
+}
+
+// NewCache returns a cache storing entries in dir, which is created on the
+// first write. version identifies the build of slimify writing the entries.
+func NewCache(dir, version string) *Cache {
+	return &Cache{dir: dir, version: version}
+}
+
+// cacheEntry is the cached classification of a single workflow file.
+type cacheEntry struct {
+	path string // Workflow path the entry was looked up for, not stored
+
+	Version              string
+	Candidates           []*Candidate
+	IneligibleJobs       []*IneligibleJob
+	AlreadySlimJobs      []*AlreadySlimJob
+	IgnoredJobs          []*IgnoredJob
+	OtherOSJobs          []*OtherOSJob
+	UnresolvedRunsOnJobs []*UnresolvedRunsOnJob
+}
+
+// cacheOptions are the scan options a file's classification depends on.
+type cacheOptions struct {
+	SourceLabels          []string
+	SlimLabel             string
+	AvailableCommands     []string
+	MissingCommands       []string
+	DockerActions         []string
+	ContainerCommands     []string
+	SafeDockerSubcommands []string
+	MinActionVersions     []string // Sorted "action@v<major>" entries
+	ResolveVars           []string // Sorted "NAME=value" entries
+	IgnoreRules           []string
+}
+
+// newCacheOptions collects the scan options that affect classification, in a
+// stable order so that equal options hash the same.
+func newCacheOptions(cl *classifier) cacheOptions {
+	opts := cacheOptions{
+		SourceLabels:          cl.sourceLabels,
+		SlimLabel:             cl.slimLabel,
+		AvailableCommands:     cl.availableCommands,
+		MissingCommands:       cl.missingCommands,
+		DockerActions:         cl.dockerActions,
+		SafeDockerSubcommands: cl.safeDockerSubcommands,
+	}
+	for _, pattern := range cl.containerCommands {
+		opts.ContainerCommands = append(opts.ContainerCommands, pattern.String())
+	}
+	for action, major := range cl.minActionVersions {
+		opts.MinActionVersions = append(opts.MinActionVersions, fmt.Sprintf("%s@v%d", action, major))
+	}
+	sort.Strings(opts.MinActionVersions)
+	for name, value := range cl.resolveVars {
+		opts.ResolveVars = append(opts.ResolveVars, name+"="+value)
+	}
+	sort.Strings(opts.ResolveVars)
+	for _, rule := range cl.ignoreRules {
+		opts.IgnoreRules = append(opts.IgnoreRules, rule.raw)
+	}
+	return opts
+}
+
+// cacheKey returns the key of the entry for the workflow at path with the
+// given content, scanned with opts.
+func cacheKey(path string, content []byte, opts cacheOptions) (string, error) {
+	encodedOpts, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%x\x00", filepath.ToSlash(filepath.Clean(path)), sha256.Sum256(content))
+	h.Write(encodedOpts)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// lookup returns the entry for key, or false if there is none for this version.
+func (c *Cache) lookup(key string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		c.misses++
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != c.version {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return &entry, true
+}
+
+// store writes entry under key. The entry is written to a temporary file and
+// renamed, so concurrent scans never read a partial entry.
+func (c *Cache) store(key string, entry *cacheEntry) error {
+	entry.Version = c.version
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	f, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	_, writeErr := f.Write(data)
+	closeErr := f.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(f.Name(), filepath.Join(c.dir, key+".json")); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// cacheLookup is the outcome of looking up the workflow files of a scan.
+type cacheLookup struct {
+	cache   *Cache
+	entries []*cacheEntry     // Entries of the unchanged files
+	keys    map[string]string // Keys to store the entries of the other files under, by path
+}
+
+// lookupFiles looks up the entries of files scanned with opts. It returns the
+// lookup and the files that have no entry and need to be parsed. Files that
+// can't be read are returned for parsing without a key, so that loading them
+// reports the error.
+func (c *Cache) lookupFiles(files []string, opts cacheOptions) (*cacheLookup, []string) {
+	lookup := &cacheLookup{cache: c, keys: make(map[string]string)}
+	var misses []string
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			misses = append(misses, path)
+			continue
+		}
+		key, err := cacheKey(path, content, opts)
+		if err != nil {
+			misses = append(misses, path)
+			continue
+		}
+		if entry, ok := c.lookup(key); ok {
+			slog.Debug("using cached scan result", "path", path)
+			entry.path = path
+			lookup.entries = append(lookup.entries, entry)
+			continue
+		}
+		lookup.keys[path] = key
+		misses = append(misses, path)
+	}
+	return lookup, misses
+}
+
+// cacheable reports whether the classification of wf depends only on its own
+// content, i.e. it neither uses local actions nor calls local reusable workflows,
+// and it is not a reusable workflow, which is reported only if not called.
+func cacheable(wf *workflow.Workflow) bool {
+	if onlyCalled(wf) {
+		return false
+	}
+	for _, job := range wf.Jobs {
+		if _, ok := job.LocalReusableWorkflowPath(); ok {
+			return false
+		}
+		if slices.ContainsFunc(job.Steps, func(s workflow.Step) bool {
+			_, ok := s.LocalActionPath()
+			return ok
+		}) {
+			return false
+		}
+	}
+	return true
+}
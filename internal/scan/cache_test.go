@@ -0,0 +1,162 @@
+package scan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScan_Cache(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	files := map[string]string{
+		".github/workflows/ci.yml": `name: CI
+on: [push, pull_request]
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    if: github.event_name == 'push'
+    steps:
+      - uses: actions/checkout@v2
+      - uses: actions/setup-node@v4
+      - run: npm run lint
+  archive:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+  slim:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make
+  mac:
+    runs-on: macos-latest
+    steps:
+      - run: make
+`,
+		".github/workflows/local.yml": `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/test
+`,
+		".github/actions/test/action.yml": `runs:
+  using: composite
+  steps:
+    - run: make test
+      shell: bash
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	scanWith := func(cache *Cache, availableCommands []string) *ScanResult {
+		t.Helper()
+		result, err := Scan(Options{SkipDuration: true, AvailableCommands: availableCommands, Cache: cache})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		return result
+	}
+	encode := func(result *ScanResult) string {
+		t.Helper()
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("Failed to encode result: %v", err)
+		}
+		return string(data)
+	}
+	uncached := encode(scanWith(nil, nil))
+
+	tests := []struct {
+		name              string
+		version           string
+		availableCommands []string
+		edit              bool
+		wantHits          int
+		wantMisses        int
+	}{
+		// local.yml uses a local action, so it is looked up but never stored
+		{name: "first scan", version: "v1", wantHits: 0, wantMisses: 2},
+		{name: "unchanged files", version: "v1", wantHits: 1, wantMisses: 1},
+		{name: "edited file", version: "v1", edit: true, wantHits: 0, wantMisses: 2},
+		{name: "unchanged after edit", version: "v1", wantHits: 1, wantMisses: 1},
+		{name: "other options", version: "v1", availableCommands: []string{"zip"}, wantHits: 0, wantMisses: 2},
+		{name: "other version", version: "v2", wantHits: 0, wantMisses: 2},
+		{name: "unchanged with new version", version: "v2", wantHits: 1, wantMisses: 1},
+	}
+	for _, tt := range tests {
+		if tt.edit {
+			// A comment changes the content hash but not the result
+			f, err := os.OpenFile(".github/workflows/ci.yml", os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				t.Fatalf("Failed to open workflow: %v", err)
+			}
+			f.WriteString("# edited\n")
+			f.Close()
+		}
+
+		cache := NewCache(dir, tt.version)
+		result := scanWith(cache, tt.availableCommands)
+		if cache.hits != tt.wantHits || cache.misses != tt.wantMisses {
+			t.Errorf("%s: hits = %d, misses = %d, want %d and %d", tt.name, cache.hits, cache.misses, tt.wantHits, tt.wantMisses)
+		}
+		if tt.availableCommands == nil {
+			if got := encode(result); got != uncached {
+				t.Errorf("%s: result = %s, want %s", tt.name, got, uncached)
+			}
+		} else if len(result.NeedsSetup) != 0 {
+			t.Errorf("%s: NeedsSetup = %d, want 0 with zip available", tt.name, len(result.NeedsSetup))
+		}
+	}
+}
+
+func TestScan_CacheOnlyUnchangedFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.MkdirAll(".github/workflows", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	write := func(path, runsOn string) {
+		t.Helper()
+		content := "on: push\njobs:\n  build:\n    runs-on: " + runsOn + "\n    steps:\n      - run: make\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	write(".github/workflows/a.yml", "ubuntu-latest")
+	write(".github/workflows/b.yml", "ubuntu-latest")
+
+	dir := t.TempDir()
+	if _, err := Scan(Options{SkipDuration: true, Cache: NewCache(dir, "v1")}); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	write(".github/workflows/b.yml", "ubuntu-slim")
+	cache := NewCache(dir, "v1")
+	result, err := Scan(Options{SkipDuration: true, Cache: cache})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if cache.hits != 1 || cache.misses != 1 {
+		t.Errorf("hits = %d, misses = %d, want 1 and 1", cache.hits, cache.misses)
+	}
+	if len(result.Candidates) != 1 || result.Candidates[0].WorkflowPath != filepath.Join(".github", "workflows", "a.yml") {
+		t.Errorf("Candidates = %+v, want the build job of a.yml", result.Candidates)
+	}
+	if len(result.AlreadySlimJobs) != 1 || result.AlreadySlimJobs[0].WorkflowPath != filepath.Join(".github", "workflows", "b.yml") {
+		t.Errorf("AlreadySlimJobs = %+v, want the build job of b.yml", result.AlreadySlimJobs)
+	}
+}
@@ -0,0 +1,61 @@
+package scan
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Confidence is how likely a candidate is to work on ubuntu-slim unchanged.
+type Confidence string
+
+// Confidence levels, from least to most confident.
+const (
+	ConfidenceLow    Confidence = "low"    // Uses commands missing in ubuntu-slim, or scripts not fully checked
+	ConfidenceMedium Confidence = "medium" // Uses setup actions, a runs-on matrix or a job-level if:
+	ConfidenceHigh   Confidence = "high"   // Only runs-on needs to change
+)
+
+// confidenceLevels lists the confidence levels from least to most confident.
+var confidenceLevels = []Confidence{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+
+// ParseConfidence parses a confidence level name (low, medium or high).
+func ParseConfidence(s string) (Confidence, error) {
+	c := Confidence(s)
+	if !slices.Contains(confidenceLevels, c) {
+		return "", fmt.Errorf("invalid confidence %q: must be one of low, medium, high", s)
+	}
+	return c, nil
+}
+
+// AtLeast reports whether c is at least as confident as min.
+func (c Confidence) AtLeast(min Confidence) bool {
+	return slices.Index(confidenceLevels, c) >= slices.Index(confidenceLevels, min)
+}
+
+// scoreConfidence derives the confidence of a candidate from the signals the
+// scan already collected. Missing commands need a setup step that may not be
+// enough, and scripts of non-POSIX shells were not checked for them, so both
+// make it low. Setup actions may install tools expecting
+// packages of ubuntu-latest, a cache of Docker layers hints at images built in
+// a way not detected, and matrix values or a job-level if: mean some runs of
+// the job were not checked as written, so they make it medium.
+func scoreConfidence(c *Candidate) Confidence {
+	switch {
+	case len(c.MissingCommands) > 0 || len(c.NonPOSIXShellSteps) > 0:
+		return ConfidenceLow
+	case len(c.SetupActions) > 0 || len(c.DockerLayerCacheSteps) > 0 || c.RunsOnMatrix || c.Condition != "":
+		return ConfidenceMedium
+	default:
+		return ConfidenceHigh
+	}
+}
+
+// FilterByConfidence removes the candidates less confident than min from
+// Candidates and NeedsSetup, and returns how many were removed.
+func (r *ScanResult) FilterByConfidence(min Confidence) int {
+	below := func(c *Candidate) bool { return !c.Confidence.AtLeast(min) }
+	before := len(r.Candidates) + len(r.NeedsSetup)
+	r.Candidates = slices.DeleteFunc(r.Candidates, below)
+	r.NeedsSetup = slices.DeleteFunc(r.NeedsSetup, below)
+	return before - len(r.Candidates) - len(r.NeedsSetup)
+}
@@ -0,0 +1,90 @@
+package scan
+
+import (
+	"testing"
+)
+
+func TestScoreConfidence(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate *Candidate
+		want      Confidence
+	}{
+		{
+			name:      "only runs-on changes",
+			candidate: &Candidate{},
+			want:      ConfidenceHigh,
+		},
+		{
+			name:      "setup actions",
+			candidate: &Candidate{SetupActions: []string{"actions/setup-node"}},
+			want:      ConfidenceMedium,
+		},
+		{
+			name:      "cache of Docker layers",
+			candidate: &Candidate{DockerLayerCacheSteps: []int{2}},
+			want:      ConfidenceMedium,
+		},
+		{
+			name:      "runs-on matrix",
+			candidate: &Candidate{RunsOnMatrix: true},
+			want:      ConfidenceMedium,
+		},
+		{
+			name:      "job-level condition",
+			candidate: &Candidate{Condition: "github.event_name == 'push'"},
+			want:      ConfidenceMedium,
+		},
+		{
+			name:      "missing commands outweigh other signals",
+			candidate: &Candidate{MissingCommands: []string{"zip"}, SetupActions: []string{"actions/setup-node"}},
+			want:      ConfidenceLow,
+		},
+		{
+			name:      "scripts of a non-POSIX shell",
+			candidate: &Candidate{NonPOSIXShellSteps: []int{2}},
+			want:      ConfidenceLow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreConfidence(tt.candidate); got != tt.want {
+				t.Errorf("scoreConfidence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfidence(t *testing.T) {
+	for _, level := range []string{"low", "medium", "high"} {
+		if got, err := ParseConfidence(level); err != nil || string(got) != level {
+			t.Errorf("ParseConfidence(%q) = (%q, %v), want (%q, nil)", level, got, err, level)
+		}
+	}
+	if _, err := ParseConfidence("HIGH"); err == nil {
+		t.Error("ParseConfidence(\"HIGH\") expected error")
+	}
+}
+
+func TestScanResult_FilterByConfidence(t *testing.T) {
+	result := &ScanResult{
+		Candidates: []*Candidate{
+			{JobID: "plain", Confidence: ConfidenceHigh},
+			{JobID: "node", Confidence: ConfidenceMedium},
+		},
+		NeedsSetup: []*Candidate{
+			{JobID: "zip", Confidence: ConfidenceLow},
+		},
+	}
+
+	if removed := result.FilterByConfidence(ConfidenceLow); removed != 0 {
+		t.Errorf("FilterByConfidence(low) removed %d, want 0", removed)
+	}
+	if removed := result.FilterByConfidence(ConfidenceMedium); removed != 1 || len(result.NeedsSetup) != 0 || len(result.Candidates) != 2 {
+		t.Errorf("FilterByConfidence(medium) removed %d, left %d candidates and %d needing setup, want 1, 2 and 0", removed, len(result.Candidates), len(result.NeedsSetup))
+	}
+	if removed := result.FilterByConfidence(ConfidenceHigh); removed != 1 || len(result.Candidates) != 1 || result.Candidates[0].JobID != "plain" {
+		t.Errorf("FilterByConfidence(high) removed %d, want only plain left", removed)
+	}
+}
@@ -0,0 +1,93 @@
+package scan
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the config file read from the repository root.
+const ConfigFileName = ".slimify.yml"
+
+// config holds organization-specific rules from a config file, which are
+// merged with the built-in ones:
+//   - SourceLabels replace DefaultSourceLabels, and are themselves replaced by
+//     source labels given to Scan (--from)
+//   - ContainerCommands, ContainerActions and MissingCommands are added to the
+//     built-in lists and to those given to Scan. Commands given to Scan as
+//     available (--has-command) are never reported as missing.
+//   - SafeDockerSubcommands exempt docker subcommands from the built-in Docker
+//     command detection.
+type config struct {
+	SourceLabels []string `yaml:"source_labels"`
+	// ContainerCommands are regular expressions matched against lower-cased run
+	// scripts. Steps matching one are treated like steps running Docker.
+	ContainerCommands []string `yaml:"container_commands"`
+	// ContainerActions are actions that need a Docker daemon, matched like
+	// --docker-action values: sub-actions match too, and a value ending in /
+	// matches every action of an owner.
+	ContainerActions []string `yaml:"container_actions"`
+	// MissingCommands are commands missing on the target runner besides the
+	// built-in list of commands missing in ubuntu-slim.
+	MissingCommands []string `yaml:"missing_commands"`
+	// SafeDockerSubcommands are docker subcommands (e.g. ps or login) that
+	// work without a Docker daemon on the target runner, so steps running
+	// them don't make a job ineligible. They are lower-cased.
+	SafeDockerSubcommands []string `yaml:"safe_docker_subcommands"`
+
+	containerPatterns []*regexp.Regexp
+}
+
+// dockerSubcommandPattern matches a single docker subcommand word.
+var dockerSubcommandPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// loadConfig reads a config file from path.
+// A missing file is not an error and results in an empty config.
+func loadConfig(path string) (*config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	cfg, err := parseConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// parseConfig parses a config file and compiles its container command
+// patterns. Unknown keys are rejected, so that a misspelled rule is not
+// silently ignored.
+func parseConfig(r io.Reader) (*config, error) {
+	var cfg config
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	for i, expr := range cfg.ContainerCommands {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("container_commands[%d]: invalid regular expression %q: %w", i, expr, err)
+		}
+		cfg.containerPatterns = append(cfg.containerPatterns, pattern)
+	}
+	for i, sub := range cfg.SafeDockerSubcommands {
+		if !dockerSubcommandPattern.MatchString(sub) {
+			return nil, fmt.Errorf("safe_docker_subcommands[%d]: invalid docker subcommand %q", i, sub)
+		}
+		cfg.SafeDockerSubcommands[i] = strings.ToLower(sub)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,245 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantPatterns int
+		wantErr      bool
+	}{
+		{
+			name: "all rules",
+			content: `source_labels: [self-hosted-small]
+container_commands:
+  - '\bpodman\s+run\b'
+  - '\bnerdctl\b'
+container_actions:
+  - my-org/container-build
+missing_commands: [terraform]
+safe_docker_subcommands: [ps, Login]
+`,
+			wantPatterns: 2,
+		},
+		{
+			name:    "empty file",
+			content: "",
+		},
+		{
+			name:    "invalid regular expression",
+			content: "container_commands: ['podman(']",
+			wantErr: true,
+		},
+		{
+			name:    "invalid docker subcommand",
+			content: "safe_docker_subcommands: ['ps -a']",
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			content: "container_command: [podman]",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseConfig(strings.NewReader(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseConfig() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseConfig() unexpected error: %v", err)
+			}
+			if len(cfg.containerPatterns) != tt.wantPatterns {
+				t.Errorf("parseConfig() compiled %d patterns, want %d", len(cfg.containerPatterns), tt.wantPatterns)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	cfg, err := loadConfig(filepath.Join(t.TempDir(), ConfigFileName))
+	if err != nil {
+		t.Fatalf("loadConfig() unexpected error: %v", err)
+	}
+	if len(cfg.SourceLabels) != 0 || len(cfg.containerPatterns) != 0 {
+		t.Errorf("loadConfig() = %+v, want an empty config", cfg)
+	}
+}
+
+func TestScan_ConfigFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowDir := filepath.Join(tmpDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	files := map[string]string{
+		filepath.Join(workflowDir, "ci.yml"): `name: ci
+on: push
+jobs:
+  podman:
+    runs-on: self-hosted-small
+    steps:
+      - run: podman run --rm alpine echo hi
+  build:
+    runs-on: self-hosted-small
+    steps:
+      - uses: my-org/container-build@v1
+  deploy:
+    runs-on: self-hosted-small
+    steps:
+      - run: terraform apply
+  lint:
+    runs-on: self-hosted-small
+    steps:
+      - run: echo lint
+  local-podman:
+    runs-on: self-hosted-small
+    steps:
+      - uses: ./.github/actions/podman
+  local-registry:
+    runs-on: self-hosted-small
+    steps:
+      - uses: ./.github/actions/registry
+  registry:
+    runs-on: self-hosted-small
+    steps:
+      - run: docker login -u me --password-stdin < token
+      - run: docker ps
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo test`,
+		".github/actions/podman/action.yml": `runs:
+  using: composite
+  steps:
+    - run: podman run --rm alpine echo hi
+      shell: bash`,
+		".github/actions/registry/action.yml": `runs:
+  using: composite
+  steps:
+    - run: docker ps
+      shell: bash`,
+		ConfigFileName: `source_labels: [self-hosted-small]
+container_commands: ['\bpodman\s+run\b']
+container_actions: [my-org/container-build]
+missing_commands: [terraform]
+safe_docker_subcommands: [login, ps]
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	jobIDs := func(result *ScanResult) (candidates, needsSetup, ineligible []string) {
+		for _, c := range result.Candidates {
+			candidates = append(candidates, c.JobID)
+		}
+		for _, c := range result.NeedsSetup {
+			needsSetup = append(needsSetup, c.JobID)
+		}
+		for _, j := range result.IneligibleJobs {
+			ineligible = append(ineligible, j.JobID)
+		}
+		slices.Sort(ineligible)
+		return candidates, needsSetup, ineligible
+	}
+
+	t.Run("config rules", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() returned error: %v", err)
+		}
+		candidates, needsSetup, ineligible := jobIDs(result)
+		// Local actions follow the same rules as the job's own steps
+		if !slices.Equal(candidates, []string{"lint", "local-registry"}) {
+			t.Errorf("Candidates = %v, want [lint local-registry]", candidates)
+		}
+		// The docker CLI is still missing for the allowlisted subcommands
+		if !slices.Equal(needsSetup, []string{"deploy", "registry"}) {
+			t.Errorf("NeedsSetup = %v, want [deploy registry]", needsSetup)
+		}
+		if !slices.Equal(ineligible, []string{"build", "local-podman", "podman", "test"}) {
+			t.Errorf("IneligibleJobs = %v, want [build local-podman podman test]", ineligible)
+		}
+	})
+
+	t.Run("source labels flag", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true, SourceLabels: []string{"ubuntu-latest"}})
+		if err != nil {
+			t.Fatalf("Scan() returned error: %v", err)
+		}
+		if candidates, _, _ := jobIDs(result); !slices.Equal(candidates, []string{"test"}) {
+			t.Errorf("Candidates = %v, want [test]", candidates)
+		}
+	})
+
+	t.Run("available commands flag", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true, AvailableCommands: []string{"terraform", "docker"}})
+		if err != nil {
+			t.Fatalf("Scan() returned error: %v", err)
+		}
+		candidates, needsSetup, _ := jobIDs(result)
+		if !slices.Contains(candidates, "deploy") {
+			t.Errorf("Candidates = %v, want deploy", candidates)
+		}
+		if len(needsSetup) != 0 {
+			t.Errorf("NeedsSetup = %v, want none", needsSetup)
+		}
+	})
+
+	t.Run("IsEligibleWith agrees with Scan", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() returned error: %v", err)
+		}
+		wantReasons := make(map[string][]string)
+		for _, j := range result.IneligibleJobs {
+			wantReasons[j.JobID] = j.Reasons
+		}
+
+		wf, err := workflow.ParseFile(filepath.Join(workflowDir, "ci.yml"))
+		if err != nil {
+			t.Fatalf("ParseFile() error: %v", err)
+		}
+		for jobID, job := range wf.Jobs {
+			eligible, reasons, err := IsEligibleWith(job, Options{})
+			if err != nil {
+				t.Fatalf("IsEligibleWith(%s) error: %v", jobID, err)
+			}
+			want, ineligible := wantReasons[jobID]
+			if eligible == ineligible || !slices.Equal(reasons, want) {
+				t.Errorf("IsEligibleWith(%s) = %v, %q, want the Scan() verdict %v, %q", jobID, eligible, reasons, !ineligible, want)
+			}
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		if err := os.WriteFile(ConfigFileName, []byte("container_commands: ['(']"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", ConfigFileName, err)
+		}
+		if _, err := Scan(Options{SkipDuration: true}); err == nil {
+			t.Errorf("Scan() expected error for an invalid %s", ConfigFileName)
+		}
+	})
+}
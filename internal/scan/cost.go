@@ -0,0 +1,59 @@
+package scan
+
+import (
+	"math"
+	"time"
+)
+
+// Pricing holds per-minute prices of GitHub-hosted runners in USD.
+type Pricing struct {
+	Standard float64 // Runners jobs are migrated from, e.g. ubuntu-latest
+	Slim     float64 // ubuntu-slim
+}
+
+// DefaultPricing is GitHub's public per-minute pricing of the standard Linux
+// 2-core runner and of ubuntu-slim.
+var DefaultPricing = Pricing{Standard: 0.006, Slim: 0.002}
+
+// Savings estimates what running candidates on ubuntu-slim saves.
+type Savings struct {
+	Jobs        int     // Candidates with a known duration, included in the estimate
+	UnknownJobs int     // Candidates with neither a known duration nor a timeout, excluded from the estimate
+	Minutes     int     // Billable minutes of one run of every included job
+	Amount      float64 // Savings in USD for one run of every included job
+	// TimeoutJobs are candidates without a known duration but with a
+	// timeout-minutes, which bounds how long they can run
+	TimeoutJobs int
+	// MaxTimeoutMinutes is the sum of the timeout-minutes of TimeoutJobs, an
+	// upper bound of their billable minutes per run
+	MaxTimeoutMinutes int
+	// MaxTimeoutAmount is the savings in USD for MaxTimeoutMinutes, an upper
+	// bound of the savings for one run of every TimeoutJobs job
+	MaxTimeoutAmount float64
+}
+
+// EstimateSavings estimates the savings of running candidates on ubuntu-slim
+// from the duration of their most recent run. As in GitHub billing, each job's
+// duration is rounded up to the whole minute. Candidates without a known
+// duration are bounded by their timeout-minutes, which is kept apart from the
+// estimate since it is only an upper bound.
+func EstimateSavings(candidates []*Candidate, pricing Pricing) Savings {
+	var savings Savings
+	for _, c := range candidates {
+		d, err := time.ParseDuration(c.Duration)
+		if c.Duration == "" || err != nil {
+			if c.TimeoutMinutes > 0 {
+				savings.TimeoutJobs++
+				savings.MaxTimeoutMinutes += c.TimeoutMinutes
+				continue
+			}
+			savings.UnknownJobs++
+			continue
+		}
+		savings.Jobs++
+		savings.Minutes += int(math.Ceil(d.Minutes()))
+	}
+	savings.Amount = float64(savings.Minutes) * (pricing.Standard - pricing.Slim)
+	savings.MaxTimeoutAmount = float64(savings.MaxTimeoutMinutes) * (pricing.Standard - pricing.Slim)
+	return savings
+}
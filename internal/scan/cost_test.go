@@ -0,0 +1,78 @@
+package scan
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateSavings(t *testing.T) {
+	pricing := Pricing{Standard: 0.008, Slim: 0.002}
+
+	tests := []struct {
+		name       string
+		durations  []string
+		timeouts   []int // timeout-minutes of the candidates, by index; 0 if not set
+		want       Savings
+		wantAmount float64
+	}{
+		{
+			name:      "no candidates",
+			durations: nil,
+			want:      Savings{},
+		},
+		{
+			name:       "durations are rounded up to whole minutes",
+			durations:  []string{"2m", "2m1s", "45s"},
+			want:       Savings{Jobs: 3, Minutes: 6},
+			wantAmount: 0.036,
+		},
+		{
+			name:      "zero duration",
+			durations: []string{"0s"},
+			want:      Savings{Jobs: 1, Minutes: 0},
+		},
+		{
+			name:       "unknown durations are excluded",
+			durations:  []string{"", "1h", "not a duration"},
+			want:       Savings{Jobs: 1, UnknownJobs: 2, Minutes: 60},
+			wantAmount: 0.36,
+		},
+		{
+			name:       "timeouts bound unknown durations",
+			durations:  []string{"", "2m", "", ""},
+			timeouts:   []int{30, 60, 0, 15},
+			want:       Savings{Jobs: 1, UnknownJobs: 1, Minutes: 2, TimeoutJobs: 2, MaxTimeoutMinutes: 45},
+			wantAmount: 0.012,
+		},
+		{
+			name:      "only unknown durations",
+			durations: []string{"", ""},
+			want:      Savings{UnknownJobs: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var candidates []*Candidate
+			for i, d := range tt.durations {
+				c := &Candidate{Duration: d}
+				if i < len(tt.timeouts) {
+					c.TimeoutMinutes = tt.timeouts[i]
+				}
+				candidates = append(candidates, c)
+			}
+
+			got := EstimateSavings(candidates, pricing)
+			if got.Jobs != tt.want.Jobs || got.UnknownJobs != tt.want.UnknownJobs || got.Minutes != tt.want.Minutes ||
+				got.TimeoutJobs != tt.want.TimeoutJobs || got.MaxTimeoutMinutes != tt.want.MaxTimeoutMinutes {
+				t.Errorf("EstimateSavings() = %+v, want %+v", got, tt.want)
+			}
+			if math.Abs(got.Amount-tt.wantAmount) > 1e-9 {
+				t.Errorf("EstimateSavings() Amount = %v, want %v", got.Amount, tt.wantAmount)
+			}
+			if wantMax := float64(tt.want.MaxTimeoutMinutes) * (pricing.Standard - pricing.Slim); math.Abs(got.MaxTimeoutAmount-wantMax) > 1e-9 {
+				t.Errorf("EstimateSavings() MaxTimeoutAmount = %v, want %v", got.MaxTimeoutAmount, wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// CandidateGroup is a set of candidates with the same signature, such as a job
+// generated from one workflow template into many workflows.
+type CandidateGroup struct {
+	Signature  string
+	Candidates []*Candidate // Sorted by workflow path and line number
+}
+
+// Workflows returns the number of workflow files the group's candidates are in.
+func (g *CandidateGroup) Workflows() int {
+	paths := make(map[string]bool)
+	for _, c := range g.Candidates {
+		paths[c.WorkflowPath] = true
+	}
+	return len(paths)
+}
+
+// jobSignature fingerprints a job by its display name and its steps, so that
+// copies of a job share a signature regardless of the file they are in or of
+// whitespace differences in their scripts.
+func jobSignature(jobName string, job *workflow.Job) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "name=%s\n", jobName)
+	for _, step := range job.Steps {
+		fmt.Fprintf(h, "step\nuses=%s\nshell=%s\nrun=%s\n", step.Uses, step.Shell, strings.Join(strings.Fields(step.Run), " "))
+		keys := make([]string, 0, len(step.With))
+		for key := range step.With {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(h, "with.%s=%v\n", key, step.With[key])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// RepeatedCandidates groups candidates by signature and returns the groups of
+// more than one candidate, largest first. A fix to the template they come
+// from migrates them all at once.
+func RepeatedCandidates(candidates []*Candidate) []*CandidateGroup {
+	bySignature := make(map[string]*CandidateGroup)
+	for _, c := range candidates {
+		if c.Signature == "" {
+			continue
+		}
+		g, ok := bySignature[c.Signature]
+		if !ok {
+			g = &CandidateGroup{Signature: c.Signature}
+			bySignature[c.Signature] = g
+		}
+		g.Candidates = append(g.Candidates, c)
+	}
+
+	var groups []*CandidateGroup
+	for _, g := range bySignature {
+		if len(g.Candidates) < 2 {
+			continue
+		}
+		sortJobs(g.Candidates, func(c *Candidate) (string, int, string) { return c.WorkflowPath, c.LineNumber, c.JobID })
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Candidates) != len(groups[j].Candidates) {
+			return len(groups[i].Candidates) > len(groups[j].Candidates)
+		}
+		return groups[i].Candidates[0].WorkflowPath < groups[j].Candidates[0].WorkflowPath
+	})
+	return groups
+}
@@ -0,0 +1,101 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepeatedCandidates(t *testing.T) {
+	dir := t.TempDir()
+	lint := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: %s
+`
+	files := map[string]string{
+		// The same job, with different whitespace in its script
+		"a.yml": fmt.Sprintf(lint, "make lint"),
+		"b.yml": fmt.Sprintf(lint, "make   lint"),
+		"c.yml": fmt.Sprintf(lint, "make lint"),
+		// A job with the same name but other steps
+		"d.yml": fmt.Sprintf(lint, "npm run lint"),
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write workflow file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	result, err := Scan(Options{SkipDuration: true, Paths: paths})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	groups := RepeatedCandidates(result.AllCandidates())
+	if len(groups) != 1 {
+		t.Fatalf("RepeatedCandidates() returned %d groups, want 1", len(groups))
+	}
+	g := groups[0]
+	if g.Workflows() != 3 {
+		t.Errorf("group appears in %d workflows, want 3", g.Workflows())
+	}
+	want := []string{"a.yml", "b.yml", "c.yml"}
+	for i, c := range g.Candidates {
+		if filepath.Base(c.WorkflowPath) != want[i] {
+			t.Errorf("Candidates[%d] is in %s, want %s", i, filepath.Base(c.WorkflowPath), want[i])
+		}
+	}
+}
+
+func TestJobSignature(t *testing.T) {
+	parse := func(content string) *Candidate {
+		t.Helper()
+		result, err := ScanReader(strings.NewReader(content), "ci.yml", Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("ScanReader() error: %v", err)
+		}
+		candidates := result.AllCandidates()
+		if len(candidates) != 1 {
+			t.Fatalf("ScanReader() returned %d candidates, want 1", len(candidates))
+		}
+		return candidates[0]
+	}
+
+	base := parse("jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.22'\n")
+	tests := []struct {
+		name    string
+		content string
+		same    bool
+	}{
+		{
+			name:    "other job ID",
+			content: "jobs:\n  unit:\n    name: test\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.22'\n",
+			same:    true,
+		},
+		{
+			name:    "other input",
+			content: "jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.23'\n",
+		},
+		{
+			name:    "other name",
+			content: "jobs:\n  check:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.22'\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(tt.content)
+			if (got.Signature == base.Signature) != tt.same {
+				t.Errorf("Signature = %s, base signature = %s, want same = %v", got.Signature, base.Signature, tt.same)
+			}
+		})
+	}
+}
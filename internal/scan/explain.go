@@ -0,0 +1,81 @@
+package scan
+
+import (
+	"fmt"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// Explanation is a detailed breakdown of how a scan classifies a single job.
+type Explanation struct {
+	WorkflowPath string
+	JobID        string
+	JobName      string
+	LineNumber   int
+	RunsOn       []string // Labels runs-on resolves to, with matrix expressions expanded
+	IgnoreRule   string   // .slimifyignore rule excluding the job, if any
+	AlreadySlim  bool
+	OtherOS      []string // Non-Linux operating systems the job runs on, if any
+	// UnresolvedVariables are the variables in runs-on with no known value, if
+	// any. Criteria are not evaluated for such jobs.
+	UnresolvedVariables []string
+	// ReusableWorkflow is the workflow called by the job, if any. Criteria are
+	// not evaluated for such jobs, since the scan evaluates the called jobs instead.
+	ReusableWorkflow string
+	Checks           []Check
+	MissingCommands  []string // Commands missing in ubuntu-slim; warnings only
+	Eligible         bool
+}
+
+// Explain evaluates a single job of the workflow file at path with the same
+// criteria as Scan, recording the outcome of every check. Unlike Scan, every
+// criterion is evaluated even when an earlier one already failed.
+// Only the options that affect classification are used, as for ScanReader.
+func Explain(path, jobID string, opts Options) (*Explanation, error) {
+	cl, err := newClassifier(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := workflow.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	job, ok := wf.Jobs[jobID]
+	if !ok {
+		return nil, fmt.Errorf("job %s not found in %s", jobID, path)
+	}
+
+	job, unresolved := cl.resolveRunsOn(job, wf)
+
+	explanation := &Explanation{
+		WorkflowPath: path,
+		JobID:        jobID,
+		JobName:      job.Name,
+		LineNumber:   job.LineStart,
+		RunsOn:       job.ResolvedRunsOnLabels(),
+	}
+	explanation.IgnoreRule, _ = cl.ignoreRules.match(path, jobID)
+
+	if job.IsReusableWorkflowCall() {
+		explanation.ReusableWorkflow = job.Uses
+		return explanation, nil
+	}
+	if len(unresolved) > 0 {
+		explanation.UnresolvedVariables = unresolved
+		return explanation, nil
+	}
+	if job.IsSlim(cl.slimLabel) {
+		explanation.AlreadySlim = true
+		return explanation, nil
+	}
+	if systems := job.OtherOperatingSystems(); len(systems) > 0 {
+		explanation.OtherOS = systems
+		return explanation, nil
+	}
+
+	explanation.Checks = evaluateCriteria(job, cl.sourceLabels, cl.dockerActions, cl.containerCommands, cl.safeDockerSubcommands)
+	explanation.MissingCommands = job.GetMissingCommandsWith(cl.sourceLabels, cl.availableCommands, cl.missingCommands)
+	explanation.Eligible, _ = checkEligibility(job, cl.sourceLabels, cl.dockerActions, cl.containerCommands, cl.safeDockerSubcommands)
+	return explanation, nil
+}
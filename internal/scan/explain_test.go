@@ -0,0 +1,179 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+  build:
+    runs-on: ubuntu-22.04
+    services:
+      redis:
+        image: redis
+    steps:
+      - run: docker build .
+  slim:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make
+  mac:
+    runs-on: macos-latest
+    steps:
+      - run: make
+`
+	path := "ci.yml"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	t.Run("eligible", func(t *testing.T) {
+		e, err := Explain(path, "lint", Options{})
+		if err != nil {
+			t.Fatalf("Explain() error: %v", err)
+		}
+		if !e.Eligible {
+			t.Errorf("Explain() Eligible = false, want true")
+		}
+		for _, check := range e.Checks {
+			if !check.Passed || check.Reason != "" {
+				t.Errorf("check %q = (%v, %q), want passed without reason", check.Name, check.Passed, check.Reason)
+			}
+		}
+		if want := []string{"zip"}; !reflect.DeepEqual(e.MissingCommands, want) {
+			t.Errorf("Explain() MissingCommands = %v, want %v", e.MissingCommands, want)
+		}
+	})
+
+	t.Run("ineligible reports every failed check", func(t *testing.T) {
+		e, err := Explain(path, "build", Options{})
+		if err != nil {
+			t.Fatalf("Explain() error: %v", err)
+		}
+		if e.Eligible {
+			t.Errorf("Explain() Eligible = true, want false")
+		}
+		if want := []string{"ubuntu-22.04"}; !reflect.DeepEqual(e.RunsOn, want) {
+			t.Errorf("Explain() RunsOn = %v, want %v", e.RunsOn, want)
+		}
+
+		var failed []string
+		for _, check := range e.Checks {
+			if !check.Passed {
+				failed = append(failed, check.Reason)
+			}
+		}
+		want := []string{
+			"runs-on is ubuntu-22.04, not ubuntu-latest",
+			"uses Docker commands in step 1",
+			"requires services: redis",
+		}
+		if !reflect.DeepEqual(failed, want) {
+			t.Errorf("Explain() failed checks = %q, want %q", failed, want)
+		}
+	})
+
+	t.Run("already slim", func(t *testing.T) {
+		e, err := Explain(path, "slim", Options{})
+		if err != nil {
+			t.Fatalf("Explain() error: %v", err)
+		}
+		if !e.AlreadySlim || len(e.Checks) != 0 {
+			t.Errorf("Explain() = %+v, want already slim without checks", e)
+		}
+	})
+
+	t.Run("other os", func(t *testing.T) {
+		e, err := Explain(path, "mac", Options{})
+		if err != nil {
+			t.Fatalf("Explain() error: %v", err)
+		}
+		if want := []string{"macOS"}; !reflect.DeepEqual(e.OtherOS, want) || len(e.Checks) != 0 {
+			t.Errorf("Explain() = %+v, want OtherOS %v without checks", e, want)
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		if _, err := Explain(path, "missing", Options{}); err == nil {
+			t.Error("Explain() expected error for unknown job")
+		}
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		if err := os.WriteFile(IgnoreFileName, []byte("ci.yml:lint\n"), 0644); err != nil {
+			t.Fatalf("Failed to write ignore file: %v", err)
+		}
+		defer os.Remove(IgnoreFileName)
+
+		e, err := Explain(filepath.Clean(path), "lint", Options{})
+		if err != nil {
+			t.Fatalf("Explain() error: %v", err)
+		}
+		if e.IgnoreRule != "ci.yml:lint" {
+			t.Errorf("Explain() IgnoreRule = %q, want %q", e.IgnoreRule, "ci.yml:lint")
+		}
+	})
+}
+
+// TestExplain_MatchesScan checks that Explain and Scan agree on eligibility.
+func TestExplain_MatchesScan(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	path := filepath.Join(workflowDir, "ci.yml")
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: docker/build-push-action@v5
+  db:
+    runs-on: ubuntu-latest
+    container: node:20
+    steps:
+      - run: npm test
+  sysctl:
+    runs-on: ubuntu-latest
+    steps:
+      - run: sudo sysctl -w vm.max_map_count=262144
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{Paths: []string{path}, SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	eligible := make(map[string]bool)
+	for _, c := range result.AllCandidates() {
+		eligible[c.JobID] = true
+	}
+
+	for _, jobID := range []string{"lint", "image", "db", "sysctl"} {
+		e, err := Explain(path, jobID, Options{})
+		if err != nil {
+			t.Fatalf("Explain(%s) error: %v", jobID, err)
+		}
+		if e.Eligible != eligible[jobID] {
+			t.Errorf("Explain(%s) Eligible = %v, Scan eligible = %v", jobID, e.Eligible, eligible[jobID])
+		}
+	}
+}
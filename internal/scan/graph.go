@@ -0,0 +1,145 @@
+package scan
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+// GraphStatus is how a job in a JobGraph was classified by the scan.
+type GraphStatus string
+
+const (
+	GraphEligible   GraphStatus = "eligible"   // A candidate, including stale and needs-setup ones
+	GraphIneligible GraphStatus = "ineligible" // Can't migrate to ubuntu-slim
+	GraphSlim       GraphStatus = "slim"       // Already on the slim label
+	GraphOther      GraphStatus = "other"      // Other OS, ignored, unresolved runs-on or a reusable workflow call
+)
+
+// JobGraph is the needs: dependency graph of the jobs of one workflow.
+type JobGraph struct {
+	WorkflowPath string
+	Jobs         []*GraphJob // Sorted by line number
+}
+
+// GraphJob is a job in a JobGraph.
+type GraphJob struct {
+	JobID      string
+	JobName    string
+	LineNumber int
+	Status     GraphStatus
+	Needs      []string // IDs of the jobs this job needs, in the order written
+	Dependents []string // IDs of the jobs that need this job, in line order
+}
+
+// IsLeaf reports whether no other job needs the job. Migrating a leaf job
+// can't change how the rest of the workflow runs, so it is the lowest risk.
+func (j *GraphJob) IsLeaf() bool {
+	return len(j.Dependents) == 0
+}
+
+// JobGraphs builds the dependency graph of each workflow with jobs in result,
+// reading the workflow files again for the jobs' needs:. Jobs reached through
+// a reusable workflow call are not part of the graph of the called workflow;
+// the calling job is in the graph of its own workflow.
+// Graphs are sorted by workflow path.
+func JobGraphs(result *ScanResult) ([]*JobGraph, error) {
+	statuses := graphStatuses(result)
+
+	var paths []string
+	for path := range statuses {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	graphs := make([]*JobGraph, 0, len(paths))
+	for _, path := range paths {
+		wf, err := workflow.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read needs of %s: %w", path, err)
+		}
+		graphs = append(graphs, buildJobGraph(wf, statuses[path]))
+	}
+	return graphs, nil
+}
+
+// BuildJobGraph builds the dependency graph of the jobs of wf, annotated with
+// how they were classified in result. Jobs not in result, such as calls to
+// local reusable workflows, are GraphOther. needs: entries naming jobs that
+// don't exist are dropped.
+func BuildJobGraph(wf *workflow.Workflow, result *ScanResult) *JobGraph {
+	return buildJobGraph(wf, graphStatuses(result)[wf.Path])
+}
+
+// buildJobGraph builds the dependency graph of wf with the job statuses of
+// the workflow, keyed by job ID.
+func buildJobGraph(wf *workflow.Workflow, statuses map[string]GraphStatus) *JobGraph {
+	graph := &JobGraph{WorkflowPath: wf.Path}
+	byID := make(map[string]*GraphJob, len(wf.Jobs))
+	for id, job := range wf.Jobs {
+		status, ok := statuses[id]
+		if !ok {
+			status = GraphOther
+		}
+		name := job.Name
+		if name == "" {
+			name = id
+		}
+		gj := &GraphJob{JobID: id, JobName: name, LineNumber: job.LineStart, Status: status}
+		graph.Jobs = append(graph.Jobs, gj)
+		byID[id] = gj
+	}
+	sortJobs(graph.Jobs, func(j *GraphJob) (string, int, string) { return "", j.LineNumber, j.JobID })
+
+	for _, gj := range graph.Jobs {
+		for _, id := range wf.Jobs[gj.JobID].Dependencies() {
+			if need, ok := byID[id]; ok {
+				gj.Needs = append(gj.Needs, id)
+				need.Dependents = append(need.Dependents, gj.JobID)
+			}
+		}
+	}
+	return graph
+}
+
+// graphStatuses maps each workflow path and job ID in result to its status.
+// Workflows calling a reusable workflow are included even if none of their
+// own jobs is in result.
+func graphStatuses(result *ScanResult) map[string]map[string]GraphStatus {
+	statuses := make(map[string]map[string]GraphStatus)
+	add := func(path, jobID string, caller *Caller, status GraphStatus) {
+		if caller != nil {
+			path, jobID = caller.WorkflowPath, ""
+		}
+		if statuses[path] == nil {
+			statuses[path] = make(map[string]GraphStatus)
+		}
+		if jobID != "" {
+			statuses[path][jobID] = status
+		}
+	}
+
+	for _, c := range result.AllCandidates() {
+		add(c.WorkflowPath, c.JobID, c.Caller, GraphEligible)
+	}
+	for _, c := range result.StaleJobs {
+		add(c.WorkflowPath, c.JobID, c.Caller, GraphEligible)
+	}
+	for _, j := range result.IneligibleJobs {
+		add(j.WorkflowPath, j.JobID, j.Caller, GraphIneligible)
+	}
+	for _, j := range result.AlreadySlimJobs {
+		add(j.WorkflowPath, j.JobID, j.Caller, GraphSlim)
+	}
+	for _, j := range result.IgnoredJobs {
+		add(j.WorkflowPath, j.JobID, j.Caller, GraphOther)
+	}
+	for _, j := range result.OtherOSJobs {
+		add(j.WorkflowPath, j.JobID, j.Caller, GraphOther)
+	}
+	for _, j := range result.UnresolvedRunsOnJobs {
+		add(j.WorkflowPath, j.JobID, j.Caller, GraphOther)
+	}
+	return statuses
+}
@@ -0,0 +1,79 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestJobGraphs_NeedsChain(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  build:
+    runs-on: ubuntu-latest
+    needs: lint
+    steps:
+      - run: docker build -t app .
+  test:
+    name: Unit tests
+    runs-on: ubuntu-slim
+    needs: [lint, build]
+    steps:
+      - run: make test
+  notify:
+    runs-on: ubuntu-latest
+    needs: [test, missing]
+    steps:
+      - run: echo done
+`
+	path := filepath.Join(workflowDir, "ci.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{Paths: []string{path}, SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	graphs, err := JobGraphs(result)
+	if err != nil {
+		t.Fatalf("JobGraphs() error: %v", err)
+	}
+	if len(graphs) != 1 || graphs[0].WorkflowPath != path {
+		t.Fatalf("JobGraphs() = %+v, want one graph for %s", graphs, path)
+	}
+
+	want := []*GraphJob{
+		{JobID: "lint", JobName: "lint", LineNumber: 4, Status: GraphEligible, Dependents: []string{"build", "test"}},
+		{JobID: "build", JobName: "build", LineNumber: 8, Status: GraphIneligible, Needs: []string{"lint"}, Dependents: []string{"test"}},
+		{JobID: "test", JobName: "Unit tests", LineNumber: 14, Status: GraphSlim, Needs: []string{"lint", "build"}, Dependents: []string{"notify"}},
+		{JobID: "notify", JobName: "notify", LineNumber: 19, Status: GraphEligible, Needs: []string{"test"}},
+	}
+	if got := graphs[0].Jobs; !reflect.DeepEqual(got, want) {
+		for i, j := range got {
+			t.Logf("job %d: %+v", i, j)
+		}
+		t.Fatalf("JobGraphs() jobs differ from %d expected jobs", len(want))
+	}
+
+	var leaves []string
+	for _, j := range graphs[0].Jobs {
+		if j.IsLeaf() {
+			leaves = append(leaves, j.JobID)
+		}
+	}
+	if !reflect.DeepEqual(leaves, []string{"notify"}) {
+		t.Errorf("leaf jobs = %v, want [notify]", leaves)
+	}
+}
@@ -0,0 +1,129 @@
+package scan
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the name of the ignore file read from the repository root.
+const IgnoreFileName = ".slimifyignore"
+
+// ignoreRule is a single line of an ignore file.
+// pathPattern is a glob matched against the workflow path, and jobPattern,
+// if non-empty, is a glob matched against the job ID.
+type ignoreRule struct {
+	raw         string
+	pathPattern string
+	jobPattern  string
+}
+
+// ignoreList is an ordered list of ignore rules.
+type ignoreList []ignoreRule
+
+// loadIgnoreFile reads ignore rules from path.
+// A missing file is not an error and results in an empty list.
+func loadIgnoreFile(path string) (ignoreList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	rules, err := parseIgnore(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// parseIgnore parses gitignore-style rules, one per line.
+// Blank lines and lines starting with # are skipped.
+// Each rule is either a workflow path glob (e.g. "ci/*.yml", "deploy.yml")
+// or a "path:job-id" pair (e.g. "ci.yml:lint") where both sides may be globs.
+func parseIgnore(r io.Reader) (ignoreList, error) {
+	var rules ignoreList
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{raw: line, pathPattern: line}
+		if idx := strings.LastIndex(line, ":"); idx >= 0 {
+			rule.pathPattern = strings.TrimSpace(line[:idx])
+			rule.jobPattern = strings.TrimSpace(line[idx+1:])
+			if rule.pathPattern == "" || rule.jobPattern == "" {
+				return nil, fmt.Errorf("line %d: expected \"path:job-id\", got %q", lineNumber, line)
+			}
+		}
+
+		// Validate patterns up front so typos are reported instead of silently never matching
+		if _, err := path.Match(rule.pathPattern, ""); err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNumber, rule.pathPattern, err)
+		}
+		if _, err := path.Match(rule.jobPattern, ""); err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNumber, rule.jobPattern, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// match returns the first rule that matches the given job, if any.
+func (l ignoreList) match(workflowPath, jobID string) (string, bool) {
+	for _, rule := range l {
+		if !matchWorkflowPath(rule.pathPattern, workflowPath) {
+			continue
+		}
+		if rule.jobPattern != "" {
+			if ok, _ := path.Match(rule.jobPattern, jobID); !ok {
+				continue
+			}
+		}
+		return rule.raw, true
+	}
+	return "", false
+}
+
+// matchWorkflowPath matches a gitignore-style pattern against a workflow path.
+// Like gitignore, a pattern without a slash matches the file name at any depth.
+// A pattern with a slash is matched against the path relative to the repository
+// root, and also relative to .github/workflows for convenience.
+// A leading slash anchors the pattern to the repository root.
+func matchWorkflowPath(pattern, workflowPath string) bool {
+	p := filepath.ToSlash(filepath.Clean(workflowPath))
+
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(p))
+		return ok
+	}
+
+	if strings.HasPrefix(pattern, "/") {
+		ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), p)
+		return ok
+	}
+
+	if ok, _ := path.Match(pattern, p); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, strings.TrimPrefix(p, ".github/workflows/"))
+	return ok
+}
@@ -0,0 +1,211 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseIgnore(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantRules int
+		wantErr   bool
+	}{
+		{
+			name: "comments and blank lines are skipped",
+			content: `# workflows slated for deletion
+
+legacy.yml
+ci.yml:lint
+`,
+			wantRules: 2,
+		},
+		{
+			name:    "missing job ID",
+			content: "ci.yml:",
+			wantErr: true,
+		},
+		{
+			name:    "missing path",
+			content: ":lint",
+			wantErr: true,
+		},
+		{
+			name:    "invalid glob",
+			content: "ci[.yml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := parseIgnore(strings.NewReader(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseIgnore() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseIgnore() unexpected error: %v", err)
+			}
+			if len(rules) != tt.wantRules {
+				t.Errorf("parseIgnore() returned %d rules, want %d", len(rules), tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestIgnoreList_Match(t *testing.T) {
+	rules, err := parseIgnore(strings.NewReader(`legacy.yml
+deploy-*.yml
+ci/*.yml
+/tools/workflows/*.yaml
+test.yml:integration
+build.yml:release-*
+`))
+	if err != nil {
+		t.Fatalf("parseIgnore() error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		workflowPath string
+		jobID        string
+		wantMatch    bool
+		wantRule     string
+	}{
+		{
+			name:         "file name without slash matches at any depth",
+			workflowPath: ".github/workflows/legacy.yml",
+			jobID:        "build",
+			wantMatch:    true,
+			wantRule:     "legacy.yml",
+		},
+		{
+			name:         "file name glob",
+			workflowPath: ".github/workflows/deploy-prod.yml",
+			jobID:        "deploy",
+			wantMatch:    true,
+			wantRule:     "deploy-*.yml",
+		},
+		{
+			name:         "path glob relative to workflows directory",
+			workflowPath: ".github/workflows/ci/lint.yml",
+			jobID:        "lint",
+			wantMatch:    true,
+			wantRule:     "ci/*.yml",
+		},
+		{
+			name:         "path glob relative to repository root",
+			workflowPath: "ci/lint.yml",
+			jobID:        "lint",
+			wantMatch:    true,
+			wantRule:     "ci/*.yml",
+		},
+		{
+			name:         "anchored pattern",
+			workflowPath: "./tools/workflows/gen.yaml",
+			jobID:        "gen",
+			wantMatch:    true,
+			wantRule:     "/tools/workflows/*.yaml",
+		},
+		{
+			name:         "path and job pair",
+			workflowPath: ".github/workflows/test.yml",
+			jobID:        "integration",
+			wantMatch:    true,
+			wantRule:     "test.yml:integration",
+		},
+		{
+			name:         "path and job pair - other job",
+			workflowPath: ".github/workflows/test.yml",
+			jobID:        "unit",
+			wantMatch:    false,
+		},
+		{
+			name:         "job glob",
+			workflowPath: ".github/workflows/build.yml",
+			jobID:        "release-linux",
+			wantMatch:    true,
+			wantRule:     "build.yml:release-*",
+		},
+		{
+			name:         "unrelated workflow",
+			workflowPath: ".github/workflows/ci.yml",
+			jobID:        "build",
+			wantMatch:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRule, gotMatch := rules.match(tt.workflowPath, tt.jobID)
+			if gotMatch != tt.wantMatch || gotRule != tt.wantRule {
+				t.Errorf("match(%q, %q) = (%q, %v), want (%q, %v)", tt.workflowPath, tt.jobID, gotRule, gotMatch, tt.wantRule, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestScan_IgnoreFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowDir := filepath.Join(tmpDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	files := map[string]string{
+		filepath.Join(workflowDir, "ci.yml"): `name: ci
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo lint
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo test`,
+		filepath.Join(workflowDir, "legacy.yml"): `name: legacy
+on: push
+jobs:
+  old:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo old`,
+		IgnoreFileName: "legacy.yml\nci.yml:test\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() returned error: %v", err)
+	}
+
+	if len(result.Candidates) != 1 || result.Candidates[0].JobID != "lint" {
+		t.Errorf("Expected only the lint candidate, got %d candidates", len(result.Candidates))
+	}
+
+	ignored := make(map[string]string)
+	for _, job := range result.IgnoredJobs {
+		ignored[job.JobID] = job.Rule
+	}
+	want := map[string]string{"old": "legacy.yml", "test": "ci.yml:test"}
+	if len(ignored) != len(want) {
+		t.Errorf("Expected %d ignored jobs, got %d: %v", len(want), len(ignored), ignored)
+	}
+	for jobID, rule := range want {
+		if ignored[jobID] != rule {
+			t.Errorf("Ignored job %s rule = %q, want %q", jobID, ignored[jobID], rule)
+		}
+	}
+}
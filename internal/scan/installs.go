@@ -0,0 +1,24 @@
+package scan
+
+import "github.com/fchimpan/gh-slimify/internal/workflow"
+
+// ManyInstallsThreshold is the number of packages to install from which a job
+// is warned that migrating may increase its runtime. ubuntu-latest comes with
+// them preinstalled, while ubuntu-slim has to install them on every run.
+const ManyInstallsThreshold = 5
+
+// InstallCount returns how many installs the candidate needs on ubuntu-slim for
+// its missing commands: one per apt package, however many of the commands it
+// provides, and one per command that is not installed with apt.
+func (c *Candidate) InstallCount() int {
+	packages, unavailable := workflow.AptPackagesFor(c.MissingCommands)
+	return len(packages) + len(unavailable)
+}
+
+// MayIncreaseRuntime reports whether the candidate needs at least
+// ManyInstallsThreshold installs on ubuntu-slim, so that installing them on
+// every run may outweigh the faster start of the slim runner. It is advisory
+// and doesn't affect eligibility.
+func (c *Candidate) MayIncreaseRuntime() bool {
+	return c.InstallCount() >= ManyInstallsThreshold
+}
@@ -0,0 +1,96 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCandidate_InstallCount(t *testing.T) {
+	tests := []struct {
+		name            string
+		missingCommands []string
+		want            int
+		wantMany        bool
+	}{
+		{
+			name: "no missing commands",
+		},
+		{
+			name:            "commands of the same package are one install",
+			missingCommands: []string{"7z", "7za"},
+			want:            1,
+		},
+		{
+			name:            "commands not installed with apt count",
+			missingCommands: []string{"terraform", "aws", "jq"},
+			want:            3,
+		},
+		{
+			name:            "many packages",
+			missingCommands: []string{"7z", "7za", "Xvfb", "add-apt-repository", "ab", "aclocal"},
+			want:            5,
+			wantMany:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Candidate{MissingCommands: tt.missingCommands}
+			if got := c.InstallCount(); got != tt.want {
+				t.Errorf("InstallCount() = %d, want %d", got, tt.want)
+			}
+			if got := c.MayIncreaseRuntime(); got != tt.wantMany {
+				t.Errorf("MayIncreaseRuntime() = %v, want %v", got, tt.wantMany)
+			}
+		})
+	}
+}
+
+func TestScan_ManyInstalls(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  toolchain:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          7z x sdk.7z
+          Xvfb :99 &
+          add-apt-repository ppa:example/ppa
+          aclocal
+          ab -n 100 http://localhost/
+  archive:
+    runs-on: ubuntu-latest
+    steps:
+      - run: 7z a dist.7z dist
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, c := range result.AllCandidates() {
+		got[c.JobID] = c.MayIncreaseRuntime()
+	}
+	want := map[string]bool{"toolchain": true, "archive": false}
+	for jobID, wantMany := range want {
+		many, ok := got[jobID]
+		if !ok {
+			t.Errorf("Scan() has no candidate %s, got %v", jobID, got)
+			continue
+		}
+		if many != wantMany {
+			t.Errorf("candidate %s MayIncreaseRuntime() = %v, want %v", jobID, many, wantMany)
+		}
+	}
+}
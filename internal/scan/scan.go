@@ -2,207 +2,1315 @@ package scan
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fchimpan/gh-slimify/internal/api"
 	"github.com/fchimpan/gh-slimify/internal/workflow"
 )
 
+// Caller identifies the job that runs a job through a reusable workflow call.
+type Caller struct {
+	WorkflowPath string // Top-level workflow whose runs include the called job
+	JobID        string // ID of the job in WorkflowPath that calls the reusable workflow
+}
+
+// String returns the caller as "path:job-id".
+func (c *Caller) String() string {
+	return c.WorkflowPath + ":" + c.JobID
+}
+
 // Candidate represents a job that is eligible for migration
 type Candidate struct {
 	WorkflowPath    string
-	JobID           string // Job ID (the key in the jobs map)
-	JobName         string // Job display name (name: field in YAML, or job ID if not specified)
+	WorkflowName    string   // Workflow name from the name: key, empty if not set
+	Triggers        []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID           string   // Job ID (the key in the jobs map)
+	JobName         string   // Job display name (name: field in YAML, or job ID if not specified)
 	LineNumber      int
-	Duration        string   // Will be populated from GitHub API later
-	MissingCommands []string // Commands that exist in ubuntu-latest but need to be installed in ubuntu-slim
+	SourceLabel     string    // The runs-on label that will be replaced (e.g. ubuntu-latest)
+	RunsOnMatrix    bool      // runs-on is a matrix expression (e.g. ${{ matrix.os }}), so the matrix values are replaced
+	Duration        string    // Will be populated from GitHub API later
+	TimeoutMinutes  int       // The job's timeout-minutes, an upper bound of its duration; 0 if not set or an expression
+	LastRun         time.Time // When the job last completed successfully, from the GitHub API; zero if unknown
+	MissingCommands []string  // Commands that exist in ubuntu-latest but need to be installed in ubuntu-slim
+	Condition       string    // Job-level if: expression that can't be evaluated statically, if any
+	SetupActions    []string  // actions/setup-* actions whose tools should be verified on ubuntu-slim
+	OutdatedActions []string  // Actions pinned to a version older than expected to work on ubuntu-slim, as "action@ref"
+	Caller          *Caller   // Set if the job is reached through a reusable workflow call
+	// Confidence is how likely the job is to work on ubuntu-slim unchanged
+	Confidence Confidence
+	// NoSteps is set when the job has no steps (steps: [] or no steps key), so
+	// it is trivially eligible rather than checked against any step
+	NoSteps bool
+	// NonPOSIXShellSteps are the 1-based indexes of run steps using a shell
+	// other than bash or sh (e.g. pwsh or python), whose scripts were not
+	// fully checked
+	NonPOSIXShellSteps []int
+	// RunsOnVariables are the variables in runs-on (e.g. vars.LINUX_RUNNER)
+	// whose values were given with resolveVars, so the label to replace is
+	// set in the variable rather than in the workflow file
+	RunsOnVariables []string
+	// LinuxSkippedDockerSteps are the 1-based indexes of steps using Docker
+	// whose if: condition is false on Linux (e.g. runner.os == 'Windows'), so
+	// they don't block migration
+	LinuxSkippedDockerSteps []int
+	// DockerLayerCacheSteps are the 1-based indexes of actions/cache steps
+	// whose path holds Docker image layers, hinting that the job builds images
+	DockerLayerCacheSteps []int
+	// Signature fingerprints the job's name and steps, shared by copies of
+	// the job in other workflows (see RepeatedCandidates)
+	Signature string
 }
 
 // IneligibleJob represents a job that is not eligible for migration
 type IneligibleJob struct {
 	WorkflowPath string
-	JobID        string // Job ID (the key in the jobs map)
-	JobName      string // Job display name (name: field in YAML, or job ID if not specified)
+	WorkflowName string   // Workflow name from the name: key, empty if not set
+	Triggers     []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID        string   // Job ID (the key in the jobs map)
+	JobName      string   // Job display name (name: field in YAML, or job ID if not specified)
 	LineNumber   int
 	Reasons      []string // Reasons why the job cannot be migrated
+	// SourceLabel is the runs-on label that is a migration source, or a matrix
+	// value that is one; empty if the job doesn't run on a migration source
+	SourceLabel string
+	// Justification is the reason of a "# slimify-ignore: <reason>" comment in
+	// the job block, if any
+	Justification string
+	// MissingCommands lists commands missing in ubuntu-slim that would also need
+	// setup if the blocking reasons were resolved
+	MissingCommands []string
+	// PartiallyEligible is set when runs-on is a matrix expression and only
+	// some of the matrix values are migration sources
+	PartiallyEligible bool
+	Services          []string // Names of the service containers the job declares, if any
+	Caller            *Caller  // Set if the job is reached through a reusable workflow call
+	// PinnedLabel is the pinned Ubuntu version label (e.g. ubuntu-22.04) the
+	// job runs on when runs-on is the only criterion it fails, so that it can
+	// migrate once the label is a migration source
+	PinnedLabel string
 }
 
 // AlreadySlimJob represents a job that is already using ubuntu-slim
 type AlreadySlimJob struct {
 	WorkflowPath string
-	JobID        string // Job ID (the key in the jobs map)
-	JobName      string // Job display name (name: field in YAML, or job ID if not specified)
+	WorkflowName string   // Workflow name from the name: key, empty if not set
+	Triggers     []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID        string   // Job ID (the key in the jobs map)
+	JobName      string   // Job display name (name: field in YAML, or job ID if not specified)
+	LineNumber   int
+	Caller       *Caller // Set if the job is reached through a reusable workflow call
+	// Reasons lists the features the job uses that ubuntu-slim doesn't support,
+	// e.g. Docker commands added after it was migrated (see SlimRegressions)
+	Reasons []string
+}
+
+// IgnoredJob represents a job excluded from migration by a .slimifyignore rule
+type IgnoredJob struct {
+	WorkflowPath string
+	WorkflowName string   // Workflow name from the name: key, empty if not set
+	Triggers     []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID        string   // Job ID (the key in the jobs map)
+	JobName      string   // Job display name (name: field in YAML, or job ID if not specified)
+	LineNumber   int
+	Rule         string  // The ignore rule that matched
+	Caller       *Caller // Set if the job is reached through a reusable workflow call
+}
+
+// OtherOSJob represents a job running on a non-Linux operating system, such as
+// windows-latest or macos-latest, to which ubuntu-slim is not applicable
+type OtherOSJob struct {
+	WorkflowPath string
+	WorkflowName string   // Workflow name from the name: key, empty if not set
+	Triggers     []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID        string   // Job ID (the key in the jobs map)
+	JobName      string   // Job display name (name: field in YAML, or job ID if not specified)
 	LineNumber   int
+	OS           []string // Operating systems the job runs on (e.g. Windows, macOS)
+	Caller       *Caller  // Set if the job is reached through a reusable workflow call
 }
 
+// UnresolvedRunsOnJob represents a job whose runs-on takes its label from a
+// variable, such as ${{ vars.LINUX_RUNNER }}, whose value is not known, so
+// whether it can run on ubuntu-slim cannot be determined
+type UnresolvedRunsOnJob struct {
+	WorkflowPath string
+	WorkflowName string   // Workflow name from the name: key, empty if not set
+	Triggers     []string // Events that trigger the workflow (e.g. push, pull_request)
+	JobID        string   // Job ID (the key in the jobs map)
+	JobName      string   // Job display name (name: field in YAML, or job ID if not specified)
+	LineNumber   int
+	Variables    []string // Unresolved variables in runs-on (e.g. vars.LINUX_RUNNER)
+	Caller       *Caller  // Set if the job is reached through a reusable workflow call
+}
+
+// DefaultSourceLabels are the runs-on labels considered for migration when none are specified.
+var DefaultSourceLabels = []string{"ubuntu-latest"}
+
 // ScanResult contains both eligible candidates and ineligible jobs
 type ScanResult struct {
-	Candidates      []*Candidate
+	Candidates      []*Candidate // Eligible jobs that need no extra setup
+	NeedsSetup      []*Candidate // Eligible jobs that use commands missing in ubuntu-slim
 	IneligibleJobs  []*IneligibleJob
 	AlreadySlimJobs []*AlreadySlimJob
+	IgnoredJobs     []*IgnoredJob
+	OtherOSJobs     []*OtherOSJob // Jobs on Windows or macOS, not applicable to ubuntu-slim
+	// UnresolvedRunsOnJobs are jobs whose runs-on uses a variable with no known
+	// value, so they cannot be classified
+	UnresolvedRunsOnJobs []*UnresolvedRunsOnJob
+	// StaleJobs are candidates moved out of Candidates and NeedsSetup by
+	// SeparateStale because they have not run recently
+	StaleJobs []*Candidate
+	// ParseErrors holds the workflow files that could not be read or parsed,
+	// which are skipped so that the other files are still scanned
+	ParseErrors []FileError
+	// Files is the number of workflow files scanned, including those in
+	// ParseErrors
+	Files int
 }
 
-// Scan scans workflows and returns migration candidates and ineligible jobs
-// If paths are provided, only those files are scanned. Otherwise, all workflow files
-// in .github/workflows are scanned.
-// skipDuration, if true, skips fetching job execution durations from GitHub API.
-// verbose, if true, enables verbose output including debug warnings.
-func Scan(skipDuration bool, verbose bool, paths ...string) (*ScanResult, error) {
+// FileError is an error loading a single workflow file.
+type FileError struct {
+	Path string
+	Err  error // Names the file, e.g. a *workflow.ParseError
+}
+
+func (e FileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e FileError) Unwrap() error {
+	return e.Err
+}
+
+// Line returns the line of the syntax error in the file, or 0 if unknown.
+func (e FileError) Line() int {
+	var parseErr *workflow.ParseError
+	if errors.As(e.Err, &parseErr) {
+		return parseErr.Line
+	}
+	return 0
+}
+
+// AllCandidates returns every job that can be migrated, both Candidates and
+// NeedsSetup, sorted by workflow path and line number.
+func (r *ScanResult) AllCandidates() []*Candidate {
+	all := make([]*Candidate, 0, len(r.Candidates)+len(r.NeedsSetup))
+	all = append(all, r.Candidates...)
+	all = append(all, r.NeedsSetup...)
+	sortJobs(all, func(c *Candidate) (string, int, string) { return c.WorkflowPath, c.LineNumber, c.JobID })
+	return all
+}
+
+// UsingSetupActions returns the candidates, from both Candidates and NeedsSetup,
+// that use actions/setup-* actions. This is informational: the tools those actions
+// install should be verified on ubuntu-slim, but they don't block migration.
+func (r *ScanResult) UsingSetupActions() []*Candidate {
+	var jobs []*Candidate
+	for _, c := range r.AllCandidates() {
+		if len(c.SetupActions) > 0 {
+			jobs = append(jobs, c)
+		}
+	}
+	return jobs
+}
+
+// UnjustifiedJobs returns the ineligible jobs that run on a migration source
+// label but have no "# slimify-ignore: <reason>" comment explaining why they
+// can't move to ubuntu-slim.
+func (r *ScanResult) UnjustifiedJobs() []*IneligibleJob {
+	var jobs []*IneligibleJob
+	for _, j := range r.IneligibleJobs {
+		if j.SourceLabel != "" && j.Justification == "" {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs
+}
+
+// SlimRegressions returns the jobs already on ubuntu-slim that use features it
+// doesn't support, such as Docker commands or services, so they will likely fail.
+func (r *ScanResult) SlimRegressions() []*AlreadySlimJob {
+	var jobs []*AlreadySlimJob
+	for _, j := range r.AlreadySlimJobs {
+		if len(j.Reasons) > 0 {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs
+}
+
+// Options configures Scan. The zero value scans every workflow in
+// .github/workflows with the defaults, fetching job durations.
+type Options struct {
+	// Paths are the workflow files to scan, which may be glob patterns (e.g.
+	// .github/workflows/deploy*.yml) that must each match at least one file.
+	// If empty, all workflow files in .github/workflows and Dirs are scanned
+	// recursively.
+	Paths []string
+	// Dirs lists additional workflow roots to scan besides .github/workflows
+	// when Paths is empty. Each must exist.
+	Dirs []string
+	// SkipDuration skips fetching job execution durations from the GitHub API.
+	SkipDuration bool
+	// Verbose enables debug warnings while fetching durations.
+	Verbose bool
+	// Hostname is the GitHub host durations are fetched from (e.g. a GitHub
+	// Enterprise Server). If empty, the host of the repository is used.
+	Hostname string
+	// APIRetries is the number of times a GitHub API request rejected by a
+	// rate limit is retried. If 0, requests are not retried.
+	APIRetries int
+	// SourceLabels lists the runs-on labels that are migration sources (e.g.
+	// ubuntu-24.04). If empty, the source_labels of .slimify.yml or
+	// DefaultSourceLabels are used.
+	SourceLabels []string
+	// SlimLabel is the runs-on label of the slim runners jobs are migrated to.
+	// Jobs already on it are reported as AlreadySlimJobs. If empty,
+	// workflow.DefaultSlimLabel is used.
+	SlimLabel string
+	// Concurrency is the maximum number of workflow files parsed in parallel.
+	// If less than 1, runtime.NumCPU() is used.
+	Concurrency int
+	// AvailableCommands lists commands installed on the target runner that are
+	// not in the built-in ubuntu-slim list, so they are not reported as missing.
+	AvailableCommands []string
+	// DockerActions lists actions that need a Docker daemon, in addition to
+	// workflow.DefaultDockerDependentActions. Jobs using them are not eligible.
+	DockerActions []string
+	// MinActionVersions maps actions to the oldest major version expected to
+	// work on ubuntu-slim, overriding workflow.DefaultMinActionVersions.
+	MinActionVersions map[string]int
+	// ResolveVars maps variable names to values substituted for
+	// ${{ vars.NAME }} and ${{ env.NAME }} in runs-on, unless the workflow's
+	// env: sets NAME. Jobs using other variables in runs-on are reported as
+	// UnresolvedRunsOnJobs.
+	ResolveVars map[string]string
+	// Cache, if non-nil, is used to reuse the results of unchanged workflow
+	// files and to store the results of the others.
+	Cache *Cache
+	// Progress, if non-nil, is called before each job duration is fetched from
+	// the GitHub API with the 1-based number of the job and the number of jobs
+	// to fetch.
+	Progress func(done, total int)
+	// Timing, if non-nil, is called with how long each phase of the scan took
+	// once it completes.
+	Timing func(Timings)
+}
+
+// Scan scans workflows and returns migration candidates and ineligible jobs.
+// Files that can't be read or parsed are skipped and listed in the result's
+// ParseErrors, so that one malformed file doesn't hide the results of the others.
+// How each job is classified is logged with log/slog at debug level.
+// Jobs matching a rule in .slimifyignore (in the current directory) are reported
+// as IgnoredJobs instead of being categorized.
+// Jobs calling a reusable workflow in the same repository (uses: ./...) are replaced
+// by the jobs of the called workflow, with Caller set. A scanned workflow that only
+// runs on workflow_call is not reported on its own if a scanned workflow calls it.
+// Calls to remote reusable workflows are reported as ineligible.
+// Each result list is sorted by workflow path and line number.
+func Scan(opts Options) (*ScanResult, error) {
+	start := time.Now()
+	var timings Timings
+
+	cl, err := newClassifier(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var workflows []*workflow.Workflow
-	var err error
+	var lookup *cacheLookup
+	var parseErrors []FileError
+
+	// load parses files, after timing how long finding them took
+	load := func(files []string) {
+		timings.Files = len(files)
+		if opts.Cache != nil {
+			lookup, files = opts.Cache.lookupFiles(files, newCacheOptions(cl))
+			timings.CachedFiles = len(lookup.entries)
+		}
+		timings.Discovery = time.Since(start)
+
+		parseStart := time.Now()
+		workflows, parseErrors = loadWorkflows(files, opts.Concurrency)
+		timings.Parsing = time.Since(parseStart)
+	}
 
-	if len(paths) > 0 {
+	if len(opts.Paths) > 0 {
 		// Load only specified files
-		workflows = make([]*workflow.Workflow, 0, len(paths))
-		for _, path := range paths {
-			wf, err := workflow.LoadWorkflow(path)
-			if err != nil {
-				return nil, fmt.Errorf("failed to load workflow %s: %w", path, err)
-			}
-			workflows = append(workflows, wf)
+		files, err := expandPaths(opts.Paths)
+		if err != nil {
+			return nil, err
 		}
+		load(files)
 	} else {
-		// Load all workflows
-		workflows, err = workflow.LoadWorkflows()
+		// Load all workflows from the default and additional workflow roots
+		for _, dir := range opts.Dirs {
+			if _, err := os.Stat(dir); err != nil {
+				return nil, fmt.Errorf("workflow directory not found: %s", dir)
+			}
+		}
+		workflowDirs := append([]string{workflow.DefaultWorkflowDir}, opts.Dirs...)
+
+		files, err := workflow.FindWorkflowFiles(workflowDirs)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load workflows: %w", err)
 		}
+		load(files)
 
-		if len(workflows) == 0 {
-			fmt.Fprintf(os.Stderr, "No workflow files found in .github/workflows\n")
-			return &ScanResult{
-				Candidates:      []*Candidate{},
-				IneligibleJobs:  []*IneligibleJob{},
-				AlreadySlimJobs: []*AlreadySlimJob{},
-			}, nil
+		if len(workflows) == 0 && len(parseErrors) == 0 && (lookup == nil || len(lookup.entries) == 0) {
+			fmt.Fprintf(os.Stderr, "No workflow files found in %s\n", strings.Join(workflowDirs, ", "))
+			result := &ScanResult{
+				Candidates:           []*Candidate{},
+				NeedsSetup:           []*Candidate{},
+				IneligibleJobs:       []*IneligibleJob{},
+				AlreadySlimJobs:      []*AlreadySlimJob{},
+				IgnoredJobs:          []*IgnoredJob{},
+				OtherOSJobs:          []*OtherOSJob{},
+				UnresolvedRunsOnJobs: []*UnresolvedRunsOnJob{},
+			}
+			reportTimings(opts, result, timings, start)
+			return result, nil
 		}
 	}
 
-	var candidates []*Candidate
-	var ineligibleJobs []*IneligibleJob
-	var alreadySlimJobs []*AlreadySlimJob
+	result, err := scanWorkflows(cl, workflows, lookup, opts, &timings)
+	if err != nil {
+		return nil, err
+	}
+	result.ParseErrors = parseErrors
+	result.Files = timings.Files
+	reportTimings(opts, result, timings, start)
+	return result, nil
+}
+
+// ScanReader scans a single workflow read from r, for callers such as editor
+// integrations that have the content but not a file. path labels the workflow
+// in the result and resolves calls to local reusable workflows, which are read
+// from the current directory like .slimifyignore. Durations are not fetched.
+// The options that select files and fetch durations (Paths, Dirs,
+// Concurrency, SkipDuration, Verbose, Cache and Progress) are ignored.
+func ScanReader(r io.Reader, path string, opts Options) (*ScanResult, error) {
+	start := time.Now()
+	cl, err := newClassifier(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	timings := Timings{Files: 1}
+	parseStart := time.Now()
+	wf, err := workflow.Parse(r, path)
+	if err != nil {
+		return nil, err
+	}
+	timings.Parsing = time.Since(parseStart)
+	result, err := scanWorkflows(cl, []*workflow.Workflow{wf}, nil, Options{SkipDuration: true}, &timings)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = 1
+	reportTimings(opts, result, timings, start)
+	return result, nil
+}
 
+// newClassifier returns a classifier for the classification options of opts,
+// with defaults applied and the rules of .slimify.yml and .slimifyignore loaded.
+func newClassifier(opts Options) (*classifier, error) {
+	cfg, err := loadConfig(ConfigFileName)
+	if err != nil {
+		return nil, err
+	}
+	sourceLabels, slimLabel := opts.SourceLabels, opts.SlimLabel
+	if len(sourceLabels) == 0 {
+		sourceLabels = cfg.SourceLabels
+	}
+	if len(sourceLabels) == 0 {
+		sourceLabels = DefaultSourceLabels
+	}
+	if slimLabel == "" {
+		slimLabel = workflow.DefaultSlimLabel
+	}
+	ignoreRules, err := loadIgnoreFile(IgnoreFileName)
+	if err != nil {
+		return nil, err
+	}
+	return &classifier{
+		sourceLabels:          sourceLabels,
+		slimLabel:             slimLabel,
+		availableCommands:     opts.AvailableCommands,
+		missingCommands:       cfg.MissingCommands,
+		dockerActions:         withDefaultDockerActions(slices.Concat(cfg.ContainerActions, opts.DockerActions)),
+		containerCommands:     cfg.containerPatterns,
+		safeDockerSubcommands: cfg.SafeDockerSubcommands,
+		minActionVersions:     withDefaultMinActionVersions(opts.MinActionVersions),
+		resolveVars:           opts.ResolveVars,
+		ignoreRules:           ignoreRules,
+		visiting:              make(map[string]bool),
+	}, nil
+}
+
+// scanWorkflows classifies the jobs of loaded workflows with cl and fetches
+// durations for the candidates unless skipDuration is set. lookup, if non-nil,
+// holds the cached entries of unchanged workflows, which are reported as is,
+// and the keys to cache the classification of the loaded workflows under.
+// Durations are fetched as set by the duration options of opts. timings, if
+// non-nil, records how long classifying jobs and fetching durations took.
+func scanWorkflows(cl *classifier, workflows []*workflow.Workflow, lookup *cacheLookup, opts Options, timings *Timings) (*ScanResult, error) {
+	if timings == nil {
+		timings = &Timings{}
+	}
+	classifyStart := time.Now()
+
+	// Reusable workflows called by other scanned workflows are reported through
+	// each caller, qualified by it, rather than on their own
+	called := calledWorkflows(workflows)
 	for _, wf := range workflows {
-		for jobID, job := range wf.Jobs {
-			// Check if job is already using ubuntu-slim
-			if job.IsUbuntuSlim() {
-				alreadySlimJobs = append(alreadySlimJobs, &AlreadySlimJob{
-					WorkflowPath: wf.Path,
-					JobID:        jobID,
-					JobName:      job.Name,
-					LineNumber:   job.LineStart,
-				})
-				continue
+		if called[filepath.Clean(wf.Path)] && onlyCalled(wf) {
+			continue
+		}
+		key, ok := "", false
+		if lookup != nil {
+			key, ok = lookup.keys[wf.Path]
+		}
+		if !ok || !cacheable(wf) {
+			for jobID, job := range wf.Jobs {
+				cl.classify(wf, jobID, job, nil, "")
 			}
+			continue
+		}
 
-			// Check migration criteria
-			isEligible, reasons := checkEligibility(job)
-			if isEligible {
-				// Check for missing commands and include in candidate
-				missingCommands := job.GetMissingCommands()
-				candidates = append(candidates, &Candidate{
-					WorkflowPath:    wf.Path,
-					JobID:           jobID,
-					JobName:         job.Name,
-					LineNumber:      job.LineStart,
-					MissingCommands: missingCommands,
-				})
-			} else {
-				// Record ineligible job with reasons
-				ineligibleJobs = append(ineligibleJobs, &IneligibleJob{
-					WorkflowPath: wf.Path,
-					JobID:        jobID,
-					JobName:      job.Name,
-					LineNumber:   job.LineStart,
-					Reasons:      reasons,
-				})
-			}
+		// Classify the workflow on its own to cache its jobs
+		wfClassifier := cl.fork()
+		for jobID, job := range wf.Jobs {
+			wfClassifier.classify(wf, jobID, job, nil, "")
+		}
+		entry := wfClassifier.entry()
+		if err := lookup.cache.store(key, entry); err != nil && opts.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache scan result of %s: %v\n", wf.Path, err)
 		}
+		cl.add(entry)
 	}
+	if lookup != nil {
+		for _, entry := range lookup.entries {
+			cl.add(entry)
+		}
+	}
+	candidates := cl.candidates
+	ineligibleJobs := cl.ineligibleJobs
+	alreadySlimJobs := cl.alreadySlimJobs
+	ignoredJobs := cl.ignoredJobs
+	otherOSJobs := cl.otherOSJobs
+	unresolvedJobs := cl.unresolvedJobs
+
+	// Jobs are stored in a map, so sort to keep output stable across runs
+	sortJobs(candidates, func(c *Candidate) (string, int, string) { return c.WorkflowPath, c.LineNumber, c.JobID })
+	sortJobs(ineligibleJobs, func(j *IneligibleJob) (string, int, string) { return j.WorkflowPath, j.LineNumber, j.JobID })
+	sortJobs(alreadySlimJobs, func(j *AlreadySlimJob) (string, int, string) { return j.WorkflowPath, j.LineNumber, j.JobID })
+	sortJobs(ignoredJobs, func(j *IgnoredJob) (string, int, string) { return j.WorkflowPath, j.LineNumber, j.JobID })
+	sortJobs(otherOSJobs, func(j *OtherOSJob) (string, int, string) { return j.WorkflowPath, j.LineNumber, j.JobID })
+	sortJobs(unresolvedJobs, func(j *UnresolvedRunsOnJob) (string, int, string) { return j.WorkflowPath, j.LineNumber, j.JobID })
+
+	timings.Classification = time.Since(classifyStart)
 
 	// Fetch duration from GitHub API for each candidate (unless skipped)
-	if !skipDuration {
-		if err := fetchDurations(candidates, verbose); err != nil {
+	if !opts.SkipDuration {
+		fetchStart := time.Now()
+		if err := fetchDurations(candidates, opts.Verbose, opts.Hostname, opts.APIRetries, opts.Progress); err != nil {
 			// Log error but don't fail the scan
-			if verbose {
+			if opts.Verbose {
 				fmt.Fprintf(os.Stderr, "Warning: failed to fetch job durations from GitHub API: %v\n", err)
 			}
 		}
+		timings.Durations = time.Since(fetchStart)
+		timings.DurationJobs = len(candidates)
+	}
+
+	// Jobs using commands missing in ubuntu-slim need a setup step before migrating
+	var cleanCandidates, needsSetup []*Candidate
+	for _, c := range candidates {
+		if len(c.MissingCommands) > 0 {
+			needsSetup = append(needsSetup, c)
+		} else {
+			cleanCandidates = append(cleanCandidates, c)
+		}
 	}
 
 	return &ScanResult{
-		Candidates:      candidates,
-		IneligibleJobs:  ineligibleJobs,
-		AlreadySlimJobs: alreadySlimJobs,
+		Candidates:           cleanCandidates,
+		NeedsSetup:           needsSetup,
+		IneligibleJobs:       ineligibleJobs,
+		AlreadySlimJobs:      alreadySlimJobs,
+		IgnoredJobs:          ignoredJobs,
+		OtherOSJobs:          otherOSJobs,
+		UnresolvedRunsOnJobs: unresolvedJobs,
 	}, nil
 }
 
+// expandPaths expands glob patterns in paths and checks that every file exists.
+// The result keeps the order of paths, without duplicates: a file given by
+// several paths, such as through a symbolic link or in another case on a
+// case-insensitive filesystem, is kept under the first one.
+func expandPaths(paths []string) ([]string, error) {
+	var expanded []string
+	var files []os.FileInfo
+	add := func(p string) {
+		info, err := os.Stat(p)
+		if err == nil {
+			if slices.ContainsFunc(files, func(f os.FileInfo) bool { return os.SameFile(f, info) }) {
+				return
+			}
+			files = append(files, info)
+		} else if slices.Contains(expanded, p) {
+			return
+		}
+		expanded = append(expanded, p)
+	}
+
+	for _, p := range paths {
+		if !strings.ContainsAny(p, "*?[") {
+			if _, err := os.Stat(p); err != nil {
+				return nil, fmt.Errorf("workflow file not found: %s", p)
+			}
+			add(p)
+			continue
+		}
+
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workflow file pattern %q: %w", p, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no workflow files match %q", p)
+		}
+		for _, match := range matches {
+			add(match)
+		}
+	}
+	return expanded, nil
+}
+
+// classifier sorts jobs into the result categories of a scan.
+type classifier struct {
+	sourceLabels      []string
+	slimLabel         string
+	availableCommands []string
+	missingCommands   []string
+	dockerActions     []string
+	containerCommands []*regexp.Regexp
+	// safeDockerSubcommands are docker subcommands that don't make a job
+	// ineligible, from the config file
+	safeDockerSubcommands []string
+	minActionVersions     map[string]int
+	resolveVars           map[string]string
+	ignoreRules           ignoreList
+	// visiting holds the workflows on the chain of reusable workflow calls
+	// being classified, to stop call cycles
+	visiting map[string]bool
+
+	candidates      []*Candidate
+	ineligibleJobs  []*IneligibleJob
+	alreadySlimJobs []*AlreadySlimJob
+	ignoredJobs     []*IgnoredJob
+	otherOSJobs     []*OtherOSJob
+	unresolvedJobs  []*UnresolvedRunsOnJob
+}
+
+// fork returns a classifier with the same options and no classified jobs.
+func (c *classifier) fork() *classifier {
+	return &classifier{
+		sourceLabels:          c.sourceLabels,
+		slimLabel:             c.slimLabel,
+		availableCommands:     c.availableCommands,
+		missingCommands:       c.missingCommands,
+		dockerActions:         c.dockerActions,
+		containerCommands:     c.containerCommands,
+		safeDockerSubcommands: c.safeDockerSubcommands,
+		minActionVersions:     c.minActionVersions,
+		resolveVars:           c.resolveVars,
+		ignoreRules:           c.ignoreRules,
+		visiting:              make(map[string]bool),
+	}
+}
+
+// entry returns the jobs classified by c as a cache entry.
+func (c *classifier) entry() *cacheEntry {
+	return &cacheEntry{
+		Candidates:           c.candidates,
+		IneligibleJobs:       c.ineligibleJobs,
+		AlreadySlimJobs:      c.alreadySlimJobs,
+		IgnoredJobs:          c.ignoredJobs,
+		OtherOSJobs:          c.otherOSJobs,
+		UnresolvedRunsOnJobs: c.unresolvedJobs,
+	}
+}
+
+// add adds the jobs of a cache entry to the jobs classified by c.
+func (c *classifier) add(entry *cacheEntry) {
+	c.candidates = append(c.candidates, entry.Candidates...)
+	c.ineligibleJobs = append(c.ineligibleJobs, entry.IneligibleJobs...)
+	c.alreadySlimJobs = append(c.alreadySlimJobs, entry.AlreadySlimJobs...)
+	c.ignoredJobs = append(c.ignoredJobs, entry.IgnoredJobs...)
+	c.otherOSJobs = append(c.otherOSJobs, entry.OtherOSJobs...)
+	c.unresolvedJobs = append(c.unresolvedJobs, entry.UnresolvedRunsOnJobs...)
+}
+
+// resolveRunsOn returns a copy of job whose runs-on variables, directly or
+// through the matrix, are replaced by their statically known values, with the
+// variables left unresolved. ${{ env.NAME }} takes the literal value of NAME in
+// the workflow-level env: of wf; the job's own env: is not available to
+// runs-on. ${{ vars.NAME }}, and env.NAME not set in wf, take the value of
+// NAME in --resolve-var. Secrets, the github context and env values that are
+// expressions themselves are only known when the workflow runs.
+func (c *classifier) resolveRunsOn(job *workflow.Job, wf *workflow.Workflow) (*workflow.Job, []string) {
+	values := make(map[string]string)
+	for _, variable := range job.RunsOnVariables() {
+		context, name, _ := strings.Cut(variable, ".")
+		switch context {
+		case "env":
+			if value, ok := wf.Env[name]; ok {
+				if !strings.Contains(value, "${{") {
+					values[variable] = value
+				}
+				continue
+			}
+			fallthrough
+		case "vars":
+			if value, ok := c.resolveVars[name]; ok {
+				values[variable] = value
+			}
+		}
+	}
+	job = job.WithRunsOnVariables(values)
+	return job, job.RunsOnVariables()
+}
+
+// classify categorizes a job of wf. caller and namePrefix are set for jobs
+// reached through a reusable workflow call: namePrefix is prepended to the job
+// name the way GitHub displays it (e.g. "build / test").
+func (c *classifier) classify(wf *workflow.Workflow, jobID string, job *workflow.Job, caller *Caller, namePrefix string) {
+	jobName := namePrefix + job.Name
+
+	// Skip jobs excluded by .slimifyignore
+	if rule, ok := c.ignoreRules.match(wf.Path, jobID); ok {
+		c.ignoredJobs = append(c.ignoredJobs, &IgnoredJob{
+			WorkflowPath: wf.Path,
+			WorkflowName: wf.Name,
+			Triggers:     wf.Triggers(),
+			JobID:        jobID,
+			JobName:      jobName,
+			LineNumber:   job.LineStart,
+			Rule:         rule,
+			Caller:       caller,
+		})
+		logClassified(wf, jobID, "ignored", "rule", rule)
+		return
+	}
+
+	// Evaluate the jobs of a called reusable workflow instead of the calling job
+	if job.IsReusableWorkflowCall() {
+		slog.Debug("following reusable workflow call", "workflow", wf.Path, "job", jobID, "uses", job.Uses)
+		c.classifyReusableWorkflowCall(wf, jobID, job, caller, jobName)
+		return
+	}
+
+	// Substitute statically known variables in runs-on; the label of a job
+	// using any other variable is not known until the workflow runs
+	runsOnVariables := job.RunsOnVariables()
+	if len(runsOnVariables) > 0 {
+		var unresolved []string
+		job, unresolved = c.resolveRunsOn(job, wf)
+		if len(unresolved) > 0 {
+			c.unresolvedJobs = append(c.unresolvedJobs, &UnresolvedRunsOnJob{
+				WorkflowPath: wf.Path,
+				WorkflowName: wf.Name,
+				Triggers:     wf.Triggers(),
+				JobID:        jobID,
+				JobName:      jobName,
+				LineNumber:   job.LineStart,
+				Variables:    unresolved,
+				Caller:       caller,
+			})
+			logClassified(wf, jobID, "unresolved_runs_on", "variables", unresolved)
+			return
+		}
+	}
+
+	// Check if job is already using ubuntu-slim
+	if job.IsSlim(c.slimLabel) {
+		reasons := slimRegressionReasons(job, c.slimLabel, c.dockerActions, c.containerCommands, c.safeDockerSubcommands)
+		c.alreadySlimJobs = append(c.alreadySlimJobs, &AlreadySlimJob{
+			WorkflowPath: wf.Path,
+			WorkflowName: wf.Name,
+			Triggers:     wf.Triggers(),
+			JobID:        jobID,
+			JobName:      jobName,
+			LineNumber:   job.LineStart,
+			Caller:       caller,
+			Reasons:      reasons,
+		})
+		logClassified(wf, jobID, "already_slim", "regressions", reasons)
+		return
+	}
+
+	// Jobs on Windows or macOS are not Linux jobs pinned to another label, so
+	// they are reported separately instead of as ineligible
+	if systems := job.OtherOperatingSystems(); len(systems) > 0 {
+		c.otherOSJobs = append(c.otherOSJobs, &OtherOSJob{
+			WorkflowPath: wf.Path,
+			WorkflowName: wf.Name,
+			Triggers:     wf.Triggers(),
+			JobID:        jobID,
+			JobName:      jobName,
+			LineNumber:   job.LineStart,
+			OS:           systems,
+			Caller:       caller,
+		})
+		logClassified(wf, jobID, "other_os", "os", systems)
+		return
+	}
+
+	// Check migration criteria
+	logCriteria(wf, jobID, job, c.sourceLabels, c.dockerActions, c.containerCommands, c.safeDockerSubcommands)
+	eligible, reasons := c.isEligible(job)
+	if eligible {
+		// Check for missing commands and include in candidate
+		sourceLabel, _ := job.MatchRunsOn(c.sourceLabels)
+		_, runsOnMatrix := job.RunsOnMatrixValues()
+		candidate := &Candidate{
+			WorkflowPath:            wf.Path,
+			WorkflowName:            wf.Name,
+			Triggers:                wf.Triggers(),
+			JobID:                   jobID,
+			JobName:                 jobName,
+			LineNumber:              job.LineStart,
+			SourceLabel:             sourceLabel,
+			RunsOnMatrix:            runsOnMatrix,
+			MissingCommands:         job.GetMissingCommandsWith(c.sourceLabels, c.availableCommands, c.missingCommands),
+			Condition:               job.Condition(),
+			SetupActions:            job.SetupActions(),
+			OutdatedActions:         job.OutdatedActions(c.minActionVersions),
+			Caller:                  caller,
+			NoSteps:                 len(job.Steps) == 0,
+			NonPOSIXShellSteps:      job.NonPOSIXShellSteps(),
+			RunsOnVariables:         runsOnVariables,
+			Signature:               jobSignature(jobName, job),
+			LinuxSkippedDockerSteps: job.DockerCommandStepsSkippedOnLinux(c.containerCommands, c.safeDockerSubcommands),
+			DockerLayerCacheSteps:   job.DockerLayerCacheSteps(),
+		}
+		candidate.TimeoutMinutes, _ = job.TimeoutMinutes()
+		candidate.Confidence = scoreConfidence(candidate)
+		c.candidates = append(c.candidates, candidate)
+		logClassified(wf, jobID, "eligible", "source_label", sourceLabel, "confidence", candidate.Confidence)
+		return
+	}
+
+	// Record ineligible job with reasons
+	matched, _ := partitionMatrixValues(job, c.sourceLabels)
+	sourceLabel, _ := job.MatchRunsOn(c.sourceLabels)
+	if sourceLabel == "" && len(matched) > 0 {
+		sourceLabel = matched[0]
+	}
+	c.ineligibleJobs = append(c.ineligibleJobs, &IneligibleJob{
+		WorkflowPath:      wf.Path,
+		WorkflowName:      wf.Name,
+		Triggers:          wf.Triggers(),
+		JobID:             jobID,
+		JobName:           jobName,
+		LineNumber:        job.LineStart,
+		Reasons:           reasons,
+		SourceLabel:       sourceLabel,
+		Justification:     job.IgnoreJustification(),
+		MissingCommands:   job.GetMissingCommandsWith(c.sourceLabels, c.availableCommands, c.missingCommands),
+		PartiallyEligible: len(matched) > 0,
+		Services:          job.ServiceNames(),
+		Caller:            caller,
+		PinnedLabel:       c.pinnedLabel(job),
+	})
+	logClassified(wf, jobID, "ineligible", "reasons", reasons)
+}
+
+// pinnedUbuntuLabel matches runs-on labels of a pinned Ubuntu version, such as
+// ubuntu-22.04 or ubuntu-24.04-arm.
+var pinnedUbuntuLabel = regexp.MustCompile(`^ubuntu-\d+\.\d+(?:-arm)?$`)
+
+// pinnedLabel returns the pinned Ubuntu label job runs on if it isn't a
+// migration source and the job meets every other criterion with it as one.
+// Returns "" otherwise.
+func (c *classifier) pinnedLabel(job *workflow.Job) string {
+	labels := job.RunsOnLabels()
+	if len(labels) != 1 {
+		return ""
+	}
+	label := workflow.NormalizeLabel(labels[0])
+	if _, ok := workflow.MatchLabel(c.sourceLabels, label); ok || !pinnedUbuntuLabel.MatchString(label) {
+		return ""
+	}
+	if eligible, _ := checkEligibility(job, []string{label}, c.dockerActions, c.containerCommands, c.safeDockerSubcommands); !eligible {
+		return ""
+	}
+	return label
+}
+
+// logClassified logs at debug level the category a job of wf was classified
+// into, followed by attributes saying why.
+func logClassified(wf *workflow.Workflow, jobID, category string, args ...any) {
+	slog.Debug("job classified", append([]any{"workflow", wf.Path, "job", jobID, "category", category}, args...)...)
+}
+
+// logCriteria logs at debug level the outcome of each migration criterion for
+// a job, tracing which one rejected it. The criteria are evaluated again for
+// this, so only when debug logging is enabled.
+func logCriteria(wf *workflow.Workflow, jobID string, job *workflow.Job, sourceLabels, dockerActions []string, containerCommands []*regexp.Regexp, safeDockerSubcommands []string) {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	for _, check := range evaluateCriteria(job, sourceLabels, dockerActions, containerCommands, safeDockerSubcommands) {
+		if check.Passed {
+			slog.Debug("criterion passed", "workflow", wf.Path, "job", jobID, "criterion", check.Name)
+		} else {
+			slog.Debug("criterion failed", "workflow", wf.Path, "job", jobID, "criterion", check.Name, "reason", check.Reason)
+		}
+	}
+}
+
+// classifyReusableWorkflowCall follows a job's call to a reusable workflow in the
+// same repository and classifies the called jobs, qualified by the calling job.
+// A workflow called by several jobs is classified once for each of them. Calls
+// that can't be followed, such as remote references or calls back into a
+// workflow of the same call chain, make the calling job ineligible.
+func (c *classifier) classifyReusableWorkflowCall(wf *workflow.Workflow, jobID string, job *workflow.Job, caller *Caller, jobName string) {
+	ineligible := func(reason string) {
+		c.ineligibleJobs = append(c.ineligibleJobs, &IneligibleJob{
+			WorkflowPath: wf.Path,
+			WorkflowName: wf.Name,
+			Triggers:     wf.Triggers(),
+			JobID:        jobID,
+			JobName:      jobName,
+			LineNumber:   job.LineStart,
+			Reasons:      []string{reason},
+			Caller:       caller,
+		})
+	}
+
+	path, ok := job.LocalReusableWorkflowPath()
+	if !ok {
+		ineligible(fmt.Sprintf("unresolvable remote reusable workflow (%s)", job.Uses))
+		return
+	}
+
+	// The chain of calls starts at the workflow of the top-level caller
+	if caller == nil {
+		start := filepath.Clean(wf.Path)
+		c.visiting[start] = true
+		defer delete(c.visiting, start)
+	}
+	if c.visiting[path] {
+		ineligible(fmt.Sprintf("reusable workflow call cycle (%s)", job.Uses))
+		return
+	}
+	c.visiting[path] = true
+	defer delete(c.visiting, path)
+
+	called, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			ineligible(fmt.Sprintf("reusable workflow not found (%s)", job.Uses))
+		} else {
+			ineligible(fmt.Sprintf("reusable workflow could not be loaded (%s)", job.Uses))
+		}
+		return
+	}
+
+	// Called jobs run as part of the top-level caller's workflow runs
+	if caller == nil {
+		caller = &Caller{WorkflowPath: wf.Path, JobID: jobID}
+	}
+	for calledID, calledJob := range called.Jobs {
+		c.classify(called, calledID, calledJob, caller, jobName+" / ")
+	}
+}
+
+// calledWorkflows returns the paths of the local reusable workflows called by
+// jobs of workflows, apart from calls of a workflow to itself.
+func calledWorkflows(workflows []*workflow.Workflow) map[string]bool {
+	called := make(map[string]bool)
+	for _, wf := range workflows {
+		for _, job := range wf.Jobs {
+			if path, ok := job.LocalReusableWorkflowPath(); ok && path != filepath.Clean(wf.Path) {
+				called[path] = true
+			}
+		}
+	}
+	return called
+}
+
+// onlyCalled reports whether wf runs only when called by another workflow,
+// i.e. its only trigger is workflow_call.
+func onlyCalled(wf *workflow.Workflow) bool {
+	return slices.Equal(wf.Triggers(), []string{"workflow_call"})
+}
+
+// loadWorkflows parses workflow files using a pool of at most concurrency workers.
+// It returns the parsed workflows and the errors of the files that could not
+// be loaded, both in the order of paths.
+func loadWorkflows(paths []string, concurrency int) ([]*workflow.Workflow, []FileError) {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > len(paths) {
+		concurrency = len(paths)
+	}
+
+	workflows := make([]*workflow.Workflow, len(paths))
+	errs := make([]error, len(paths))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				workflows[i], errs[i] = workflow.LoadWorkflow(paths[i])
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var loaded []*workflow.Workflow
+	var fileErrors []FileError
+	for i, wf := range workflows {
+		if errs[i] != nil {
+			fileErrors = append(fileErrors, FileError{Path: paths[i], Err: errs[i]})
+			continue
+		}
+		loaded = append(loaded, wf)
+	}
+	return loaded, fileErrors
+}
+
+// sortJobs sorts jobs by workflow path, then line number, then job ID as returned by key
+func sortJobs[T any](jobs []T, key func(T) (string, int, string)) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		pi, li, ii := key(jobs[i])
+		pj, lj, ij := key(jobs[j])
+		if pi != pj {
+			return pi < pj
+		}
+		if li != lj {
+			return li < lj
+		}
+		return ii < ij
+	})
+}
+
 // checkEligibility checks if a job meets all migration criteria and returns
 // eligibility status along with reasons if not eligible.
-// Criteria:
-// 1. Runs on ubuntu-latest
+// Reasons point at what blocks migration, e.g. the actual runs-on label,
+// the steps using Docker, or the names of service containers.
+// Criteria (evaluated by evaluateCriteria):
+// 0. Is not disabled by a static if: false
+// 1. Runs on one of sourceLabels (ubuntu-latest by default)
 // 2. Does not use Docker commands
-// 3. Does not use container-based GitHub Actions
+// 3. Does not use container-based GitHub Actions, or other actions that need
+// a Docker daemon (dockerActions)
 // 4. Does not use services containers (e.g. services:)
 // 5. Does not run steps inside a Docker container. (e.g. container:)
-// 6. Duration check will be added later via GitHub API
+// 6. Does not use privileged operations
+// 7. Duration check will be added later via GitHub API
+// A job failing criterion 0 or 1 only reports that reason.
 // Returns (isEligible, reasons) where reasons is empty if eligible.
-func checkEligibility(job *workflow.Job) (bool, []string) {
+func checkEligibility(job *workflow.Job, sourceLabels, dockerActions []string, containerCommands []*regexp.Regexp, safeDockerSubcommands []string) (bool, []string) {
 	var reasons []string
+	for _, check := range evaluateCriteria(job, sourceLabels, dockerActions, containerCommands, safeDockerSubcommands) {
+		if check.Passed {
+			continue
+		}
+		if check.final {
+			return false, []string{check.Reason}
+		}
+		reasons = append(reasons, check.Reason)
+	}
+
+	// Duration check will be done via GitHub API
+	// Duration is fetched after eligibility check to avoid blocking on API calls
 
-	// Criterion 1: Must run on ubuntu-latest
-	if !job.IsUbuntuLatest() {
-		reasons = append(reasons, "does not run on ubuntu-latest")
+	if len(reasons) > 0 {
 		return false, reasons
 	}
 
+	return true, nil
+}
+
+// Check is the outcome of a single migration criterion for a job.
+type Check struct {
+	Name   string // What the criterion requires, e.g. "no Docker commands"
+	Passed bool
+	Reason string // Why the criterion failed, as reported in IneligibleJob.Reasons
+	// final marks criteria whose failure makes the other criteria irrelevant
+	final bool
+}
+
+// evaluateCriteria evaluates every migration criterion for job, in the order
+// they are reported. It is the single source of truth for checkEligibility and Explain.
+func evaluateCriteria(job *workflow.Job, sourceLabels, dockerActions []string, containerCommands []*regexp.Regexp, safeDockerSubcommands []string) []Check {
+	var checks []Check
+
+	// Criterion 0: Jobs that never run are not worth migrating
+	disabled := Check{Name: "not disabled by if: false", Passed: true, final: true}
+	if job.IsDisabled() {
+		disabled.Passed = false
+		disabled.Reason = "is disabled by if: false"
+	}
+	checks = append(checks, disabled)
+
+	// Criterion 1: Must run on a migration source label
+	wanted := strings.Join(sourceLabels, " or ")
+	runsOn := Check{Name: "runs-on is " + wanted, Passed: true, final: true}
+	if _, ok := job.MatchRunsOn(sourceLabels); !ok {
+		runsOn.Passed = false
+		if matched, others := partitionMatrixValues(job, sourceLabels); len(matched) > 0 {
+			runsOn.Reason = fmt.Sprintf("runs-on matrix is only partially eligible, %s is not %s", strings.Join(others, ", "), wanted)
+		} else if len(others) > 0 {
+			runsOn.Reason = fmt.Sprintf("runs-on is %s (%s), not %s", describeRunsOn(job.RunsOn), strings.Join(others, ", "), wanted)
+		} else if labels := job.RunsOnLabels(); len(labels) > 1 && slices.ContainsFunc(labels, func(l string) bool { _, ok := workflow.MatchLabel(sourceLabels, l); return ok }) {
+			// A label list selects runners that have all of the labels
+			runsOn.Reason = fmt.Sprintf("runs-on is %s, which needs a runner with all of these labels, not %s", describeRunsOn(job.RunsOn), wanted)
+		} else {
+			runsOn.Reason = fmt.Sprintf("runs-on is %s, not %s", describeRunsOn(job.RunsOn), wanted)
+		}
+	}
+	checks = append(checks, runsOn)
+
 	// Criterion 2: Must not use Docker commands
-	if job.HasDockerCommands() {
-		reasons = append(reasons, "uses Docker commands")
+	docker := Check{Name: "no Docker commands", Passed: true}
+	if steps := job.DockerCommandStepsWith(containerCommands, safeDockerSubcommands); len(steps) > 0 {
+		docker.Passed = false
+		docker.Reason = "uses Docker commands in " + formatSteps(steps)
 	}
+	checks = append(checks, docker)
 
-	// Criterion 3: Must not use container-based GitHub Actions
-	if job.HasContainerActions() {
-		reasons = append(reasons, "uses container-based GitHub Actions")
+	// Criterion 2b: Must not create local Kubernetes clusters, whose nodes
+	// are Docker containers
+	cluster := Check{Name: "no local Kubernetes clusters", Passed: true}
+	if tools := job.LocalClusterTools(); len(tools) > 0 {
+		cluster.Passed = false
+		cluster.Reason = "creates a local Kubernetes cluster, which needs a Docker daemon: " + strings.Join(tools, ", ")
 	}
+	checks = append(checks, cluster)
+
+	// Criterion 3: Must not use container-based GitHub Actions. Actions
+	// setting up cross-architecture builds are reported on their own, since
+	// the job needs emulation rather than only a Docker daemon
+	crossArchSteps, crossArchActions := job.CrossArchSetupSteps()
+	crossArch := Check{Name: "no cross-architecture build setup", Passed: true}
+	if len(crossArchSteps) > 0 {
+		crossArch.Passed = false
+		crossArch.Reason = fmt.Sprintf("sets up a cross-architecture build in %s (%s), which needs QEMU emulation and a Docker daemon",
+			formatSteps(crossArchSteps), strings.Join(crossArchActions, ", "))
+	}
+	checks = append(checks, crossArch)
+
+	actions := Check{Name: "no container-based GitHub Actions", Passed: true}
+	containerSteps := slices.DeleteFunc(job.ContainerActionSteps(), func(step int) bool {
+		return slices.Contains(crossArchSteps, step)
+	})
+	if len(containerSteps) > 0 {
+		actions.Passed = false
+		actions.Reason = "uses container-based GitHub Actions in " + formatSteps(containerSteps)
+	}
+	checks = append(checks, actions)
+
+	// Criterion 3b: Must not use actions that need a Docker daemon
+	dependent := Check{Name: "no docker-dependent actions", Passed: true}
+	if names := job.DockerDependentActions(dockerActions); len(names) > 0 {
+		dependent.Passed = false
+		dependent.Reason = "uses docker-dependent action: " + strings.Join(names, ", ")
+	}
+	checks = append(checks, dependent)
+
+	// Criterion 3c: Must not use local actions that need Docker
+	local := Check{Name: "no local composite actions using Docker", Passed: true}
+	if names := job.LocalDockerActionsWith(dockerActions, containerCommands, safeDockerSubcommands); len(names) > 0 {
+		local.Passed = false
+		local.Reason = "local composite action uses docker: " + strings.Join(names, ", ")
+	}
+	checks = append(checks, local)
 
 	// Criterion 4: Must not use services
+	services := Check{Name: "no service containers", Passed: true}
 	if job.HasServices() {
-		reasons = append(reasons, "uses service containers")
+		services.Passed = false
+		services.Reason = "uses service containers"
+		if names := job.ServiceNames(); len(names) > 0 {
+			services.Reason = "requires services: " + strings.Join(names, ", ")
+		}
 	}
+	checks = append(checks, services)
 
 	// Criterion 5: Must not use container: syntax
+	container := Check{Name: "no container: syntax", Passed: true}
 	if job.HasContainer() {
-		reasons = append(reasons, "uses container syntax")
+		container.Passed = false
+		container.Reason = "uses container syntax"
+		if image := job.ContainerImage(); image != "" {
+			container.Reason += " (" + image + ")"
+		}
 	}
+	checks = append(checks, container)
 
 	// Criterion 6: Must not use privileged operations
+	privileged := Check{Name: "no privileged operations", Passed: true}
 	if hasPrivOps, privCmds := job.HasPrivilegedOperations(); hasPrivOps {
-		reasons = append(reasons, fmt.Sprintf("uses privileged operations (%s)", strings.Join(privCmds, ", ")))
+		privileged.Passed = false
+		privileged.Reason = "uses privileged operation: " + strings.Join(privCmds, ", ")
 	}
+	checks = append(checks, privileged)
 
-	// Criterion 7: Duration check will be done via GitHub API
-	// Duration is fetched after eligibility check to avoid blocking on API calls
+	return checks
+}
 
-	if len(reasons) > 0 {
-		return false, reasons
+// slimRegressionReasons returns why a job already on slimLabel would fail
+// there: the migration criteria other than runs-on that it doesn't meet.
+// Jobs disabled with if: false never run, so they have none.
+func slimRegressionReasons(job *workflow.Job, slimLabel string, dockerActions []string, containerCommands []*regexp.Regexp, safeDockerSubcommands []string) []string {
+	if job.IsDisabled() {
+		return nil
+	}
+	var reasons []string
+	for _, check := range evaluateCriteria(job, []string{slimLabel}, dockerActions, containerCommands, safeDockerSubcommands) {
+		if !check.Passed && !check.final {
+			reasons = append(reasons, check.Reason)
+		}
 	}
+	return reasons
+}
 
-	return true, nil
+// withDefaultMinActionVersions returns the default minimum action versions
+// overridden by the given ones.
+func withDefaultMinActionVersions(overrides map[string]int) map[string]int {
+	versions := maps.Clone(workflow.DefaultMinActionVersions)
+	maps.Copy(versions, overrides)
+	return versions
+}
+
+// withDefaultDockerActions returns the default docker-dependent actions
+// followed by the additional actions.
+func withDefaultDockerActions(additional []string) []string {
+	return append(slices.Clone(workflow.DefaultDockerDependentActions), additional...)
+}
+
+// partitionMatrixValues splits the values of a runs-on matrix expression into
+// those that are in sourceLabels and the others.
+// Both are empty if runs-on is not a resolvable matrix expression.
+func partitionMatrixValues(job *workflow.Job, sourceLabels []string) (matched, others []string) {
+	values, ok := job.RunsOnMatrixValues()
+	if !ok {
+		return nil, nil
+	}
+	for _, value := range values {
+		if _, ok := workflow.MatchLabel(sourceLabels, value); ok {
+			matched = append(matched, value)
+		} else {
+			others = append(others, value)
+		}
+	}
+	return matched, others
+}
+
+// describeRunsOn formats a runs-on value for use in a reason message
+func describeRunsOn(runsOn interface{}) string {
+	switch v := runsOn.(type) {
+	case nil:
+		return "not set"
+	case string:
+		return v
+	case []interface{}:
+		labels := make([]string, 0, len(v))
+		for _, item := range v {
+			labels = append(labels, fmt.Sprint(item))
+		}
+		return "[" + strings.Join(labels, ", ") + "]"
+	case map[string]interface{}:
+		desc := "a runner group"
+		if group, ok := v["group"].(string); ok {
+			desc = "runner group " + group
+		}
+		if labels, ok := v["labels"]; ok {
+			desc += " with labels " + describeRunsOn(labels)
+		}
+		return desc
+	default:
+		return "a runner group"
+	}
+}
+
+// formatSteps formats 1-based step indexes, e.g. "step 3" or "steps 2, 4"
+func formatSteps(steps []int) string {
+	if len(steps) == 1 {
+		return fmt.Sprintf("step %d", steps[0])
+	}
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = fmt.Sprint(step)
+	}
+	return "steps " + strings.Join(parts, ", ")
+}
+
+// IsEligible reports whether job can migrate to ubuntu-slim and, if not, the
+// reasons why, as reported in IneligibleJob.Reasons. The job is judged like
+// Scan judges it without .slimify.yml or options: it must run on one of
+// DefaultSourceLabels, and only the built-in Docker-dependent actions and
+// container commands are known. Reasons are empty if the job is eligible.
+// Durations, which come from the GitHub API, are not considered.
+func IsEligible(job *workflow.Job) (bool, []string) {
+	cl := &classifier{sourceLabels: DefaultSourceLabels, dockerActions: withDefaultDockerActions(nil)}
+	return cl.isEligible(job)
+}
+
+// IsEligibleWith is like IsEligible, but judges job exactly like Scan with opts
+// does, including the rules of .slimify.yml in the current directory. runs-on
+// is taken as is; variables in it are not resolved. It returns an error if
+// .slimify.yml or .slimifyignore can't be loaded.
+func IsEligibleWith(job *workflow.Job, opts Options) (bool, []string, error) {
+	cl, err := newClassifier(opts)
+	if err != nil {
+		return false, nil, err
+	}
+	eligible, reasons := cl.isEligible(job)
+	return eligible, reasons, nil
 }
 
-// isEligible checks if a job meets all migration criteria (kept for backward compatibility with tests)
-func isEligible(job *workflow.Job) bool {
-	isEligible, _ := checkEligibility(job)
-	return isEligible
+// isEligible reports whether job meets the migration criteria under the rules
+// of c and, if not, the reasons why. It is the verdict classify reports.
+func (c *classifier) isEligible(job *workflow.Job) (bool, []string) {
+	return checkEligibility(job, c.sourceLabels, c.dockerActions, c.containerCommands, c.safeDockerSubcommands)
 }
 
 // fetchDurations fetches job execution durations from GitHub API
 // verbose, if true, enables verbose output including debug warnings.
-func fetchDurations(candidates []*Candidate, verbose bool) error {
+// hostname, if non-empty, overrides the host of the repository.
+// apiRetries is the number of times a rate limited request is retried.
+// progress, if non-nil, is called before each job's duration is fetched.
+func fetchDurations(candidates []*Candidate, verbose bool, hostname string, apiRetries int, progress func(done, total int)) error {
 	if len(candidates) == 0 {
 		return nil
 	}
@@ -212,19 +1320,44 @@ func fetchDurations(candidates []*Candidate, verbose bool) error {
 	if err != nil {
 		return fmt.Errorf("failed to get repository info: %w", err)
 	}
+	if hostname != "" {
+		host = hostname
+	}
 
 	// Create API client
-	client, err := api.NewClient(host, owner, repo)
+	client, err := api.NewClient(host, owner, repo, apiRetries)
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
 	ctx := context.Background()
 
+	// Only consider runs on the default branch, so durations are not skewed by
+	// feature branches. Fall back to all branches if it cannot be determined.
+	branch, err := client.GetDefaultBranch(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get default branch, using runs from all branches: %v\n", err)
+		}
+		branch = ""
+	}
+
 	// Fetch duration for each candidate
-	for _, candidate := range candidates {
-		duration, err := client.GetJobDuration(ctx, candidate.WorkflowPath, candidate.JobID, candidate.JobName)
+	for i, candidate := range candidates {
+		if progress != nil {
+			progress(i+1, len(candidates))
+		}
+		// Jobs of reusable workflows run as part of their caller's workflow runs
+		workflowPath := candidate.WorkflowPath
+		if candidate.Caller != nil {
+			workflowPath = candidate.Caller.WorkflowPath
+		}
+		duration, err := client.GetJobDuration(ctx, workflowPath, branch, candidate.JobID, candidate.JobName)
 		if err != nil {
+			// Remaining durations are left unknown once rate limited
+			if errors.Is(err, api.ErrRateLimited) {
+				return fmt.Errorf("stopped fetching durations: %w", err)
+			}
 			// Log error for debugging but continue to next candidate
 			if verbose {
 				fmt.Fprintf(os.Stderr, "Warning: failed to get duration for job %s (ID: %s) in %s: %v\n", candidate.JobName, candidate.JobID, candidate.WorkflowPath, err)
@@ -234,6 +1367,7 @@ func fetchDurations(candidates []*Candidate, verbose bool) error {
 
 		// Format duration as human-readable string
 		candidate.Duration = formatDuration(duration.Duration)
+		candidate.LastRun = duration.CompletedAt
 	}
 
 	return nil
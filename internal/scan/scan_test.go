@@ -1,8 +1,13 @@
 package scan
 
 import (
+	"bytes"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -132,6 +137,15 @@ func TestIsEligible_UbuntuLatest(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "eligible - owner name starts with docker",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Uses: "dockerized-lint/action@v1"}},
+				Services: nil,
+			},
+			expected: true,
+		},
 		{
 			name: "eligible - uses standard actions",
 			job: &workflow.Job{
@@ -245,6 +259,51 @@ echo "Done"`,
 			},
 			expected: false,
 		},
+		{
+			name: "not eligible - uses podman build",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Run: "podman build -t myapp ."}},
+				Services: nil,
+			},
+			expected: false,
+		},
+		{
+			name: "not eligible - uses podman run",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Run: "podman run myapp"}},
+				Services: nil,
+			},
+			expected: false,
+		},
+		{
+			name: "not eligible - uses podman-compose",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Run: "podman-compose up -d"}},
+				Services: nil,
+			},
+			expected: false,
+		},
+		{
+			name: "not eligible - uses buildah bud",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Run: "buildah bud -t myapp ."}},
+				Services: nil,
+			},
+			expected: false,
+		},
+		{
+			name: "eligible - podman as part of another word",
+			job: &workflow.Job{
+				RunsOn:   "ubuntu-latest",
+				Steps:    []workflow.Step{{Run: "mypodman-helper run"}},
+				Services: nil,
+			},
+			expected: true,
+		},
 		{
 			name: "eligible - amount does not match mount",
 			job: &workflow.Job{
@@ -258,9 +317,62 @@ echo "Done"`,
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isEligible(tt.job)
+			got, _ := IsEligible(tt.job)
+			if got != tt.expected {
+				t.Errorf("IsEligible() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsEligible_IfCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      *workflow.Job
+		expected bool
+	}{
+		{
+			name: "not eligible - if: false",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				If:     false,
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expected: false,
+		},
+		{
+			name: "not eligible - if: ${{ false }}",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				If:     "${{ false }}",
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expected: false,
+		},
+		{
+			name: "eligible - event expression",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				If:     "${{ github.event_name == 'push' }}",
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expected: true,
+		},
+		{
+			name: "eligible - no if",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := IsEligible(tt.job)
 			if got != tt.expected {
-				t.Errorf("isEligible() = %v, want %v", got, tt.expected)
+				t.Errorf("IsEligible() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
@@ -294,9 +406,61 @@ func TestIsEligible_MatrixStrategy(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isEligible(tt.job)
+			got, _ := IsEligible(tt.job)
 			if got != tt.expected {
-				t.Errorf("isEligible() = %v, want %v", got, tt.expected)
+				t.Errorf("IsEligible() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsEligible_Reasons(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         *workflow.Job
+		wantReasons []string
+	}{
+		{
+			name: "eligible",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps:  []workflow.Step{{Run: "make test"}},
+			},
+		},
+		{
+			name: "other runner only reports runs-on",
+			job: &workflow.Job{
+				RunsOn: "windows-latest",
+				Steps:  []workflow.Step{{Run: "docker build ."}},
+			},
+			wantReasons: []string{"runs-on is windows-latest, not ubuntu-latest"},
+		},
+		{
+			name: "every failed criterion",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []workflow.Step{
+					{Run: "docker build -t app ."},
+					{Uses: "aquasecurity/trivy-action@0.28.0"},
+				},
+				Services: map[string]any{"postgres": map[string]any{"image": "postgres:16"}},
+			},
+			wantReasons: []string{
+				"uses Docker commands in step 1",
+				"uses docker-dependent action: aquasecurity/trivy-action",
+				"requires services: postgres",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eligible, reasons := IsEligible(tt.job)
+			if eligible != (len(tt.wantReasons) == 0) {
+				t.Errorf("IsEligible() eligible = %v, want %v", eligible, len(tt.wantReasons) == 0)
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("IsEligible() reasons = %q, want %q", reasons, tt.wantReasons)
 			}
 		})
 	}
@@ -477,7 +641,7 @@ on: push`,
 			}
 
 			// Run Scan (skip duration for tests to avoid API calls)
-			result, err := Scan(true, false)
+			result, err := Scan(Options{SkipDuration: true})
 
 			if tt.expectError && err == nil {
 				t.Errorf("Scan() expected error but got none")
@@ -542,7 +706,7 @@ func TestCheckEligibility_PrivilegedOperations(t *testing.T) {
 				Steps:  []workflow.Step{{Run: "mount /dev/sda1 /mnt"}},
 			},
 			wantEligible:   false,
-			wantReasonPart: "uses privileged operations (mount)",
+			wantReasonPart: "uses privileged operation: mount",
 		},
 		{
 			name: "not eligible - multiple privileged commands",
@@ -554,7 +718,7 @@ func TestCheckEligibility_PrivilegedOperations(t *testing.T) {
 				},
 			},
 			wantEligible:   false,
-			wantReasonPart: "uses privileged operations",
+			wantReasonPart: "uses privileged operation: mount, sysctl",
 		},
 		{
 			name: "not eligible - privileged ops combined with docker",
@@ -566,13 +730,13 @@ func TestCheckEligibility_PrivilegedOperations(t *testing.T) {
 				},
 			},
 			wantEligible:   false,
-			wantReasonPart: "uses privileged operations",
+			wantReasonPart: "uses privileged operation: iptables",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotEligible, reasons := checkEligibility(tt.job)
+			gotEligible, reasons := checkEligibility(tt.job, DefaultSourceLabels, nil, nil, nil)
 			if gotEligible != tt.wantEligible {
 				t.Errorf("checkEligibility() eligible = %v, want %v", gotEligible, tt.wantEligible)
 			}
@@ -592,93 +756,84 @@ func TestCheckEligibility_PrivilegedOperations(t *testing.T) {
 	}
 }
 
-func TestScan_NoWorkflowDirectory(t *testing.T) {
-	// Create a temporary directory without .github/workflows
-	tmpDir := t.TempDir()
-
-	// Save original working directory
-	originalWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-
-	// Change to temporary directory
-	if err := os.Chdir(tmpDir); err != nil {
-		t.Fatalf("Failed to change directory: %v", err)
+func TestCheckEligibility_LocalClusters(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        string
+		wantReason string
+	}{
+		{name: "kind", run: "kind create cluster", wantReason: "creates a local Kubernetes cluster, which needs a Docker daemon: kind"},
+		{name: "minikube", run: "minikube start --driver=docker", wantReason: "creates a local Kubernetes cluster, which needs a Docker daemon: minikube"},
+		{name: "k3d", run: "k3d cluster create ci", wantReason: "creates a local Kubernetes cluster, which needs a Docker daemon: k3d"},
+		{name: "kindly", run: "kindly create cluster"},
 	}
-	defer func() {
-		os.Chdir(originalWd)
-	}()
 
-	result, err := Scan(true, false)
-	if err == nil {
-		t.Error("Scan() expected error when workflow directory doesn't exist")
-	}
-	if result != nil {
-		t.Errorf("Scan() expected nil result, got %v", result)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Run: tt.run}}}
+			gotEligible, reasons := checkEligibility(job, DefaultSourceLabels, nil, nil, nil)
+			if gotEligible != (tt.wantReason == "") {
+				t.Errorf("checkEligibility() eligible = %v, want %v", gotEligible, tt.wantReason == "")
+			}
+			if tt.wantReason != "" && !reflect.DeepEqual(reasons, []string{tt.wantReason}) {
+				t.Errorf("checkEligibility() reasons = %v, want [%s]", reasons, tt.wantReason)
+			}
+		})
 	}
 }
 
-func TestIsEligible_AlreadySlim(t *testing.T) {
+func TestCheckEligibility_CrossArchBuild(t *testing.T) {
 	tests := []struct {
-		name             string
-		job              *workflow.Job
-		expectedSlim     bool
-		expectedEligible bool
+		name        string
+		steps       []workflow.Step
+		wantReasons []string
 	}{
 		{
-			name: "already using ubuntu-slim",
-			job: &workflow.Job{
-				RunsOn: "ubuntu-slim",
-				Steps:  []workflow.Step{{Run: "echo hello"}},
-			},
-			expectedSlim:     true,
-			expectedEligible: false,
+			name:        "QEMU",
+			steps:       []workflow.Step{{Uses: "actions/checkout@v4"}, {Uses: "docker/setup-qemu-action@v3"}},
+			wantReasons: []string{"sets up a cross-architecture build in step 2 (docker/setup-qemu-action), which needs QEMU emulation and a Docker daemon"},
 		},
 		{
-			name: "ubuntu-slim in matrix",
-			job: &workflow.Job{
-				RunsOn: []interface{}{"ubuntu-slim"},
-				Steps:  []workflow.Step{{Run: "echo hello"}},
-			},
-			expectedSlim:     true,
-			expectedEligible: false,
+			name:        "Buildx",
+			steps:       []workflow.Step{{Uses: "docker/setup-buildx-action@v3"}},
+			wantReasons: []string{"sets up a cross-architecture build in step 1 (docker/setup-buildx-action), which needs QEMU emulation and a Docker daemon"},
 		},
 		{
-			name: "ubuntu-latest - eligible",
-			job: &workflow.Job{
-				RunsOn: "ubuntu-latest",
-				Steps:  []workflow.Step{{Run: "echo hello"}},
+			name: "QEMU and Buildx with another Docker action",
+			steps: []workflow.Step{
+				{Uses: "docker/setup-qemu-action@v3"},
+				{Uses: "docker/setup-buildx-action@v3"},
+				{Uses: "docker/build-push-action@v6"},
 			},
-			expectedSlim:     false,
-			expectedEligible: true,
+			wantReasons: []string{
+				"sets up a cross-architecture build in steps 1, 2 (docker/setup-qemu-action, docker/setup-buildx-action), which needs QEMU emulation and a Docker daemon",
+				"uses container-based GitHub Actions in step 3",
+			},
+		},
+		{
+			name:        "plain Docker commands",
+			steps:       []workflow.Step{{Run: "docker build --platform linux/arm64 ."}},
+			wantReasons: []string{"uses Docker commands in step 1"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotSlim := tt.job.IsUbuntuSlim()
-			if gotSlim != tt.expectedSlim {
-				t.Errorf("IsUbuntuSlim() = %v, want %v", gotSlim, tt.expectedSlim)
+			job := &workflow.Job{RunsOn: "ubuntu-latest", Steps: tt.steps}
+			eligible, reasons := checkEligibility(job, DefaultSourceLabels, nil, nil, nil)
+			if eligible {
+				t.Fatalf("checkEligibility() eligible = true, want false")
 			}
-
-			if !gotSlim {
-				gotEligible := isEligible(tt.job)
-				if gotEligible != tt.expectedEligible {
-					t.Errorf("isEligible() = %v, want %v", gotEligible, tt.expectedEligible)
-				}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("checkEligibility() reasons = %q, want %q", reasons, tt.wantReasons)
 			}
 		})
 	}
 }
 
-func TestScan_AlreadySlimJobs(t *testing.T) {
-	// Create a temporary directory structure
+func TestScan_NoWorkflowDirectory(t *testing.T) {
+	// Create a temporary directory without .github/workflows
 	tmpDir := t.TempDir()
-	workflowDir := filepath.Join(tmpDir, ".github", "workflows")
-	if err := os.MkdirAll(workflowDir, 0755); err != nil {
-		t.Fatalf("Failed to create workflow directory: %v", err)
-	}
 
 	// Save original working directory
 	originalWd, err := os.Getwd()
@@ -694,58 +849,1916 @@ func TestScan_AlreadySlimJobs(t *testing.T) {
 		os.Chdir(originalWd)
 	}()
 
-	// Create a workflow with an already-slim job
-	workflowContent := `name: test
+	result, err := Scan(Options{SkipDuration: true})
+	if err == nil {
+		t.Error("Scan() expected error when workflow directory doesn't exist")
+	}
+	if result != nil {
+		t.Errorf("Scan() expected nil result, got %v", result)
+	}
+}
+
+func TestScan_AdditionalDirs(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `name: CI
 on: push
 jobs:
-  already-slim:
-    runs-on: ubuntu-slim
-    steps:
-      - run: echo "already slim"
-  eligible:
+  test:
     runs-on: ubuntu-latest
     steps:
-      - run: echo "can migrate"
-  ineligible:
-    runs-on: ubuntu-22.04
-    steps:
-      - run: echo "cannot migrate"`
-
-	workflowPath := filepath.Join(workflowDir, "test.yml")
-	if err := os.WriteFile(workflowPath, []byte(workflowContent), 0644); err != nil {
-		t.Fatalf("Failed to write workflow file: %v", err)
+      - run: echo hello
+`
+	for _, f := range []string{".github/workflows/ci.yml", "apps/web/workflows/nested/web.yaml"} {
+		if err := os.MkdirAll(filepath.Dir(f), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(f, []byte(workflowContent), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", f, err)
+		}
 	}
 
-	result, err := Scan(true, false)
+	result, err := Scan(Options{SkipDuration: true, Dirs: []string{"apps/web/workflows"}})
 	if err != nil {
-		t.Fatalf("Scan() returned error: %v", err)
+		t.Fatalf("Scan() error: %v", err)
 	}
-
-	if result == nil {
-		t.Fatal("Scan() returned nil result")
+	if len(result.Candidates) != 2 {
+		t.Errorf("Scan() returned %d candidates, want 2", len(result.Candidates))
 	}
 
-	// Check candidates (should have 1 eligible job)
-	if len(result.Candidates) != 1 {
-		t.Errorf("Expected 1 candidate, got %d", len(result.Candidates))
-	}
-	if len(result.Candidates) > 0 && result.Candidates[0].JobID != "eligible" {
-		t.Errorf("Expected eligible job, got %s", result.Candidates[0].JobID)
+	if _, err := Scan(Options{SkipDuration: true, Dirs: []string{"apps/missing"}}); err == nil {
+		t.Error("Scan() expected error when an additional directory doesn't exist")
 	}
+}
 
-	// Check ineligible jobs (should have 1 ineligible job)
-	if len(result.IneligibleJobs) != 1 {
-		t.Errorf("Expected 1 ineligible job, got %d", len(result.IneligibleJobs))
+func TestScan_Paths(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
 	}
-	if len(result.IneligibleJobs) > 0 && result.IneligibleJobs[0].JobID != "ineligible" {
-		t.Errorf("Expected ineligible job, got %s", result.IneligibleJobs[0].JobID)
+	for _, name := range []string{"ci.yml", "deploy-staging.yml", "deploy-prod.yml"} {
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(workflowContent), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
 	}
 
-	// Check already slim jobs (should have 1 already-slim job)
-	if len(result.AlreadySlimJobs) != 1 {
-		t.Errorf("Expected 1 already-slim job, got %d", len(result.AlreadySlimJobs))
+	tests := []struct {
+		name      string
+		paths     []string
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name:      "explicit file",
+			paths:     []string{".github/workflows/ci.yml"},
+			wantFiles: []string{".github/workflows/ci.yml"},
+		},
+		{
+			name:      "glob",
+			paths:     []string{".github/workflows/deploy*.yml"},
+			wantFiles: []string{".github/workflows/deploy-prod.yml", ".github/workflows/deploy-staging.yml"},
+		},
+		{
+			name:      "glob overlapping explicit file",
+			paths:     []string{".github/workflows/deploy-prod.yml", ".github/workflows/deploy*.yml"},
+			wantFiles: []string{".github/workflows/deploy-prod.yml", ".github/workflows/deploy-staging.yml"},
+		},
+		{
+			name:    "missing file",
+			paths:   []string{".github/workflows/missing.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "glob without matches",
+			paths:   []string{".github/workflows/release*.yml"},
+			wantErr: true,
+		},
 	}
-	if len(result.AlreadySlimJobs) > 0 && result.AlreadySlimJobs[0].JobID != "already-slim" {
-		t.Errorf("Expected already-slim job, got %s", result.AlreadySlimJobs[0].JobID)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Scan(Options{Paths: tt.paths, SkipDuration: true})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Scan() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+
+			var gotFiles []string
+			for _, c := range result.Candidates {
+				gotFiles = append(gotFiles, filepath.ToSlash(c.WorkflowPath))
+			}
+			if !reflect.DeepEqual(gotFiles, tt.wantFiles) {
+				t.Errorf("Scan() scanned %v, want %v", gotFiles, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestScan_ParseErrors(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	files := map[string]string{
+		"a.yml":      workflowContent,
+		"broken.yml": "on: push\njobs:\n\ttest: {}\n",
+		"c.yml":      workflowContent,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "all workflows"},
+		{name: "explicit files", paths: []string{".github/workflows/a.yml", ".github/workflows/broken.yml", ".github/workflows/c.yml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Scan(Options{Paths: tt.paths, SkipDuration: true})
+			if err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+
+			var gotFiles []string
+			for _, c := range result.Candidates {
+				gotFiles = append(gotFiles, filepath.ToSlash(c.WorkflowPath))
+			}
+			wantFiles := []string{".github/workflows/a.yml", ".github/workflows/c.yml"}
+			if !reflect.DeepEqual(gotFiles, wantFiles) {
+				t.Errorf("Scan() candidates in %v, want %v", gotFiles, wantFiles)
+			}
+
+			if len(result.ParseErrors) != 1 {
+				t.Fatalf("Scan() returned %d parse errors, want 1: %v", len(result.ParseErrors), result.ParseErrors)
+			}
+			parseErr := result.ParseErrors[0]
+			if filepath.ToSlash(parseErr.Path) != ".github/workflows/broken.yml" || parseErr.Line() != 3 {
+				t.Errorf("ParseErrors[0] = %s:%d (%v), want .github/workflows/broken.yml:3", parseErr.Path, parseErr.Line(), parseErr)
+			}
+		})
+	}
+}
+
+func TestScan_MatrixRunsOn(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  linux:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest]
+        go: ["1.22", "1.23"]
+    steps:
+      - run: echo test
+  mixed:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, windows-latest]
+    steps:
+      - run: echo test
+  other:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-22.04]
+    steps:
+      - run: echo test
+  mac:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [macos-latest]
+    steps:
+      - run: echo test
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if len(result.Candidates) != 1 {
+		t.Fatalf("Scan() returned %d candidates, want 1", len(result.Candidates))
+	}
+	candidate := result.Candidates[0]
+	if candidate.JobID != "linux" || !candidate.RunsOnMatrix || candidate.SourceLabel != "ubuntu-latest" {
+		t.Errorf("Scan() candidate = %+v, want matrix job linux with source ubuntu-latest", candidate)
+	}
+
+	ineligible := make(map[string]*IneligibleJob)
+	for _, job := range result.IneligibleJobs {
+		ineligible[job.JobID] = job
+	}
+
+	mixed := ineligible["mixed"]
+	if mixed == nil || !mixed.PartiallyEligible {
+		t.Fatalf("Scan() mixed job = %+v, want partially eligible", mixed)
+	}
+	if want := "runs-on matrix is only partially eligible, windows-latest is not ubuntu-latest"; mixed.Reasons[0] != want {
+		t.Errorf("Scan() mixed reason = %q, want %q", mixed.Reasons[0], want)
+	}
+
+	other := ineligible["other"]
+	if other == nil || other.PartiallyEligible {
+		t.Fatalf("Scan() other job = %+v, want ineligible and not partially eligible", other)
+	}
+	if want := "runs-on is ${{ matrix.os }} (ubuntu-22.04), not ubuntu-latest"; other.Reasons[0] != want {
+		t.Errorf("Scan() other reason = %q, want %q", other.Reasons[0], want)
+	}
+
+	if len(result.OtherOSJobs) != 1 || result.OtherOSJobs[0].JobID != "mac" {
+		t.Errorf("Scan() OtherOSJobs = %+v, want mac", result.OtherOSJobs)
+	}
+}
+
+func TestScan_NeedsSetup(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  clean:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+  archive:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+  docker:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          docker build -t app .
+          rsync -a dist/ out/
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if len(result.Candidates) != 1 || result.Candidates[0].JobID != "clean" {
+		t.Errorf("Scan() Candidates = %+v, want only clean", result.Candidates)
+	}
+	if len(result.NeedsSetup) != 1 || result.NeedsSetup[0].JobID != "archive" {
+		t.Fatalf("Scan() NeedsSetup = %+v, want only archive", result.NeedsSetup)
+	}
+	if got := result.NeedsSetup[0].MissingCommands; !reflect.DeepEqual(got, []string{"zip"}) {
+		t.Errorf("Scan() NeedsSetup missing commands = %v, want [zip]", got)
+	}
+	if got := result.AllCandidates(); len(got) != 2 {
+		t.Errorf("AllCandidates() returned %d jobs, want 2", len(got))
+	}
+
+	if len(result.IneligibleJobs) != 1 {
+		t.Fatalf("Scan() returned %d ineligible jobs, want 1", len(result.IneligibleJobs))
+	}
+	if got := result.IneligibleJobs[0].MissingCommands; !reflect.DeepEqual(got, []string{"docker", "rsync"}) {
+		t.Errorf("Scan() ineligible missing commands = %v, want [docker rsync]", got)
+	}
+
+	// Declaring the command available makes the job a clean candidate
+	result, err = Scan(Options{SkipDuration: true, AvailableCommands: []string{"zip"}})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(result.Candidates) != 2 || len(result.NeedsSetup) != 0 {
+		t.Errorf("Scan() with zip available: %d candidates, %d needs setup; want 2, 0", len(result.Candidates), len(result.NeedsSetup))
+	}
+}
+
+func TestScan_Services(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  integration:
+    runs-on: ubuntu-latest
+    services:
+      redis:
+        image: redis:7
+      postgres:
+        image: postgres:16
+    steps:
+      - run: echo hello
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(result.IneligibleJobs) != 1 {
+		t.Fatalf("Scan() returned %d ineligible jobs, want 1", len(result.IneligibleJobs))
+	}
+
+	job := result.IneligibleJobs[0]
+	if want := []string{"postgres", "redis"}; !reflect.DeepEqual(job.Services, want) {
+		t.Errorf("IneligibleJob.Services = %v, want %v", job.Services, want)
+	}
+	if want := []string{"requires services: postgres, redis"}; !reflect.DeepEqual(job.Reasons, want) {
+		t.Errorf("IneligibleJob.Reasons = %q, want %q", job.Reasons, want)
+	}
+}
+
+func TestScan_DockerActions(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  trivy:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: aquasecurity/trivy-action@0.28.0
+  custom:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: my-org/scan-image@v1
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	ineligible := func(result *ScanResult) map[string][]string {
+		reasons := make(map[string][]string)
+		for _, job := range result.IneligibleJobs {
+			reasons[job.JobID] = job.Reasons
+		}
+		return reasons
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	want := map[string][]string{"trivy": {"uses docker-dependent action: aquasecurity/trivy-action"}}
+	if got := ineligible(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() ineligible = %v, want %v", got, want)
+	}
+
+	result, err = Scan(Options{SkipDuration: true, DockerActions: []string{"my-org/scan-image"}})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	want["custom"] = []string{"uses docker-dependent action: my-org/scan-image"}
+	if got := ineligible(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() with my-org/scan-image ineligible = %v, want %v", got, want)
+	}
+}
+
+func TestScan_LocalCompositeActions(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	files := map[string]string{
+		".github/workflows/ci.yml": `on: push
+jobs:
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: ./.github/actions/build-image
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: ./.github/actions/test
+`,
+		".github/actions/build-image/action.yml": `name: Build image
+runs:
+  using: composite
+  steps:
+    - run: docker build -t app .
+      shell: bash
+`,
+		".github/actions/test/action.yml": `name: Test
+runs:
+  using: composite
+  steps:
+    - run: make test
+      shell: bash
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(result.Candidates) != 1 || result.Candidates[0].JobID != "test" {
+		t.Errorf("Scan() candidates = %d, want only test", len(result.Candidates))
+	}
+	if len(result.IneligibleJobs) != 1 {
+		t.Fatalf("Scan() ineligible jobs = %d, want 1", len(result.IneligibleJobs))
+	}
+	want := []string{"local composite action uses docker: ./.github/actions/build-image"}
+	if got := result.IneligibleJobs[0].Reasons; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() reasons = %v, want %v", got, want)
+	}
+	if got := reasonCategory(want[0]); got != ReasonLocalActions {
+		t.Errorf("reasonCategory() = %q, want %q", got, ReasonLocalActions)
+	}
+}
+
+func TestScan_NoSteps(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  empty:
+    runs-on: ubuntu-latest
+    steps: []
+  missing:
+    runs-on: ubuntu-latest
+    needs: [empty]
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  db:
+    runs-on: ubuntu-latest
+    services:
+      postgres:
+        image: postgres
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, c := range result.AllCandidates() {
+		got[c.JobID] = c.NoSteps
+	}
+	want := map[string]bool{"empty": true, "missing": true, "build": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() candidates NoSteps = %v, want %v", got, want)
+	}
+	if len(result.IneligibleJobs) != 1 || result.IneligibleJobs[0].JobID != "db" {
+		t.Errorf("Scan() expected job db without steps but with services to be ineligible")
+	}
+}
+
+func TestScan_Anchors(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+x-defaults: &defaults
+  runs-on: ubuntu-latest
+  timeout-minutes: 10
+jobs:
+  lint:
+    <<: *defaults
+    steps:
+      - run: make lint
+  test:
+    runs-on: &runner
+      group: default
+      labels: ubuntu-latest
+    steps:
+      - run: make test
+  build:
+    runs-on: *runner
+    steps:
+      - &docker
+        run: docker build .
+  image:
+    <<: *defaults
+    steps:
+      - *docker
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	var candidates, ineligible []string
+	for _, c := range result.Candidates {
+		candidates = append(candidates, c.JobID)
+	}
+	for _, j := range result.IneligibleJobs {
+		ineligible = append(ineligible, j.JobID)
+		if want := []string{"uses Docker commands in step 1"}; !reflect.DeepEqual(j.Reasons, want) {
+			t.Errorf("job %s Reasons = %q, want %q", j.JobID, j.Reasons, want)
+		}
+	}
+	sort.Strings(candidates)
+	sort.Strings(ineligible)
+
+	if want := []string{"lint", "test"}; !reflect.DeepEqual(candidates, want) {
+		t.Errorf("Candidates = %v, want %v", candidates, want)
+	}
+	if want := []string{"build", "image"}; !reflect.DeepEqual(ineligible, want) {
+		t.Errorf("IneligibleJobs = %v, want %v", ineligible, want)
+	}
+}
+
+func TestScan_WorkflowNameAndTriggers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `name: CI
+on: [push, pull_request]
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(result.Candidates) != 1 || len(result.IneligibleJobs) != 1 {
+		t.Fatalf("Scan() = %d candidates, %d ineligible jobs, want 1 and 1", len(result.Candidates), len(result.IneligibleJobs))
+	}
+
+	wantTriggers := []string{"push", "pull_request"}
+	c := result.Candidates[0]
+	if c.WorkflowName != "CI" || !reflect.DeepEqual(c.Triggers, wantTriggers) {
+		t.Errorf("candidate WorkflowName = %q, Triggers = %v, want %q and %v", c.WorkflowName, c.Triggers, "CI", wantTriggers)
+	}
+	j := result.IneligibleJobs[0]
+	if j.WorkflowName != "CI" || !reflect.DeepEqual(j.Triggers, wantTriggers) {
+		t.Errorf("ineligible WorkflowName = %q, Triggers = %v, want %q and %v", j.WorkflowName, j.Triggers, "CI", wantTriggers)
+	}
+}
+
+// TestScan_LineNumbers checks that jobs of every category point at their runs-on line.
+func TestScan_LineNumbers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+
+  image:
+    name: Build image
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+  slim:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make
+  # Signed on macOS
+  mac:
+    runs-on: macos-latest
+    steps:
+      - run: make
+  legacy:
+    if: false
+    runs-on: ubuntu-latest
+    steps:
+      - run: make legacy
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+	if err := os.WriteFile(IgnoreFileName, []byte("ci.yml:legacy\n"), 0644); err != nil {
+		t.Fatalf("Failed to write ignore file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	got := make(map[string]int)
+	for _, c := range result.AllCandidates() {
+		got[c.JobID] = c.LineNumber
+	}
+	for _, j := range result.IneligibleJobs {
+		got[j.JobID] = j.LineNumber
+	}
+	for _, j := range result.AlreadySlimJobs {
+		got[j.JobID] = j.LineNumber
+	}
+	for _, j := range result.OtherOSJobs {
+		got[j.JobID] = j.LineNumber
+	}
+	for _, j := range result.IgnoredJobs {
+		got[j.JobID] = j.LineNumber
+	}
+
+	want := map[string]int{"lint": 4, "image": 10, "slim": 14, "mac": 19, "legacy": 24}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() line numbers = %v, want %v", got, want)
+	}
+}
+
+func TestScanResult_UnjustifiedJobs(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  # slimify-ignore: builds images with Docker
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+  db:
+    runs-on: ubuntu-latest
+    services:
+      postgres:
+        image: postgres
+    steps:
+      - run: make test
+  linux:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: make test
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	justifications := make(map[string]string)
+	for _, j := range result.IneligibleJobs {
+		justifications[j.JobID] = j.Justification
+	}
+	want := map[string]string{"image": "builds images with Docker", "db": "", "linux": ""}
+	if !reflect.DeepEqual(justifications, want) {
+		t.Errorf("Justifications = %v, want %v", justifications, want)
+	}
+
+	// linux doesn't run on ubuntu-latest, so it needs no justification
+	var unjustified []string
+	for _, j := range result.UnjustifiedJobs() {
+		unjustified = append(unjustified, j.JobID)
+	}
+	if want := []string{"db"}; !reflect.DeepEqual(unjustified, want) {
+		t.Errorf("UnjustifiedJobs() = %v, want %v", unjustified, want)
+	}
+}
+
+func TestScanReader(t *testing.T) {
+	// Nothing is written, so the workflow is only read from the reader
+	t.Chdir(t.TempDir())
+
+	content := `name: CI
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    timeout-minutes: 10
+    steps:
+      - run: make lint
+  archive:
+    runs-on: ubuntu-latest
+    steps:
+      - run: zip -r dist.zip dist
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+`
+	result, err := ScanReader(strings.NewReader(content), ".github/workflows/ci.yml", Options{})
+	if err != nil {
+		t.Fatalf("ScanReader() error: %v", err)
+	}
+
+	if len(result.Candidates) != 1 || result.Candidates[0].JobID != "lint" || result.Candidates[0].TimeoutMinutes != 10 {
+		t.Errorf("ScanReader() Candidates = %+v, want lint with a 10 minute timeout", result.Candidates)
+	}
+	if len(result.NeedsSetup) != 1 || result.NeedsSetup[0].JobID != "archive" {
+		t.Errorf("ScanReader() NeedsSetup = %+v, want archive", result.NeedsSetup)
+	}
+	if len(result.IneligibleJobs) != 1 || result.IneligibleJobs[0].JobID != "build" {
+		t.Errorf("ScanReader() IneligibleJobs = %+v, want build", result.IneligibleJobs)
+	}
+	for _, c := range result.AllCandidates() {
+		if c.WorkflowPath != ".github/workflows/ci.yml" || c.WorkflowName != "CI" || c.Duration != "" {
+			t.Errorf("ScanReader() candidate = %+v, want labeled with the given path and no duration", c)
+		}
+	}
+
+	if _, err := ScanReader(strings.NewReader("jobs: ["), "stdin.yml", Options{}); err == nil || !strings.Contains(err.Error(), "stdin.yml") {
+		t.Errorf("ScanReader() error = %v, want a parse error mentioning stdin.yml", err)
+	}
+}
+
+func TestScanReader_DockerStepSkippedOnLinux(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	content := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+      - if: runner.os == 'Windows'
+        run: docker build -t app .
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - if: runner.os == 'Linux'
+        run: docker build -t app .
+`
+	result, err := ScanReader(strings.NewReader(content), "ci.yml", Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("ScanReader() error: %v", err)
+	}
+
+	candidates := result.AllCandidates()
+	if len(candidates) != 1 || candidates[0].JobID != "test" {
+		t.Fatalf("ScanReader() candidates = %+v, want test", candidates)
+	}
+	if got := candidates[0].LinuxSkippedDockerSteps; !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("LinuxSkippedDockerSteps = %v, want [2]", got)
+	}
+	if len(result.IneligibleJobs) != 1 || result.IneligibleJobs[0].JobID != "image" {
+		t.Errorf("ScanReader() IneligibleJobs = %+v, want image", result.IneligibleJobs)
+	}
+}
+
+func TestScan_PinnedUbuntuLabel(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: make lint
+  image:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: docker build -t app .
+  labels:
+    runs-on: [self-hosted, ubuntu-22.04]
+    steps:
+      - run: make test
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	t.Run("not a migration source", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if len(result.AllCandidates()) != 0 {
+			t.Errorf("Scan() candidates = %+v, want none", result.AllCandidates())
+		}
+		pinned := make(map[string]string)
+		for _, j := range result.IneligibleJobs {
+			pinned[j.JobID] = j.PinnedLabel
+		}
+		want := map[string]string{"lint": "ubuntu-22.04", "image": "", "labels": ""}
+		if !reflect.DeepEqual(pinned, want) {
+			t.Errorf("PinnedLabel by job = %v, want %v", pinned, want)
+		}
+	})
+
+	t.Run("migration source", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true, SourceLabels: []string{"ubuntu-latest", "ubuntu-22.04"}})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		candidates := result.AllCandidates()
+		if len(candidates) != 1 || candidates[0].JobID != "lint" || candidates[0].SourceLabel != "ubuntu-22.04" {
+			t.Fatalf("Scan() candidates = %+v, want lint from ubuntu-22.04", candidates)
+		}
+		for _, j := range result.IneligibleJobs {
+			if j.PinnedLabel != "" {
+				t.Errorf("job %s PinnedLabel = %q, want empty when the label is a source", j.JobID, j.PinnedLabel)
+			}
+		}
+	})
+}
+
+func TestScan_SymlinkedWorkflows(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join("ci", "workflows"), 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	content := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`
+	if err := os.WriteFile(filepath.Join("ci", "workflows", "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+	if err := os.Mkdir(".github", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.Symlink(filepath.Join("..", "ci", "workflows"), workflow.DefaultWorkflowDir); err != nil {
+		t.Skipf("Symbolic links not supported: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "symlinked directory and its target", opts: Options{Dirs: []string{filepath.Join("ci", "workflows")}}},
+		{name: "file given by two paths", opts: Options{Paths: []string{".github/workflows/ci.yml", "ci/workflows/ci.yml"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.SkipDuration = true
+			result, err := Scan(tt.opts)
+			if err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+			candidates := result.AllCandidates()
+			if len(candidates) != 1 || filepath.ToSlash(candidates[0].WorkflowPath) != ".github/workflows/ci.yml" {
+				t.Errorf("Scan() candidates = %+v, want lint once in .github/workflows/ci.yml", candidates)
+			}
+		})
+	}
+}
+
+func TestScan_ReusableWorkflows(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	files := map[string]string{
+		"ci.yml": `on: push
+jobs:
+  build:
+    name: Build
+    uses: ./.github/workflows/reusable.yml
+  remote:
+    uses: octo-org/example/.github/workflows/build.yml@v1
+  missing:
+    uses: ./.github/workflows/missing.yml
+`,
+		// Calls back into ci.yml to check that cycles terminate
+		"reusable.yml": `on: workflow_call
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+  loop:
+    uses: ./.github/workflows/ci.yml
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("called workflow not scanned directly", func(t *testing.T) {
+		result, err := Scan(Options{Paths: []string{".github/workflows/ci.yml"}, SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+
+		if len(result.Candidates) != 1 {
+			t.Fatalf("Scan() returned %d candidates, want 1", len(result.Candidates))
+		}
+		got := result.Candidates[0]
+		if got.JobID != "test" || got.JobName != "Build / test" || filepath.ToSlash(got.WorkflowPath) != ".github/workflows/reusable.yml" {
+			t.Errorf("Scan() candidate = %+v, want job test of reusable.yml named \"Build / test\"", got)
+		}
+		if got.Caller == nil || got.Caller.String() != ".github/workflows/ci.yml:build" {
+			t.Errorf("Scan() candidate caller = %v, want .github/workflows/ci.yml:build", got.Caller)
+		}
+
+		reasons := make(map[string][]string)
+		for _, job := range result.IneligibleJobs {
+			reasons[job.JobID] = job.Reasons
+		}
+		want := map[string][]string{
+			"remote":  {"unresolvable remote reusable workflow (octo-org/example/.github/workflows/build.yml@v1)"},
+			"missing": {"reusable workflow not found (./.github/workflows/missing.yml)"},
+			"loop":    {"reusable workflow call cycle (./.github/workflows/ci.yml)"},
+		}
+		if !reflect.DeepEqual(reasons, want) {
+			t.Errorf("Scan() ineligible reasons = %v, want %v", reasons, want)
+		}
+	})
+
+	t.Run("called workflow scanned directly", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+
+		// The job is reported once, through its caller rather than on its own
+		if len(result.Candidates) != 1 {
+			t.Fatalf("Scan() returned %d candidates, want 1", len(result.Candidates))
+		}
+		if got := result.Candidates[0]; got.JobName != "Build / test" || got.Caller == nil || got.Caller.String() != ".github/workflows/ci.yml:build" {
+			t.Errorf("Scan() candidate = %+v, want Build / test called by .github/workflows/ci.yml:build", got)
+		}
+	})
+}
+
+// TestScan_ReusableWorkflowCallers checks that a reusable workflow in the
+// scanned directory is reported through every job calling it.
+func TestScan_ReusableWorkflowCallers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	files := map[string]string{
+		"ci.yml": `on: push
+jobs:
+  build:
+    name: Build
+    uses: ./.github/workflows/shared.yml
+`,
+		"release.yml": `on:
+  push:
+    tags: ['v*']
+jobs:
+  publish:
+    name: Publish
+    uses: ./.github/workflows/shared.yml
+`,
+		"shared.yml": `on: workflow_call
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	var got []string
+	for _, c := range result.Candidates {
+		if filepath.ToSlash(c.WorkflowPath) != ".github/workflows/shared.yml" {
+			t.Errorf("Scan() candidate %q in %s, want shared.yml", c.JobName, c.WorkflowPath)
+		}
+		if c.Caller == nil {
+			t.Fatalf("Scan() candidate %q has no caller", c.JobName)
+		}
+		got = append(got, c.JobName+" <- "+c.Caller.String())
+	}
+	sort.Strings(got)
+	want := []string{
+		"Build / test <- .github/workflows/ci.yml:build",
+		"Publish / test <- .github/workflows/release.yml:publish",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() candidates = %v, want %v", got, want)
+	}
+	if len(result.IneligibleJobs) != 0 {
+		t.Errorf("Scan() ineligible jobs = %+v, want none", result.IneligibleJobs)
+	}
+}
+
+// TestScan_YamlExtension checks that .yaml workflows are discovered, matched by
+// paths and globs, followed as reusable workflows and updated by fix exactly
+// like .yml ones.
+func TestScan_YamlExtension(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	files := map[string]string{
+		"ci.yaml": `name: CI
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "hello"
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build -t app .
+  call:
+    uses: ./.github/workflows/reusable.yaml
+`,
+		"reusable.yaml": `on: workflow_call
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	candidateJobs := func(result *ScanResult) []string {
+		var jobs []string
+		for _, c := range result.Candidates {
+			jobs = append(jobs, filepath.Base(c.WorkflowPath)+":"+c.JobID)
+		}
+		sort.Strings(jobs)
+		return jobs
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name: "all workflows",
+			want: []string{"ci.yaml:test", "reusable.yaml:lint"},
+		},
+		{
+			name:  "explicit path follows reusable workflow",
+			paths: []string{".github/workflows/ci.yaml"},
+			want:  []string{"ci.yaml:test", "reusable.yaml:lint"},
+		},
+		{
+			name:  "glob",
+			paths: []string{".github/workflows/c*.yaml"},
+			want:  []string{"ci.yaml:test", "reusable.yaml:lint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Scan(Options{Paths: tt.paths, SkipDuration: true})
+			if err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+			if got := candidateJobs(result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() candidates = %v, want %v", got, tt.want)
+			}
+			if len(result.IneligibleJobs) != 1 || result.IneligibleJobs[0].JobID != "build" {
+				t.Errorf("Scan() ineligible jobs = %+v, want build", result.IneligibleJobs)
+			}
+		})
+	}
+
+	t.Run("fix", func(t *testing.T) {
+		result, err := Scan(Options{Paths: []string{".github/workflows/ci.yaml"}, SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		for _, c := range result.Candidates {
+			if err := workflow.UpdateRunsOnAtLine(c.WorkflowPath, c.LineNumber, c.SourceLabel, "ubuntu-slim"); err != nil {
+				t.Fatalf("UpdateRunsOnAtLine(%s) error: %v", c.WorkflowPath, err)
+			}
+		}
+
+		result, err = Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if got := candidateJobs(result); len(got) != 0 {
+			t.Errorf("Scan() after fix candidates = %v, want none", got)
+		}
+		if len(result.AlreadySlimJobs) != 2 {
+			t.Errorf("Scan() after fix already slim jobs = %d, want 2", len(result.AlreadySlimJobs))
+		}
+	})
+}
+
+func TestScan_SetupActions(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	content := `on: push
+jobs:
+  plain:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+  node:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+      - run: npm test
+`
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	// Setup actions are informational and don't affect eligibility
+	if len(result.Candidates) != 2 {
+		t.Fatalf("Scan() returned %d candidates, want 2", len(result.Candidates))
+	}
+	jobs := result.UsingSetupActions()
+	if len(jobs) != 1 || jobs[0].JobID != "node" {
+		t.Fatalf("UsingSetupActions() = %+v, want only node", jobs)
+	}
+	if got := jobs[0].SetupActions; !reflect.DeepEqual(got, []string{"actions/setup-node"}) {
+		t.Errorf("SetupActions = %v, want [actions/setup-node]", got)
+	}
+}
+
+func TestScan_DeterministicOrder(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	var jobs strings.Builder
+	for i := range 10 {
+		fmt.Fprintf(&jobs, "  job%d:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo %d\n", i, i)
+	}
+	for _, name := range []string{"c.yml", "a.yml", "b.yaml"} {
+		content := "on: push\njobs:\n" + jobs.String()
+		if err := os.WriteFile(filepath.Join(workflowDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		result, err := Scan(Options{SkipDuration: true, Concurrency: concurrency})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if len(result.Candidates) != 30 {
+			t.Fatalf("Scan() returned %d candidates, want 30", len(result.Candidates))
+		}
+
+		for i := 1; i < len(result.Candidates); i++ {
+			prev, cur := result.Candidates[i-1], result.Candidates[i]
+			if prev.WorkflowPath > cur.WorkflowPath ||
+				(prev.WorkflowPath == cur.WorkflowPath && prev.LineNumber >= cur.LineNumber) {
+				t.Errorf("concurrency %d: candidates not sorted: %s:%d before %s:%d",
+					concurrency, prev.WorkflowPath, prev.LineNumber, cur.WorkflowPath, cur.LineNumber)
+			}
+		}
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	b.Chdir(b.TempDir())
+
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		b.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	var jobs strings.Builder
+	for i := range 20 {
+		fmt.Fprintf(&jobs, `  job%d:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: |
+          npm ci
+          npm test
+          curl -sSL https://example.com | jq .
+`, i)
+	}
+	content := "name: CI\non: push\njobs:\n" + jobs.String()
+	for i := range 300 {
+		path := filepath.Join(workflowDir, fmt.Sprintf("workflow%03d.yml", i))
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			b.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	for _, concurrency := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := Scan(Options{SkipDuration: true, Concurrency: concurrency}); err != nil {
+					b.Fatalf("Scan() error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestIsEligible_AlreadySlim(t *testing.T) {
+	tests := []struct {
+		name             string
+		job              *workflow.Job
+		expectedSlim     bool
+		expectedEligible bool
+	}{
+		{
+			name: "already using ubuntu-slim",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-slim",
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expectedSlim:     true,
+			expectedEligible: false,
+		},
+		{
+			name: "ubuntu-slim in matrix",
+			job: &workflow.Job{
+				RunsOn: []interface{}{"ubuntu-slim"},
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expectedSlim:     true,
+			expectedEligible: false,
+		},
+		{
+			name: "ubuntu-latest - eligible",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps:  []workflow.Step{{Run: "echo hello"}},
+			},
+			expectedSlim:     false,
+			expectedEligible: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSlim := tt.job.IsUbuntuSlim()
+			if gotSlim != tt.expectedSlim {
+				t.Errorf("IsUbuntuSlim() = %v, want %v", gotSlim, tt.expectedSlim)
+			}
+
+			if !gotSlim {
+				gotEligible, _ := IsEligible(tt.job)
+				if gotEligible != tt.expectedEligible {
+					t.Errorf("IsEligible() = %v, want %v", gotEligible, tt.expectedEligible)
+				}
+			}
+		})
+	}
+}
+
+func TestScan_AlreadySlimJobs(t *testing.T) {
+	// Create a temporary directory structure
+	tmpDir := t.TempDir()
+	workflowDir := filepath.Join(tmpDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+
+	// Save original working directory
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	// Change to temporary directory
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer func() {
+		os.Chdir(originalWd)
+	}()
+
+	// Create a workflow with an already-slim job
+	workflowContent := `name: test
+on: push
+jobs:
+  already-slim:
+    runs-on: ubuntu-slim
+    steps:
+      - run: echo "already slim"
+  eligible:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "can migrate"
+  ineligible:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: echo "cannot migrate"`
+
+	workflowPath := filepath.Join(workflowDir, "test.yml")
+	if err := os.WriteFile(workflowPath, []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() returned error: %v", err)
+	}
+
+	if result == nil {
+		t.Fatal("Scan() returned nil result")
+	}
+
+	// Check candidates (should have 1 eligible job)
+	if len(result.Candidates) != 1 {
+		t.Errorf("Expected 1 candidate, got %d", len(result.Candidates))
+	}
+	if len(result.Candidates) > 0 && result.Candidates[0].JobID != "eligible" {
+		t.Errorf("Expected eligible job, got %s", result.Candidates[0].JobID)
+	}
+
+	// Check ineligible jobs (should have 1 ineligible job)
+	if len(result.IneligibleJobs) != 1 {
+		t.Errorf("Expected 1 ineligible job, got %d", len(result.IneligibleJobs))
+	}
+	if len(result.IneligibleJobs) > 0 && result.IneligibleJobs[0].JobID != "ineligible" {
+		t.Errorf("Expected ineligible job, got %s", result.IneligibleJobs[0].JobID)
+	}
+
+	// Check already slim jobs (should have 1 already-slim job)
+	if len(result.AlreadySlimJobs) != 1 {
+		t.Errorf("Expected 1 already-slim job, got %d", len(result.AlreadySlimJobs))
+	}
+	if len(result.AlreadySlimJobs) > 0 && result.AlreadySlimJobs[0].JobID != "already-slim" {
+		t.Errorf("Expected already-slim job, got %s", result.AlreadySlimJobs[0].JobID)
+	}
+}
+
+func TestScan_SlimLabel(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  test:
+    runs-on: self-hosted-slim
+    steps:
+      - run: docker compose up -d
+  legacy:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make legacy
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true, SlimLabel: "self-hosted-slim"})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(result.AlreadySlimJobs) != 1 || result.AlreadySlimJobs[0].JobID != "test" {
+		t.Fatalf("Scan() AlreadySlimJobs = %+v, want test", result.AlreadySlimJobs)
+	}
+	if regressions := result.SlimRegressions(); len(regressions) != 1 {
+		t.Errorf("Scan() SlimRegressions() = %+v, want test using Docker on self-hosted-slim", regressions)
+	}
+	if len(result.Candidates) != 1 || result.Candidates[0].JobID != "lint" {
+		t.Errorf("Scan() Candidates = %+v, want lint", result.Candidates)
+	}
+	if len(result.IneligibleJobs) != 1 || result.IneligibleJobs[0].JobID != "legacy" {
+		t.Errorf("Scan() IneligibleJobs = %+v, want legacy on ubuntu-slim, which is not the slim label", result.IneligibleJobs)
+	}
+}
+
+func TestScan_RunsOnVariables(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  lint:
+    runs-on: ${{ vars.LINUX_RUNNER }}
+    steps:
+      - run: make lint
+  build:
+    runs-on: ${{ env.BUILD_RUNNER }}
+    steps:
+      - run: make build
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	t.Run("unresolved", func(t *testing.T) {
+		result, err := Scan(Options{SkipDuration: true})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if len(result.AllCandidates()) != 0 || len(result.IneligibleJobs) != 0 {
+			t.Errorf("Scan() classified jobs with unresolved runs-on: candidates %+v, ineligible %+v", result.AllCandidates(), result.IneligibleJobs)
+		}
+		var got []string
+		for _, job := range result.UnresolvedRunsOnJobs {
+			got = append(got, job.JobID+":"+strings.Join(job.Variables, ","))
+		}
+		want := []string{"lint:vars.LINUX_RUNNER", "build:env.BUILD_RUNNER"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan() UnresolvedRunsOnJobs = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("substituted", func(t *testing.T) {
+		resolveVars := map[string]string{"LINUX_RUNNER": "ubuntu-latest", "BUILD_RUNNER": "windows-latest"}
+		result, err := Scan(Options{SkipDuration: true, ResolveVars: resolveVars})
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if len(result.UnresolvedRunsOnJobs) != 0 {
+			t.Errorf("Scan() UnresolvedRunsOnJobs = %+v, want none", result.UnresolvedRunsOnJobs)
+		}
+		if len(result.Candidates) != 1 || result.Candidates[0].JobID != "lint" {
+			t.Fatalf("Scan() Candidates = %+v, want lint", result.Candidates)
+		}
+		c := result.Candidates[0]
+		if c.SourceLabel != "ubuntu-latest" || !reflect.DeepEqual(c.RunsOnVariables, []string{"vars.LINUX_RUNNER"}) {
+			t.Errorf("Scan() candidate SourceLabel = %q, RunsOnVariables = %v, want ubuntu-latest from vars.LINUX_RUNNER", c.SourceLabel, c.RunsOnVariables)
+		}
+		if len(result.OtherOSJobs) != 1 || result.OtherOSJobs[0].JobID != "build" {
+			t.Errorf("Scan() OtherOSJobs = %+v, want build", result.OtherOSJobs)
+		}
+	})
+}
+
+func TestClassifier_ResolveRunsOn(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            string // Workflow-level env: block
+		job            string // Job block below jobs:
+		resolveVars    map[string]string
+		wantLabels     []string
+		wantUnresolved []string
+	}{
+		{
+			name:       "literal label",
+			job:        "runs-on: ubuntu-latest",
+			wantLabels: []string{"ubuntu-latest"},
+		},
+		{
+			name:       "workflow env",
+			env:        "env:\n  RUNNER: ubuntu-latest",
+			job:        "runs-on: ${{ env.RUNNER }}",
+			wantLabels: []string{"ubuntu-latest"},
+		},
+		{
+			name:        "workflow env takes precedence over --resolve-var",
+			env:         "env:\n  RUNNER: ubuntu-latest",
+			job:         "runs-on: ${{ env.RUNNER }}",
+			resolveVars: map[string]string{"RUNNER": "windows-latest"},
+			wantLabels:  []string{"ubuntu-latest"},
+		},
+		{
+			name:           "workflow env set by an expression",
+			env:            "env:\n  RUNNER: ${{ vars.RUNNER }}",
+			job:            "runs-on: ${{ env.RUNNER }}",
+			resolveVars:    map[string]string{"RUNNER": "ubuntu-latest"},
+			wantUnresolved: []string{"env.RUNNER"},
+		},
+		{
+			name:           "job env is not available to runs-on",
+			job:            "runs-on: ${{ env.RUNNER }}\n    env:\n      RUNNER: ubuntu-latest",
+			wantUnresolved: []string{"env.RUNNER"},
+		},
+		{
+			name:        "env not in the workflow with --resolve-var",
+			job:         "runs-on: ${{ env.RUNNER }}",
+			resolveVars: map[string]string{"RUNNER": "ubuntu-latest"},
+			wantLabels:  []string{"ubuntu-latest"},
+		},
+		{
+			name:        "configuration variable with --resolve-var",
+			job:         "runs-on: [self-hosted, '${{ vars.RUNNER }}']",
+			resolveVars: map[string]string{"RUNNER": "linux"},
+			wantLabels:  []string{"self-hosted", "linux"},
+		},
+		{
+			name:           "configuration variable",
+			env:            "env:\n  RUNNER: ubuntu-latest",
+			job:            "runs-on: ${{ vars.RUNNER }}",
+			wantUnresolved: []string{"vars.RUNNER"},
+		},
+		{
+			name:           "secret",
+			job:            "runs-on: ${{ secrets.RUNNER }}",
+			resolveVars:    map[string]string{"RUNNER": "ubuntu-latest"},
+			wantUnresolved: []string{"secrets.RUNNER"},
+		},
+		{
+			name:           "github context",
+			job:            "runs-on: ${{ github.event.inputs.runner }}",
+			wantUnresolved: []string{"github.event.inputs.runner"},
+		},
+		{
+			name:       "literal matrix",
+			job:        "runs-on: ${{ matrix.os }}\n    strategy:\n      matrix:\n        os: [ubuntu-latest, ubuntu-22.04]",
+			wantLabels: []string{"ubuntu-latest", "ubuntu-22.04"},
+		},
+		{
+			name:       "matrix of workflow env",
+			env:        "env:\n  RUNNER: ubuntu-24.04",
+			job:        "runs-on: ${{ matrix.os }}\n    strategy:\n      matrix:\n        os: [ubuntu-latest, '${{ env.RUNNER }}']",
+			wantLabels: []string{"ubuntu-latest", "ubuntu-24.04"},
+		},
+		{
+			name:           "matrix include of a secret",
+			job:            "runs-on: ${{ matrix.os }}\n    strategy:\n      matrix:\n        os: [ubuntu-latest]\n        include:\n          - os: ${{ secrets.RUNNER }}",
+			wantUnresolved: []string{"secrets.RUNNER"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "on: push\n" + tt.env + "\njobs:\n  test:\n    " + tt.job + "\n    steps:\n      - run: make\n"
+			wf, err := workflow.Parse(strings.NewReader(content), "ci.yml")
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			job := wf.Jobs["test"]
+			before := job.RunsOnLabels()
+
+			c := &classifier{resolveVars: tt.resolveVars}
+			resolved, unresolved := c.resolveRunsOn(job, wf)
+			if !reflect.DeepEqual(unresolved, tt.wantUnresolved) {
+				t.Errorf("resolveRunsOn() unresolved = %v, want %v", unresolved, tt.wantUnresolved)
+			}
+			if got := resolved.ResolvedRunsOnLabels(); !reflect.DeepEqual(got, tt.wantLabels) {
+				t.Errorf("resolveRunsOn() labels = %v, want %v", got, tt.wantLabels)
+			}
+			if after := job.RunsOnLabels(); !reflect.DeepEqual(after, before) {
+				t.Errorf("resolveRunsOn() modified the job: runs-on %v, was %v", after, before)
+			}
+		})
+	}
+}
+
+func TestScan_SlimRegressions(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-slim
+    steps:
+      - run: make lint
+  image:
+    runs-on: ubuntu-slim
+    steps:
+      - uses: actions/checkout@v4
+      - run: docker build -t app .
+  disabled:
+    if: false
+    runs-on: ubuntu-slim
+    services:
+      redis:
+        image: redis
+    steps:
+      - run: echo disabled
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	result, err := Scan(Options{SkipDuration: true})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if len(result.AlreadySlimJobs) != 3 {
+		t.Errorf("Scan() returned %d already-slim jobs, want 3", len(result.AlreadySlimJobs))
+	}
+	regressions := result.SlimRegressions()
+	if len(regressions) != 1 || regressions[0].JobID != "image" {
+		t.Fatalf("SlimRegressions() = %+v, want only image", regressions)
+	}
+	if want := []string{"uses Docker commands in step 2"}; !reflect.DeepEqual(regressions[0].Reasons, want) {
+		t.Errorf("SlimRegressions() reasons = %v, want %v", regressions[0].Reasons, want)
+	}
+}
+
+func TestScan_DebugLog(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	workflowContent := `on: push
+jobs:
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+`
+	workflowDir := filepath.Join(".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflow directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(defaultLogger)
+
+	if _, err := Scan(Options{SkipDuration: true}); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	for _, want := range []string{
+		`msg="criterion failed" workflow=.github/workflows/ci.yml job=image criterion="no Docker commands" reason="uses Docker commands in step 1"`,
+		`msg="job classified" workflow=.github/workflows/ci.yml job=image category=ineligible`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("debug log does not contain %s:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCheckEligibility_ReasonDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         *workflow.Job
+		wantReasons []string
+	}{
+		{
+			name:        "runs-on not set",
+			job:         &workflow.Job{},
+			wantReasons: []string{"runs-on is not set, not ubuntu-latest"},
+		},
+		{
+			name:        "runs-on label list",
+			job:         &workflow.Job{RunsOn: []any{"self-hosted", "linux"}},
+			wantReasons: []string{"runs-on is [self-hosted, linux], not ubuntu-latest"},
+		},
+		{
+			name:        "runs-on label list with ubuntu-latest",
+			job:         &workflow.Job{RunsOn: []any{"self-hosted", "ubuntu-latest"}},
+			wantReasons: []string{"runs-on is [self-hosted, ubuntu-latest], which needs a runner with all of these labels, not ubuntu-latest"},
+		},
+		{
+			name:        "runs-on runner group",
+			job:         &workflow.Job{RunsOn: map[string]any{"group": "large"}},
+			wantReasons: []string{"runs-on is runner group large, not ubuntu-latest"},
+		},
+		{
+			name:        "runs-on runner group with labels",
+			job:         &workflow.Job{RunsOn: map[string]any{"group": "large", "labels": []any{"ubuntu-22.04"}}},
+			wantReasons: []string{"runs-on is runner group large with labels [ubuntu-22.04], not ubuntu-latest"},
+		},
+		{
+			name: "docker command and container action steps",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []workflow.Step{
+					{Uses: "actions/checkout@v4"},
+					{Run: "docker build ."},
+					{Uses: "docker/login-action@v3"},
+					{Run: "docker push app"},
+				},
+			},
+			wantReasons: []string{
+				"uses Docker commands in steps 2, 4",
+				"uses container-based GitHub Actions in step 3",
+			},
+		},
+		{
+			name: "docker command in bash -c and a command substitution",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []workflow.Step{
+					{Run: `bash -c "docker run x"`},
+					{Run: "make test"},
+					{Run: `echo "containers: $(docker ps -q)"`},
+				},
+			},
+			wantReasons: []string{"uses Docker commands in steps 1, 3"},
+		},
+		{
+			name: "services and container",
+			job: &workflow.Job{
+				RunsOn:    "ubuntu-latest",
+				Services:  map[string]any{"postgres": map[string]any{"image": "postgres:16"}},
+				Container: "node:20",
+			},
+			wantReasons: []string{
+				"requires services: postgres",
+				"uses container syntax (node:20)",
+			},
+		},
+		{
+			name: "docker-dependent actions",
+			job: &workflow.Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []workflow.Step{
+					{Uses: "aquasecurity/trivy-action@0.28.0"},
+					{Uses: "hadolint/hadolint-action@v3.1.0"},
+				},
+			},
+			wantReasons: []string{"uses docker-dependent action: aquasecurity/trivy-action, hadolint/hadolint-action"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEligible, reasons := checkEligibility(tt.job, DefaultSourceLabels, workflow.DefaultDockerDependentActions, nil, nil)
+			if gotEligible {
+				t.Fatal("checkEligibility() eligible = true, want false")
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("checkEligibility() reasons = %q, want %q", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestCheckEligibility_SourceLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		job          *workflow.Job
+		sourceLabels []string
+		wantEligible bool
+		wantReason   string
+	}{
+		{
+			name:         "ubuntu-latest with default labels",
+			job:          &workflow.Job{RunsOn: "ubuntu-latest"},
+			sourceLabels: DefaultSourceLabels,
+			wantEligible: true,
+		},
+		{
+			name:         "ubuntu-24.04 with default labels",
+			job:          &workflow.Job{RunsOn: "ubuntu-24.04"},
+			sourceLabels: DefaultSourceLabels,
+			wantEligible: false,
+			wantReason:   "runs-on is ubuntu-24.04, not ubuntu-latest",
+		},
+		{
+			name:         "ubuntu-24.04 opted in",
+			job:          &workflow.Job{RunsOn: "ubuntu-24.04"},
+			sourceLabels: []string{"ubuntu-latest", "ubuntu-24.04"},
+			wantEligible: true,
+		},
+		{
+			name:         "ubuntu-latest not in custom labels",
+			job:          &workflow.Job{RunsOn: "ubuntu-latest"},
+			sourceLabels: []string{"ubuntu-22.04"},
+			wantEligible: false,
+			wantReason:   "runs-on is ubuntu-latest, not ubuntu-22.04",
+		},
+		{
+			name:         "already slim is never a source",
+			job:          &workflow.Job{RunsOn: "ubuntu-slim"},
+			sourceLabels: []string{"ubuntu-latest", "ubuntu-22.04"},
+			wantEligible: false,
+			wantReason:   "runs-on is ubuntu-slim, not ubuntu-latest or ubuntu-22.04",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEligible, reasons := checkEligibility(tt.job, tt.sourceLabels, nil, nil, nil)
+			if gotEligible != tt.wantEligible {
+				t.Errorf("checkEligibility() eligible = %v, want %v", gotEligible, tt.wantEligible)
+			}
+			if tt.wantReason != "" && (len(reasons) == 0 || reasons[0] != tt.wantReason) {
+				t.Errorf("checkEligibility() reasons = %v, want %q", reasons, tt.wantReason)
+			}
+		})
 	}
 }
@@ -0,0 +1,56 @@
+package scan
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sinceUnits are the units ParseSince accepts besides those of time.ParseDuration.
+var sinceUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseSince parses a recency window such as 90d, 2w or 12h. Days (d) and
+// weeks (w) are accepted besides the units of time.ParseDuration.
+func ParseSince(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range sinceUnits {
+		number, ok := strings.CutSuffix(s, suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window %q: expected a positive number of days or weeks (e.g. 90d, 2w)", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q: expected a positive duration (e.g. 90d, 2w, 12h)", s)
+	}
+	return d, nil
+}
+
+// SeparateStale moves the candidates that last ran before cutoff from
+// Candidates and NeedsSetup to StaleJobs, and returns how many were moved.
+// Candidates whose last run is unknown are kept, since there is no evidence
+// that they stopped running.
+func (r *ScanResult) SeparateStale(cutoff time.Time) int {
+	stale := func(c *Candidate) bool {
+		if !c.LastRun.IsZero() && c.LastRun.Before(cutoff) {
+			r.StaleJobs = append(r.StaleJobs, c)
+			return true
+		}
+		return false
+	}
+	before := len(r.StaleJobs)
+	r.Candidates = slices.DeleteFunc(r.Candidates, stale)
+	r.NeedsSetup = slices.DeleteFunc(r.NeedsSetup, stale)
+	sortJobs(r.StaleJobs, func(c *Candidate) (string, int, string) { return c.WorkflowPath, c.LineNumber, c.JobID })
+	return len(r.StaleJobs) - before
+}
@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30d", want: 30 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "12h", want: 12 * time.Hour},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "0d", wantErr: true},
+		{in: "-3d", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "90", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "3mo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSince(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSince(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanResult_SeparateStale(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	result := &ScanResult{
+		Candidates: []*Candidate{
+			{JobID: "recent", LastRun: now.Add(-24 * time.Hour)},
+			{JobID: "old", LastRun: now.Add(-100 * 24 * time.Hour)},
+			{JobID: "unknown"},
+		},
+		NeedsSetup: []*Candidate{
+			{JobID: "old-setup", LastRun: now.Add(-200 * 24 * time.Hour), MissingCommands: []string{"zip"}},
+		},
+	}
+
+	if moved := result.SeparateStale(now.Add(-90 * 24 * time.Hour)); moved != 2 {
+		t.Errorf("SeparateStale() = %d, want 2", moved)
+	}
+
+	jobIDs := func(candidates []*Candidate) []string {
+		var ids []string
+		for _, c := range candidates {
+			ids = append(ids, c.JobID)
+		}
+		return ids
+	}
+	if got := jobIDs(result.Candidates); len(got) != 2 || got[0] != "recent" || got[1] != "unknown" {
+		t.Errorf("Candidates = %v, want [recent unknown]", got)
+	}
+	if len(result.NeedsSetup) != 0 {
+		t.Errorf("NeedsSetup = %v, want none", jobIDs(result.NeedsSetup))
+	}
+	if got := jobIDs(result.StaleJobs); len(got) != 2 || got[0] != "old" || got[1] != "old-setup" {
+		t.Errorf("StaleJobs = %v, want [old old-setup]", got)
+	}
+}
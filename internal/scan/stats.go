@@ -0,0 +1,109 @@
+package scan
+
+import "strings"
+
+// Reason categories used to break down ineligible jobs in Stats.
+const (
+	ReasonDisabled             = "disabled"
+	ReasonRunsOn               = "runs_on"
+	ReasonDockerCommands       = "docker_commands"
+	ReasonLocalCluster         = "local_kubernetes_cluster"
+	ReasonContainerActions     = "container_actions"
+	ReasonCrossArchBuild       = "cross_arch_build"
+	ReasonDockerActions        = "docker_dependent_actions"
+	ReasonLocalActions         = "local_docker_actions"
+	ReasonServices             = "services"
+	ReasonContainer            = "container"
+	ReasonPrivilegedOperations = "privileged_operations"
+	ReasonReusableWorkflow     = "reusable_workflow"
+	ReasonOther                = "other"
+)
+
+// reasonCategories maps the prefix of each reason produced by checkEligibility
+// to its category. Keep in sync when adding a criterion.
+var reasonCategories = []struct {
+	prefix   string
+	category string
+}{
+	{"is disabled", ReasonDisabled},
+	{"runs-on", ReasonRunsOn},
+	{"uses Docker commands", ReasonDockerCommands},
+	{"creates a local Kubernetes cluster", ReasonLocalCluster},
+	{"uses container-based GitHub Actions", ReasonContainerActions},
+	{"sets up a cross-architecture build", ReasonCrossArchBuild},
+	{"uses docker-dependent action", ReasonDockerActions},
+	{"local composite action uses docker", ReasonLocalActions},
+	{"uses service containers", ReasonServices},
+	{"requires services", ReasonServices},
+	{"uses container syntax", ReasonContainer},
+	{"uses privileged operation", ReasonPrivilegedOperations},
+	{"unresolvable remote reusable workflow", ReasonReusableWorkflow},
+	{"reusable workflow", ReasonReusableWorkflow},
+}
+
+// Stats is an aggregate view of a scan, used to track migration progress over time.
+type Stats struct {
+	TotalJobs   int // Every job found, including ignored jobs
+	Eligible    int // Jobs that can be migrated, including NeedsSetup
+	NeedsSetup  int // Eligible jobs that use commands missing in ubuntu-slim
+	Ineligible  int
+	AlreadySlim int
+	Ignored     int
+	OtherOS     int // Jobs on Windows or macOS, to which ubuntu-slim is not applicable
+	// UnresolvedRunsOn counts jobs whose runs-on uses a variable with no known value
+	UnresolvedRunsOn int
+	// IneligibleByReason counts ineligible jobs per reason category (e.g. ReasonServices).
+	// A job blocked for several reasons is counted once in each category.
+	IneligibleByReason map[string]int
+	// MigratedPercent is the share of jobs already using ubuntu-slim, out of all
+	// jobs that are not ignored, on another OS or on an unresolved runner. It is
+	// 0 if there are no such jobs.
+	MigratedPercent float64
+}
+
+// Summarize aggregates a scan result into Stats.
+func Summarize(result *ScanResult) Stats {
+	stats := Stats{
+		Eligible:           len(result.Candidates) + len(result.NeedsSetup),
+		NeedsSetup:         len(result.NeedsSetup),
+		Ineligible:         len(result.IneligibleJobs),
+		AlreadySlim:        len(result.AlreadySlimJobs),
+		Ignored:            len(result.IgnoredJobs),
+		OtherOS:            len(result.OtherOSJobs),
+		UnresolvedRunsOn:   len(result.UnresolvedRunsOnJobs),
+		IneligibleByReason: make(map[string]int),
+	}
+	stats.TotalJobs = stats.Eligible + stats.Ineligible + stats.AlreadySlim + stats.Ignored + stats.OtherOS + stats.UnresolvedRunsOn
+
+	for _, job := range result.IneligibleJobs {
+		for category := range job.ReasonsByCategory() {
+			stats.IneligibleByReason[category]++
+		}
+	}
+
+	if considered := stats.TotalJobs - stats.Ignored - stats.OtherOS - stats.UnresolvedRunsOn; considered > 0 {
+		stats.MigratedPercent = float64(stats.AlreadySlim) / float64(considered) * 100
+	}
+	return stats
+}
+
+// ReasonsByCategory groups the job's reasons by reason category (e.g.
+// ReasonServices), keeping their order within each category.
+func (j *IneligibleJob) ReasonsByCategory() map[string][]string {
+	categories := make(map[string][]string)
+	for _, reason := range j.Reasons {
+		category := reasonCategory(reason)
+		categories[category] = append(categories[category], reason)
+	}
+	return categories
+}
+
+// reasonCategory returns the category of a reason produced by checkEligibility.
+func reasonCategory(reason string) string {
+	for _, rc := range reasonCategories {
+		if strings.HasPrefix(reason, rc.prefix) {
+			return rc.category
+		}
+	}
+	return ReasonOther
+}
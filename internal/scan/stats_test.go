@@ -0,0 +1,124 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fchimpan/gh-slimify/internal/workflow"
+)
+
+func TestSummarize(t *testing.T) {
+	result := &ScanResult{
+		Candidates: []*Candidate{{JobID: "lint"}, {JobID: "test"}},
+		NeedsSetup: []*Candidate{{JobID: "archive", MissingCommands: []string{"zip"}}},
+		IneligibleJobs: []*IneligibleJob{
+			{JobID: "build", Reasons: []string{"uses Docker commands in step 2", "requires services: postgres"}},
+			{JobID: "e2e", Reasons: []string{"requires services: redis", "creates a local Kubernetes cluster, which needs a Docker daemon: kind"}},
+			{JobID: "pinned", Reasons: []string{"runs-on is ubuntu-22.04, not ubuntu-latest"}},
+		},
+		AlreadySlimJobs: []*AlreadySlimJob{{JobID: "fmt"}, {JobID: "vet"}},
+		IgnoredJobs:     []*IgnoredJob{{JobID: "release"}},
+		OtherOSJobs:     []*OtherOSJob{{JobID: "windows", OS: []string{"Windows"}}},
+	}
+
+	got := Summarize(result)
+	want := Stats{
+		TotalJobs:   10,
+		Eligible:    3,
+		NeedsSetup:  1,
+		Ineligible:  3,
+		AlreadySlim: 2,
+		Ignored:     1,
+		OtherOS:     1,
+		IneligibleByReason: map[string]int{
+			ReasonDockerCommands: 1,
+			ReasonLocalCluster:   1,
+			ReasonServices:       2,
+			ReasonRunsOn:         1,
+		},
+		MigratedPercent: 25,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	got := Summarize(&ScanResult{})
+	if got.TotalJobs != 0 || got.MigratedPercent != 0 || len(got.IneligibleByReason) != 0 {
+		t.Errorf("Summarize() = %+v, want zero stats", got)
+	}
+}
+
+// TestReasonCategory checks that every reason checkEligibility produces maps to a
+// known category, so new criteria are not silently counted as ReasonOther.
+func TestReasonCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *workflow.Job
+		want string
+	}{
+		{
+			name: "disabled",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", If: false},
+			want: ReasonDisabled,
+		},
+		{
+			name: "runs-on",
+			job:  &workflow.Job{RunsOn: "macos-latest"},
+			want: ReasonRunsOn,
+		},
+		{
+			name: "docker commands",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Run: "docker build ."}}},
+			want: ReasonDockerCommands,
+		},
+		{
+			name: "local Kubernetes cluster",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Run: "kind create cluster"}}},
+			want: ReasonLocalCluster,
+		},
+		{
+			name: "container actions",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Uses: "docker/build-push-action@v5"}}},
+			want: ReasonContainerActions,
+		},
+		{
+			name: "cross-architecture build",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Uses: "docker/setup-qemu-action@v3"}}},
+			want: ReasonCrossArchBuild,
+		},
+		{
+			name: "docker-dependent actions",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Uses: "aquasecurity/trivy-action@0.28.0"}}},
+			want: ReasonDockerActions,
+		},
+		{
+			name: "services",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Services: map[string]any{"redis": map[string]any{"image": "redis"}}},
+			want: ReasonServices,
+		},
+		{
+			name: "container",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Container: "node:20"},
+			want: ReasonContainer,
+		},
+		{
+			name: "privileged operations",
+			job:  &workflow.Job{RunsOn: "ubuntu-latest", Steps: []workflow.Step{{Run: "sudo sysctl -w vm.max_map_count=262144"}}},
+			want: ReasonPrivilegedOperations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eligible, reasons := checkEligibility(tt.job, DefaultSourceLabels, workflow.DefaultDockerDependentActions, nil, nil)
+			if eligible || len(reasons) != 1 {
+				t.Fatalf("checkEligibility() = (%v, %v), want a single reason", eligible, reasons)
+			}
+			if got := reasonCategory(reasons[0]); got != tt.want {
+				t.Errorf("reasonCategory(%q) = %q, want %q", reasons[0], got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package scan
+
+import "time"
+
+// Timings records how long each phase of a scan took, with the number of
+// files and jobs it went through, to find out where the time of a slow scan
+// goes.
+type Timings struct {
+	// Discovery is the time taken to find the workflow files and look them
+	// up in the cache
+	Discovery time.Duration
+	// Parsing is the time taken to read and parse the files not in the cache
+	Parsing time.Duration
+	// Classification is the time taken to check the eligibility of each job
+	Classification time.Duration
+	// Durations is the time taken to fetch job durations from the GitHub API,
+	// 0 if they were skipped
+	Durations time.Duration
+	// Total is the time taken by the whole scan
+	Total time.Duration
+
+	Files        int // Workflow files found
+	CachedFiles  int // Files whose results were taken from the cache
+	Jobs         int // Jobs in the result, in every category
+	DurationJobs int // Candidates whose duration was looked up
+}
+
+// jobCount returns the number of jobs in the result, in every category.
+func (r *ScanResult) jobCount() int {
+	return len(r.Candidates) + len(r.NeedsSetup) + len(r.StaleJobs) + len(r.IneligibleJobs) +
+		len(r.AlreadySlimJobs) + len(r.IgnoredJobs) + len(r.OtherOSJobs) + len(r.UnresolvedRunsOnJobs)
+}
+
+// reportTimings completes timings of the scan of result started at start, and
+// passes them to opts.Timing if set.
+func reportTimings(opts Options, result *ScanResult, timings Timings, start time.Time) {
+	if opts.Timing == nil {
+		return
+	}
+	timings.Jobs = result.jobCount()
+	timings.Total = time.Since(start)
+	opts.Timing(timings)
+}
@@ -0,0 +1,102 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScan_Timing(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	files := map[string]string{
+		".github/workflows/ci.yml": `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+`,
+		".github/workflows/release.yml": `on: push
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make release
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	cache := NewCache(filepath.Join(t.TempDir(), "cache"), "test")
+	scanTimings := func() []Timings {
+		t.Helper()
+		var got []Timings
+		opts := Options{SkipDuration: true, Cache: cache, Timing: func(timings Timings) {
+			got = append(got, timings)
+		}}
+		if _, err := Scan(opts); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		return got
+	}
+
+	for _, tt := range []struct {
+		name       string
+		wantCached int
+	}{
+		{name: "cold cache", wantCached: 0},
+		{name: "warm cache", wantCached: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scanTimings()
+			if len(got) != 1 {
+				t.Fatalf("Timing called %d times, want 1", len(got))
+			}
+			timings := got[0]
+			if timings.Files != 2 || timings.CachedFiles != tt.wantCached || timings.Jobs != 3 {
+				t.Errorf("Timings counts = %d files, %d cached, %d jobs, want 2, %d, 3",
+					timings.Files, timings.CachedFiles, timings.Jobs, tt.wantCached)
+			}
+			if timings.Durations != 0 || timings.DurationJobs != 0 {
+				t.Errorf("Timings durations = %v for %d jobs, want none with SkipDuration", timings.Durations, timings.DurationJobs)
+			}
+			if timings.Total <= 0 || timings.Total < timings.Discovery+timings.Parsing+timings.Classification {
+				t.Errorf("Timings total = %v, want at least the sum of the phases %+v", timings.Total, timings)
+			}
+		})
+	}
+}
+
+func TestScanReader_Timing(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var got []Timings
+	_, err := ScanReader(strings.NewReader(`on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`), "ci.yml", Options{Timing: func(timings Timings) { got = append(got, timings) }})
+	if err != nil {
+		t.Fatalf("ScanReader() error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Timing called %d times, want 1", len(got))
+	}
+	if got[0].Files != 1 || got[0].Jobs != 1 {
+		t.Errorf("Timings counts = %d files, %d jobs, want 1, 1", got[0].Files, got[0].Jobs)
+	}
+}
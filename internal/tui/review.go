@@ -0,0 +1,164 @@
+// Package tui implements the interactive review of migration candidates, kept
+// apart from the scan and fix code so that only the review command depends
+// on the terminal UI libraries.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+// MinWidth and MinHeight are the smallest terminal size the review list fits
+// in. Callers should fall back to non-interactive output on smaller terminals.
+const (
+	MinWidth  = 60
+	MinHeight = 8
+)
+
+// chromeLines is the number of lines around the list: the title, a blank
+// line, a blank line and the status line.
+const chromeLines = 4
+
+// Item is a candidate listed for review.
+type Item struct {
+	Candidate *scan.Candidate
+	Warning   string // Why the job requires attention; empty for safe jobs
+	Selected  bool   // Whether the job is selected when the review starts
+}
+
+// Review lists items with a checkbox each and lets the user toggle which to
+// migrate to slimLabel. It returns the candidates selected when the user
+// applies the selection, or nil if the review is canceled.
+func Review(items []Item, slimLabel string) ([]*scan.Candidate, error) {
+	m := newModel(items, slimLabel)
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	return final.(model).selected(), nil
+}
+
+// model is the bubbletea model of the review list.
+type model struct {
+	items     []Item
+	slimLabel string
+	cursor    int // Index of the highlighted item
+	offset    int // Index of the first visible item
+	height    int // Terminal height, 0 until known
+	applied   bool
+}
+
+func newModel(items []Item, slimLabel string) model {
+	return model{items: items, slimLabel: slimLabel}
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			if len(m.items) > 0 {
+				m.items[m.cursor].Selected = !m.items[m.cursor].Selected
+			}
+		case "a":
+			// Select all, or clear the selection if everything is selected
+			all := m.count() < len(m.items)
+			for i := range m.items {
+				m.items[i].Selected = all
+			}
+		case "enter":
+			m.applied = true
+			return m, tea.Quit
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	m.scroll()
+	return m, nil
+}
+
+// scroll moves the visible window of items so that the cursor is in it.
+func (m *model) scroll() {
+	rows := m.rows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// rows returns how many items fit on the screen.
+func (m model) rows() int {
+	if m.height == 0 {
+		return len(m.items)
+	}
+	return max(m.height-chromeLines, 1)
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Select jobs to migrate to %s (space: toggle, a: all, enter: apply, q: quit)\n\n", m.slimLabel)
+	end := min(m.offset+m.rows(), len(m.items))
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+		check := " "
+		if item.Selected {
+			check = "x"
+		}
+		c := item.Candidate
+		fmt.Fprintf(&b, "%s [%s] %q  %s:%d", cursor, check, c.JobName, c.WorkflowPath, c.LineNumber)
+		if item.Warning != "" {
+			fmt.Fprintf(&b, "  ⚠️  %s", item.Warning)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n%d of %d job(s) selected", m.count(), len(m.items))
+	return b.String()
+}
+
+// count returns the number of selected items.
+func (m model) count() int {
+	n := 0
+	for _, item := range m.items {
+		if item.Selected {
+			n++
+		}
+	}
+	return n
+}
+
+// selected returns the selected candidates if the selection was applied.
+func (m model) selected() []*scan.Candidate {
+	if !m.applied {
+		return nil
+	}
+	var candidates []*scan.Candidate
+	for _, item := range m.items {
+		if item.Selected {
+			candidates = append(candidates, item.Candidate)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fchimpan/gh-slimify/internal/scan"
+)
+
+func TestModel(t *testing.T) {
+	lint := &scan.Candidate{WorkflowPath: "ci.yml", JobID: "lint", JobName: "lint", LineNumber: 5}
+	test := &scan.Candidate{WorkflowPath: "ci.yml", JobID: "test", JobName: "test", LineNumber: 9}
+	docs := &scan.Candidate{WorkflowPath: "docs.yml", JobID: "docs", JobName: "docs", LineNumber: 4}
+	newItems := func() []Item {
+		return []Item{
+			{Candidate: lint, Selected: true},
+			{Candidate: test, Selected: true},
+			{Candidate: docs, Warning: "requires installing: zip"},
+		}
+	}
+
+	tests := []struct {
+		name string
+		keys []string
+		want []*scan.Candidate
+	}{
+		{name: "apply initial selection", keys: []string{"enter"}, want: []*scan.Candidate{lint, test}},
+		{name: "toggle", keys: []string{"down", " ", "j", "x", "enter"}, want: []*scan.Candidate{lint, docs}},
+		{name: "cursor stays in the list", keys: []string{"up", " ", "down", "down", "down", " ", "enter"}, want: []*scan.Candidate{test, docs}},
+		{name: "select all", keys: []string{"a", "enter"}, want: []*scan.Candidate{lint, test, docs}},
+		{name: "clear all", keys: []string{"a", "a", "enter"}, want: nil},
+		{name: "quit", keys: []string{"q"}, want: nil},
+		{name: "ctrl+c", keys: []string{" ", "ctrl+c"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m tea.Model = newModel(newItems(), "ubuntu-slim")
+			for _, key := range tt.keys {
+				m, _ = m.Update(keyMsg(key))
+			}
+			got := m.(model).selected()
+			if len(got) != len(tt.want) {
+				t.Fatalf("selected() = %d candidate(s), want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("selected()[%d] = %s, want %s", i, got[i].JobID, tt.want[i].JobID)
+				}
+			}
+		})
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	var items []Item
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		items = append(items, Item{Candidate: &scan.Candidate{WorkflowPath: "ci.yml", JobID: id, JobName: id, LineNumber: 1}})
+	}
+	items[0].Selected = true
+	items[5].Warning = "requires installing: zip"
+
+	// Only 2 items fit; moving the cursor to the last one scrolls the list
+	var m tea.Model = newModel(items, "ubuntu-slim")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 2 + chromeLines})
+	for range 5 {
+		m, _ = m.Update(keyMsg("down"))
+	}
+
+	view := m.View()
+	for _, want := range []string{"migrate to ubuntu-slim", `> [ ] "f"  ci.yml:1  ⚠️  requires installing: zip`, `  [ ] "e"`, "1 of 6 job(s) selected"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, `"d"`) {
+		t.Errorf("View() shows items above the visible window:\n%s", view)
+	}
+}
+
+// keyMsg returns the key message bubbletea sends for a key name.
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "ctrl+c":
+		return tea.KeyMsg{Type: tea.KeyCtrlC}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
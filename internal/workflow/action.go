@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action represents the metadata file (action.yml) of a GitHub Action
+type Action struct {
+	Name string     `yaml:"name"`
+	Runs ActionRuns `yaml:"runs"`
+}
+
+// ActionRuns is the runs: section of an action's metadata
+type ActionRuns struct {
+	Using string `yaml:"using"` // composite, docker, node20, ...
+	Image string `yaml:"image"` // Image or Dockerfile of a Docker container action
+	Steps []Step `yaml:"steps"` // Steps of a composite action
+}
+
+// maxLocalActionDepth limits how deep local composite actions calling other
+// local composite actions are followed.
+const maxLocalActionDepth = 5
+
+// LocalActionPath returns the directory, relative to the repository root, of a
+// step's action when it is in the same repository (e.g. ./.github/actions/setup).
+// Returns false for actions from other repositories and docker:// images.
+func (s *Step) LocalActionPath() (string, bool) {
+	if !strings.HasPrefix(s.Uses, "./") {
+		return "", false
+	}
+	return filepath.Clean(s.Uses), true
+}
+
+// LoadLocalAction loads the action.yml or action.yaml file of the action in dir.
+func LoadLocalAction(dir string) (*Action, error) {
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+
+		var action Action
+		if err := yaml.Unmarshal(data, &action); err != nil {
+			return nil, newParseError(path, err)
+		}
+		return &action, nil
+	}
+	return nil, fmt.Errorf("action metadata not found in %s", dir)
+}
+
+// LocalDockerActions returns the local actions used by the job (e.g.
+// ./.github/actions/build) that need Docker, in order of first use: Docker
+// container actions, and composite actions whose steps use Docker commands,
+// container-based actions or the docker-dependent actions, directly or through
+// other local actions. Actions are read relative to the current directory,
+// which should be the repository root. Missing or invalid action files, cycles
+// and nesting beyond a few levels are skipped rather than reported.
+func (j *Job) LocalDockerActions(dockerActions []string) []string {
+	return j.LocalDockerActionsWith(dockerActions, nil, nil)
+}
+
+// LocalDockerActionsWith is like LocalDockerActions but checks the steps of
+// composite actions with DockerCommandStepsWith, so that they follow the same
+// extraPatterns and safeDockerSubcommands as the job's own steps.
+func (j *Job) LocalDockerActionsWith(dockerActions []string, extraPatterns []*regexp.Regexp, safeDockerSubcommands []string) []string {
+	d := localDockerDetector{dockerActions: dockerActions, extraPatterns: extraPatterns, safeDockerSubcommands: safeDockerSubcommands}
+	var found []string
+	for _, step := range j.Steps {
+		path, ok := step.LocalActionPath()
+		if !ok || slices.Contains(found, step.Uses) {
+			continue
+		}
+		if d.usesDocker(path, 1, map[string]bool{}) {
+			found = append(found, step.Uses)
+		}
+	}
+	return found
+}
+
+// localDockerDetector holds what counts as using Docker in the steps of local
+// composite actions.
+type localDockerDetector struct {
+	dockerActions         []string
+	extraPatterns         []*regexp.Regexp
+	safeDockerSubcommands []string
+}
+
+// usesDocker reports whether the local action in dir needs Docker.
+// visiting holds the actions being inspected further up the call chain.
+func (d *localDockerDetector) usesDocker(dir string, depth int, visiting map[string]bool) bool {
+	if depth > maxLocalActionDepth || visiting[dir] {
+		return false
+	}
+	action, err := LoadLocalAction(dir)
+	if err != nil {
+		slog.Debug("skipping local action that cannot be loaded", "dir", dir, "error", err)
+		return false
+	}
+
+	switch strings.ToLower(action.Runs.Using) {
+	case "docker":
+		return true
+	case "composite":
+	default:
+		return false
+	}
+
+	steps := &Job{Steps: action.Runs.Steps}
+	if len(steps.DockerCommandStepsWith(d.extraPatterns, d.safeDockerSubcommands)) > 0 || len(steps.ContainerActionSteps()) > 0 || len(steps.DockerDependentActions(d.dockerActions)) > 0 {
+		return true
+	}
+
+	visiting[dir] = true
+	defer delete(visiting, dir)
+	for _, step := range action.Runs.Steps {
+		if path, ok := step.LocalActionPath(); ok && d.usesDocker(path, depth+1, visiting) {
+			return true
+		}
+	}
+	return false
+}
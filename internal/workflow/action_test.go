@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestJob_LocalDockerActions(t *testing.T) {
+	tests := []struct {
+		name          string
+		uses          []string
+		dockerActions []string
+		want          []string
+	}{
+		{
+			name: "composite action running docker",
+			uses: []string{"./testdata/actions/docker-build"},
+			want: []string{"./testdata/actions/docker-build"},
+		},
+		{
+			name: "docker container action",
+			uses: []string{"./testdata/actions/container"},
+			want: []string{"./testdata/actions/container"},
+		},
+		{
+			name: "nested local action running docker",
+			uses: []string{"./testdata/actions/nested"},
+			want: []string{"./testdata/actions/nested"},
+		},
+		{
+			name: "composite action without docker in action.yaml",
+			uses: []string{"./testdata/actions/node"},
+			want: nil,
+		},
+		{
+			name:          "composite action using a docker-dependent action",
+			uses:          []string{"./testdata/actions/node"},
+			dockerActions: []string{"actions/setup-node"},
+			want:          []string{"./testdata/actions/node"},
+		},
+		{
+			name: "missing action file",
+			uses: []string{"./testdata/actions/missing"},
+			want: nil,
+		},
+		{
+			name: "cyclic local actions",
+			uses: []string{"./testdata/actions/cycle-a"},
+			want: nil,
+		},
+		{
+			name: "remote actions are not resolved",
+			uses: []string{"actions/checkout@v4", "docker://alpine:3.20"},
+			want: nil,
+		},
+		{
+			name: "reported once in order of first use",
+			uses: []string{"./testdata/actions/nested", "./testdata/actions/node", "./testdata/actions/container", "./testdata/actions/nested"},
+			want: []string{"./testdata/actions/nested", "./testdata/actions/container"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{RunsOn: "ubuntu-latest"}
+			for _, uses := range tt.uses {
+				job.Steps = append(job.Steps, Step{Uses: uses})
+			}
+			if got := job.LocalDockerActions(tt.dockerActions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocalDockerActions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_LocalDockerActionsWith(t *testing.T) {
+	tests := []struct {
+		name                  string
+		extraPatterns         []*regexp.Regexp
+		safeDockerSubcommands []string
+		want                  []string
+	}{
+		{
+			name: "default rules",
+			want: []string{"./testdata/actions/docker-build", "./testdata/actions/nested"},
+		},
+		{
+			name:                  "safe docker subcommand",
+			safeDockerSubcommands: []string{"build"},
+			want:                  nil,
+		},
+		{
+			name:                  "extra container command",
+			extraPatterns:         []*regexp.Regexp{regexp.MustCompile(`\bnpm\s+test\b`)},
+			safeDockerSubcommands: []string{"build"},
+			want:                  []string{"./testdata/actions/node"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{RunsOn: "ubuntu-latest", Steps: []Step{
+				{Uses: "./testdata/actions/docker-build"},
+				{Uses: "./testdata/actions/nested"},
+				{Uses: "./testdata/actions/node"},
+			}}
+			if got := job.LocalDockerActionsWith(nil, tt.extraPatterns, tt.safeDockerSubcommands); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocalDockerActionsWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// BackupSuffix is appended to a workflow path to name its backup.
+const BackupSuffix = ".slimify.bak"
+
+// Backup copies the workflow at path to path+BackupSuffix, keeping its file
+// mode, so that Restore can undo later changes. An existing backup is kept, so
+// that after several changes it still holds the workflow as it was before the
+// first one.
+func Backup(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path+BackupSuffix, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if errors.Is(err, fs.ErrExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + BackupSuffix)
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return nil
+}
+
+// HasBackup reports whether the workflow at path has a backup.
+func HasBackup(path string) bool {
+	info, err := os.Stat(path + BackupSuffix)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Restore replaces the workflow at path with its backup and removes the backup,
+// leaving the file byte-identical to when Backup was called.
+// The returned error wraps fs.ErrNotExist if path has no backup.
+func Restore(path string) error {
+	if err := os.Rename(path+BackupSuffix, path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no backup found for %s: %w", path, fs.ErrNotExist)
+		}
+		return fmt.Errorf("failed to restore %s: %w", path, err)
+	}
+	return nil
+}
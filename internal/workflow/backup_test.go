@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupRestore(t *testing.T) {
+	content := `# CI pipeline
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest  # keep this comment
+    steps:
+      - run: zip -r dist.zip dist
+  test:
+    runs-on: [ubuntu-22.04]
+    steps:
+      - run: make test
+`
+	filePath := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	if err := Backup(filePath); err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+	if !HasBackup(filePath) {
+		t.Fatalf("HasBackup() = false after Backup()")
+	}
+
+	// Apply the same edits as fix
+	if err := UpdateRunsOnAtLine(filePath, 5, "ubuntu-latest", "ubuntu-slim"); err != nil {
+		t.Fatalf("UpdateRunsOnAtLine() error: %v", err)
+	}
+	if err := UpdateRunsOnAtLine(filePath, 9, "ubuntu-22.04", "ubuntu-slim"); err != nil {
+		t.Fatalf("UpdateRunsOnAtLine() error: %v", err)
+	}
+	if err := PrependRunStep(filePath, "lint", "Install tools", "sudo apt-get install -y zip"); err != nil {
+		t.Fatalf("PrependRunStep() error: %v", err)
+	}
+
+	if err := Restore(filePath); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	got, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read restored file: %v", err)
+	}
+	if string(got) != content {
+		t.Errorf("Restored content =\n%s\nwant\n%s", got, content)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("Failed to stat restored file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Restored file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+	if HasBackup(filePath) {
+		t.Errorf("HasBackup() = true after Restore()")
+	}
+
+	if err := Restore(filePath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Restore() without backup error = %v, want fs.ErrNotExist", err)
+	}
+}
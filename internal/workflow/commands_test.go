@@ -0,0 +1,106 @@
+package workflow
+
+import "testing"
+
+func TestEmbeddedMissingCommands(t *testing.T) {
+	commands, err := parseMissingCommands(missingCommandsData)
+	if err != nil {
+		t.Fatalf("parseMissingCommands() error on missing_commands.yml: %v", err)
+	}
+	if len(commands) == 0 {
+		t.Fatal("missing_commands.yml has no commands")
+	}
+
+	for _, c := range commands {
+		if c.VerifiedAgainst == "" {
+			t.Errorf("%s has no verified_against version", c.Name)
+		}
+	}
+
+	tests := []struct {
+		cmd         string
+		wantMissing bool
+	}{
+		{cmd: "zip", wantMissing: true},
+		{cmd: "lsb_release", wantMissing: true},
+		{cmd: "docker", wantMissing: true},
+		{cmd: "bash", wantMissing: false},
+		{cmd: "git", wantMissing: false},
+	}
+	for _, tt := range tests {
+		if got := IsMissingInSlim(tt.cmd); got != tt.wantMissing {
+			t.Errorf("IsMissingInSlim(%q) = %v, want %v", tt.cmd, got, tt.wantMissing)
+		}
+	}
+}
+
+func TestParseMissingCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantPackage map[string]string
+		wantVersion map[string]string
+		wantErr     bool
+	}{
+		{
+			name: "defaults",
+			data: `verified_against: 20250101.1
+commands:
+  - name: jq
+  - name: psql
+    package: postgresql-client
+  - name: kubectl
+    package: "-"
+    verified_against: 20250301.1
+`,
+			wantPackage: map[string]string{"jq": "jq", "psql": "postgresql-client", "kubectl": ""},
+			wantVersion: map[string]string{"jq": "20250101.1", "psql": "20250101.1", "kubectl": "20250301.1"},
+		},
+		{
+			name:    "no version",
+			data:    "commands:\n  - name: jq\n",
+			wantErr: true,
+		},
+		{
+			name:    "no name",
+			data:    "verified_against: baseline\ncommands:\n  - package: jq\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			data:    "verified_against: baseline\ncommands:\n  - name: jq\n  - name: jq\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			data:    "verified_against: baseline\ncommands:\n  - name: jq\n    pkg: jq\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := parseMissingCommands([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseMissingCommands() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMissingCommands() unexpected error: %v", err)
+			}
+			if len(commands) != len(tt.wantPackage) {
+				t.Fatalf("parseMissingCommands() returned %d commands, want %d", len(commands), len(tt.wantPackage))
+			}
+			for _, c := range commands {
+				if c.Package != tt.wantPackage[c.Name] {
+					t.Errorf("%s package = %q, want %q", c.Name, c.Package, tt.wantPackage[c.Name])
+				}
+				if c.VerifiedAgainst != tt.wantVersion[c.Name] {
+					t.Errorf("%s verified_against = %q, want %q", c.Name, c.VerifiedAgainst, tt.wantVersion[c.Name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"regexp"
+	"strings"
+)
+
+// runnerOSComparison matches an expression comparing runner.os with a string
+// literal, in either order, e.g. "runner.os == 'Windows'".
+var runnerOSComparison = regexp.MustCompile(`^(?:runner\.os\s*(==|!=)\s*'([^']*)'|'([^']*)'\s*(==|!=)\s*runner\.os)$`)
+
+// unsupportedExpression matches expressions evaluateCondition gives up on:
+// those with parentheses (function calls or grouping) or negations.
+var unsupportedExpression = regexp.MustCompile(`[()]|!(?:[^=]|$)`)
+
+// evaluateCondition evaluates an if: condition (a bool or an expression string,
+// optionally wrapped in ${{ }}) when its value is known before the workflow
+// runs. runnerOS is the value of runner.os (e.g. Linux), or "" where the runner
+// context is not available, as in job-level conditions.
+//
+// Only the obvious cases are handled: true and false literals, comparisons of
+// runner.os with a string, and && or || of those. Other expressions, and any
+// with parentheses or negations, are unknown and return false for ok.
+func evaluateCondition(cond interface{}, runnerOS string) (value bool, ok bool) {
+	switch v := cond.(type) {
+	case bool:
+		return v, true
+	case string:
+		expr := strings.TrimSpace(v)
+		if strings.HasPrefix(expr, "${{") && strings.HasSuffix(expr, "}}") {
+			expr = strings.TrimSpace(expr[3 : len(expr)-2])
+		}
+		if unsupportedExpression.MatchString(expr) {
+			return false, false
+		}
+		return evaluateOr(expr, runnerOS)
+	}
+	return false, false
+}
+
+// evaluateOr evaluates a || b || ..., which is true if any operand is true
+// and false if all of them are false.
+func evaluateOr(expr, runnerOS string) (bool, bool) {
+	known := true
+	for _, operand := range strings.Split(expr, "||") {
+		value, ok := evaluateAnd(operand, runnerOS)
+		if ok && value {
+			return true, true
+		}
+		known = known && ok
+	}
+	return false, known
+}
+
+// evaluateAnd evaluates a && b && ..., which is false if any operand is false
+// and true if all of them are true.
+func evaluateAnd(expr, runnerOS string) (bool, bool) {
+	known := true
+	for _, operand := range strings.Split(expr, "&&") {
+		value, ok := evaluateOperand(strings.TrimSpace(operand), runnerOS)
+		if ok && !value {
+			return false, true
+		}
+		known = known && ok
+	}
+	return true, known
+}
+
+// evaluateOperand evaluates a literal or a runner.os comparison. Strings are
+// compared case-insensitively, as GitHub Actions expressions do.
+func evaluateOperand(expr, runnerOS string) (bool, bool) {
+	switch expr {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+	m := runnerOSComparison.FindStringSubmatch(expr)
+	if m == nil || runnerOS == "" {
+		return false, false
+	}
+	op, value := m[1], m[2]
+	if op == "" {
+		op, value = m[4], m[3]
+	}
+	equal := strings.EqualFold(runnerOS, value)
+	return equal == (op == "=="), true
+}
+
+// SkippedOnLinux reports whether the step's if: condition is statically false
+// on Linux runners, e.g. if: runner.os == 'Windows', so the step never runs
+// on ubuntu-slim.
+func (s Step) SkippedOnLinux() bool {
+	value, ok := evaluateCondition(s.If, "Linux")
+	return ok && !value
+}
@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		cond      interface{}
+		runnerOS  string
+		wantValue bool
+		wantOK    bool
+	}{
+		{name: "bool", cond: false, runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "literal", cond: "${{ true }}", runnerOS: "Linux", wantValue: true, wantOK: true},
+		{name: "other OS", cond: "runner.os == 'Windows'", runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "same OS", cond: "${{ runner.os == 'Linux' }}", runnerOS: "Linux", wantValue: true, wantOK: true},
+		{name: "case-insensitive", cond: "runner.os == 'linux'", runnerOS: "Linux", wantValue: true, wantOK: true},
+		{name: "reversed operands", cond: "'macOS' == runner.os", runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "not equal", cond: "runner.os != 'Linux'", runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "and with a false operand", cond: "github.event_name == 'push' && runner.os == 'Windows'", runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "and with an unknown operand", cond: "github.event_name == 'push' && runner.os == 'Linux'", runnerOS: "Linux", wantOK: false},
+		{name: "or of other OSes", cond: "runner.os == 'Windows' || runner.os == 'macOS'", runnerOS: "Linux", wantValue: false, wantOK: true},
+		{name: "or with an unknown operand", cond: "runner.os == 'Windows' || github.event_name == 'push'", runnerOS: "Linux", wantOK: false},
+		{name: "runner context unavailable", cond: "runner.os == 'Windows'", runnerOS: "", wantOK: false},
+		{name: "negation", cond: "!(runner.os == 'Linux')", runnerOS: "Linux", wantOK: false},
+		{name: "function call", cond: "startsWith(runner.os, 'Win')", runnerOS: "Linux", wantOK: false},
+		{name: "no condition", cond: nil, runnerOS: "Linux", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := evaluateCondition(tt.cond, tt.runnerOS)
+			if ok != tt.wantOK || (ok && value != tt.wantValue) {
+				t.Errorf("evaluateCondition(%v, %q) = (%v, %v), want (%v, %v)", tt.cond, tt.runnerOS, value, ok, tt.wantValue, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestJob_DockerCommandStepsSkippedOnLinux(t *testing.T) {
+	job := &Job{
+		Steps: []Step{
+			{Run: "docker build -t app .", If: "${{ runner.os == 'Windows' }}"},
+			{Run: "docker run app", If: "runner.os == 'Linux'"},
+			{Run: "docker push app", If: "github.ref == 'refs/heads/main'"},
+			{Run: "echo done", If: "runner.os == 'macOS'"},
+		},
+	}
+
+	if got, want := job.DockerCommandStepsWith(nil, nil), []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DockerCommandStepsWith() = %v, want %v", got, want)
+	}
+	if got, want := job.DockerCommandStepsSkippedOnLinux(nil, nil), []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DockerCommandStepsSkippedOnLinux() = %v, want %v", got, want)
+	}
+}
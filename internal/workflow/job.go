@@ -1,7 +1,15 @@
 package workflow
 
 import (
+	"fmt"
+	"maps"
+	"math"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -37,93 +45,817 @@ var setupActionCommands = map[string][]string{
 }
 
 var (
-	// containerCommandPatterns lists regex patterns that match container commands
+	// dockerDaemonSubcommands are the docker subcommands that talk to the
+	// daemon. Others, like docker version or docker context, work without one.
+	dockerDaemonSubcommands = []string{
+		"build", "buildx", "run", "exec", "ps", "pull", "push", "tag", "login", "compose",
+		"image", "container", "network", "volume", "create", "start", "stop", "rm", "rmi",
+		"cp", "save", "load", "logs", "inspect", "system",
+	}
+
+	// dockerCommandPattern matches docker invoked with a subcommand of
+	// dockerDaemonSubcommands.
+	dockerCommandPattern = newDockerCommandPattern(dockerDaemonSubcommands)
+
+	// containerCommandPatterns lists regex patterns that match container
+	// commands other than the docker CLI, which dockerCommandPattern matches.
 	// Each pattern is compiled and checked against run commands.
-	// Future additions could include: podman commands, containerd commands, etc.
+	// Podman and Buildah are included because, like Docker, they need a real
+	// container runtime that ubuntu-slim does not provide.
+	// Future additions could include: containerd commands, etc.
 	containerCommandPatterns = []*regexp.Regexp{
-		regexp.MustCompile(`\bdocker[\s-](?:build|run|exec|ps|pull|push|tag|login)\b`),
 		regexp.MustCompile(`\bdocker-compose\b`),
-		regexp.MustCompile(`\bdocker\s+compose\b`),
+		regexp.MustCompile(`\bpodman\s+(?:build|run|exec|ps|pull|push|tag|login)\b`),
+		regexp.MustCompile(`\bpodman-compose\b`),
+		regexp.MustCompile(`\bbuildah\s+(?:bud|build|from|run|commit|push|pull|tag|login)\b`),
+		// BuildKit clients run outside the docker CLI: the buildx plugin binary
+		// and buildctl, which talk to a buildkitd daemon
+		regexp.MustCompile(`\bdocker-buildx\s+(?:bake|build|create|use|inspect|imagetools)\b`),
+		regexp.MustCompile(`\bbuildctl\s+(?:build|prune|du)\b`),
 	}
 
-	// privilegedCommandPattern matches privileged operations that require capabilities
-	// not available in non-privileged containers like ubuntu-slim.
-	// Categories: filesystem mounts, kernel modules, network firewall,
+	// localClusterCommands lists the commands that create a local Kubernetes
+	// cluster, by the tool running it. kind and k3d run the cluster nodes as
+	// Docker containers, and so does minikube with its default docker driver;
+	// its other drivers need a VM or root access to a container runtime, which
+	// ubuntu-slim doesn't provide either.
+	localClusterCommands = []clusterCommand{
+		{tool: "kind", pattern: regexp.MustCompile(commandPosition + `kind\s+create\s+cluster\b`)},
+		{tool: "minikube", pattern: regexp.MustCompile(commandPosition + `minikube\s+start\b`)},
+		{tool: "k3d", pattern: regexp.MustCompile(commandPosition + `k3d\s+cluster\s+create\b`)},
+	}
+
+	// privilegedOperations lists privileged operations that require capabilities
+	// not available in non-privileged containers like ubuntu-slim, with the pattern
+	// that detects each one in a lower-cased run script.
+	// Categories: filesystem mounts, loop devices, kernel modules, network firewall,
 	// sysctl, namespaces, cgroups, device management, Linux capabilities.
-	privilegedCommandPattern = regexp.MustCompile(
-		`\b(mount|umount|modprobe|insmod|rmmod|iptables|ip6tables|nft|nftables|sysctl|unshare|nsenter|cgcreate|cgexec|mknod|losetup|setcap|getcap|capsh)\b`,
+	// Add an entry to detect a new operation.
+	privilegedOperations = append(
+		privilegedCommands(
+			"mount", "umount", "losetup", "modprobe", "insmod", "rmmod",
+			"iptables", "ip6tables", "nft", "nftables", "sysctl", "unshare", "nsenter",
+			"cgcreate", "cgexec", "mknod", "setcap", "getcap", "capsh",
+		),
+		privilegedOperation{
+			name: "write to /dev/loop",
+			// Redirections (> /dev/loop0) and dd output (of=/dev/loop0)
+			pattern: regexp.MustCompile(`(?:>|\bof=)\s*/dev/loop`),
+		},
 	)
 
 	// containerActionPrefixes lists prefixes that indicate container-based GitHub Actions
 	// This covers:
 	// - docker:// image syntax (e.g., "docker://alpine:latest")
 	// - docker/ organization actions (e.g., "docker/build-push-action@v6")
+	// Each prefix ends with a separator so that unrelated owners that merely start
+	// with the letters "docker" (e.g., "dockerized-lint/action") are not matched.
 	// Future additions could include: "container://", "podman/", etc.
-	containerActionPrefixes = []string{"docker"}
+	containerActionPrefixes = []string{"docker://", "docker/"}
 )
 
+// clusterCommand is a command creating a local Kubernetes cluster with tool.
+type clusterCommand struct {
+	tool    string
+	pattern *regexp.Regexp
+}
+
+// privilegedOperation is a privileged operation detected in run scripts.
+type privilegedOperation struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// commandPosition matches the start of a shell command: the start of a line or
+// a position after a command separator or sudo (with options), optionally
+// followed by a directory, so that /usr/bin/mount is matched as mount.
+// Arguments of other commands (git mount) and words in quoted strings
+// (echo "mount the disk") are not at a command position.
+const commandPosition = `(?m)(?:^|[;&|(\x60{!]|\$\(|\b(?:then|do|else|exec|time|nohup|xargs)\b)\s*(?:sudo\s+(?:-\S+\s+)*)?(?:[\w./-]*/)?`
+
+// privilegedCommands returns privileged operations for commands detected by name
+// at a command position. Commands that merely start with a name (mountpoint,
+// sysctld) are not matched.
+func privilegedCommands(names ...string) []privilegedOperation {
+	ops := make([]privilegedOperation, len(names))
+	for i, name := range names {
+		ops[i] = privilegedOperation{
+			name:    name,
+			pattern: regexp.MustCompile(commandPosition + regexp.QuoteMeta(name) + `(?:\s|$|[;&|)\x60])`),
+		}
+	}
+	return ops
+}
+
+// DefaultDockerDependentActions lists actions that need a Docker daemon although
+// they are not container-based actions, e.g. because they pull or scan images or
+// are implemented as Docker container actions.
+// Actions of the docker/ organization (e.g. docker/scout-action) are already
+// detected by HasContainerActions and don't need to be listed.
+var DefaultDockerDependentActions = []string{
+	"aquasecurity/trivy-action",
+	"hadolint/hadolint-action",
+	"github/super-linter",
+	"super-linter/super-linter",
+	"addnab/docker-run-action",
+	// Actions creating a local Kubernetes cluster, see localClusterCommands
+	"helm/kind-action",
+	"medyagh/setup-minikube",
+	"AbsaOSS/k3d-action",
+}
+
 // IsUbuntuLatest checks if a job runs on ubuntu-latest
 func (j *Job) IsUbuntuLatest() bool {
-	if j.RunsOn == nil {
-		return false
+	_, ok := j.MatchRunsOn([]string{"ubuntu-latest"})
+	return ok
+}
+
+// NormalizeLabel returns a runner label the way GitHub compares labels:
+// trimmed of surrounding whitespace and lowercased, so that "Ubuntu-Latest"
+// and "ubuntu-latest " select the same runners as ubuntu-latest.
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// MatchLabel returns the entry of labels that label selects, comparing them
+// as GitHub does (see NormalizeLabel).
+func MatchLabel(labels []string, label string) (string, bool) {
+	label = NormalizeLabel(label)
+	for _, l := range labels {
+		if NormalizeLabel(l) == label {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// MatchRunsOn checks if a job runs on any of the given labels and returns the
+// first label of labels that matched. This generalizes IsUbuntuLatest so that
+// pinned images (e.g. ubuntu-24.04) can be treated as migration sources as well.
+// Labels are compared regardless of case and surrounding whitespace, as
+// GitHub does (see NormalizeLabel).
+// A runs-on expression like ${{ matrix.os }} matches only if every value the
+// matrix gives it is one of labels (see RunsOnMatrixValues).
+// A list of labels like [self-hosted, linux] selects a runner that has all of
+// them, so it matches only if it lists exactly one label, e.g. [ubuntu-latest].
+func (j *Job) MatchRunsOn(labels []string) (string, bool) {
+	if values, ok := j.RunsOnMatrixValues(); ok {
+		var first string
+		for i, value := range values {
+			matched, ok := MatchLabel(labels, value)
+			if !ok {
+				return "", false
+			}
+			if i == 0 {
+				first = matched
+			}
+		}
+		return first, true
+	}
+
+	runsOn := j.RunsOnLabels()
+	if len(runsOn) != 1 {
+		return "", false
+	}
+	return MatchLabel(labels, runsOn[0])
+}
+
+// IsUbuntuSlim checks if a job already runs on ubuntu-slim.
+func (j *Job) IsUbuntuSlim() bool {
+	return j.IsSlim(DefaultSlimLabel)
+}
+
+// IsSlim checks if a job already runs on slimLabel, the label of the slim
+// runners jobs are migrated to, compared as GitHub does (see NormalizeLabel).
+// A runs-on expression like ${{ matrix.os }} counts only if every value the
+// matrix gives it is slimLabel.
+func (j *Job) IsSlim(slimLabel string) bool {
+	slim := []string{slimLabel}
+	if values, ok := j.RunsOnMatrixValues(); ok {
+		for _, value := range values {
+			if _, ok := MatchLabel(slim, value); !ok {
+				return false
+			}
+		}
+		return true
 	}
+	return slices.ContainsFunc(j.RunsOnLabels(), func(label string) bool {
+		_, ok := MatchLabel(slim, label)
+		return ok
+	})
+}
 
+// RunsOnLabels returns the labels listed in runs-on, whether it is a single
+// label, a list of labels or an object with group and labels. Expressions like
+// ${{ matrix.os }} are returned as written (see ResolvedRunsOnLabels), and
+// items that are not strings are skipped. Returns nil if runs-on is not set.
+func (j *Job) RunsOnLabels() []string {
 	switch v := j.RunsOn.(type) {
+	case string, []any:
+		return stringLabels(v)
+	case map[string]any:
+		// runs-on can be an object with group and labels
+		return stringLabels(v["labels"])
+	default:
+		return nil
+	}
+}
+
+// stringLabels returns the string labels of a runs-on labels value,
+// which may be a single label or a list of labels.
+func stringLabels(value any) []string {
+	switch v := value.(type) {
 	case string:
-		return v == "ubuntu-latest"
+		return []string{v}
 	case []any:
-		// runs-on can be a matrix or array
+		var labels []string
 		for _, item := range v {
-			if str, ok := item.(string); ok && str == "ubuntu-latest" {
-				return true
+			if str, ok := item.(string); ok {
+				labels = append(labels, str)
 			}
 		}
-		return false
+		return labels
 	default:
-		return false
+		return nil
 	}
 }
 
-// IsUbuntuSlim checks if a job already runs on ubuntu-slim
-func (j *Job) IsUbuntuSlim() bool {
-	if j.RunsOn == nil {
-		return false
+// runsOnVariablePattern matches a runs-on label taken from a configuration
+// variable, an environment variable, a secret or the github context, e.g.
+// ${{ vars.LINUX_RUNNER }} or ${{ github.event.inputs.runner }}
+var runsOnVariablePattern = regexp.MustCompile(`^\$\{\{\s*((?:vars|env|secrets|github)\.[A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}$`)
+
+// RunsOnVariables returns the variables whose values runs-on uses as labels,
+// e.g. ["vars.LINUX_RUNNER"] for runs-on: ${{ vars.LINUX_RUNNER }}, including
+// those given by the matrix values of a runs-on expression like
+// ${{ matrix.os }}. Such labels can't be known from the job alone.
+func (j *Job) RunsOnVariables() []string {
+	var variables []string
+	add := func(value any) {
+		str, ok := value.(string)
+		if !ok {
+			return
+		}
+		match := runsOnVariablePattern.FindStringSubmatch(strings.TrimSpace(str))
+		if match != nil && !slices.Contains(variables, match[1]) {
+			variables = append(variables, match[1])
+		}
+	}
+	for _, label := range j.RunsOnLabels() {
+		add(label)
+		if match := runsOnMatrixPattern.FindStringSubmatch(strings.TrimSpace(label)); match != nil {
+			for _, value := range j.matrixValues(match[1]) {
+				add(value)
+			}
+		}
+	}
+	return variables
+}
+
+// WithRunsOnVariables returns a copy of the job whose runs-on labels of the
+// form ${{ vars.NAME }}, ${{ env.NAME }} and so on, and matrix values used by
+// runs-on of that form, are replaced by values["vars.NAME"], values["env.NAME"]
+// and so on. Labels whose variable is not in values are kept as written, and
+// the job itself is not modified.
+func (j *Job) WithRunsOnVariables(values map[string]string) *Job {
+	resolve := func(label any) any {
+		str, ok := label.(string)
+		if !ok {
+			return label
+		}
+		match := runsOnVariablePattern.FindStringSubmatch(strings.TrimSpace(str))
+		if match == nil {
+			return label
+		}
+		if value, ok := values[match[1]]; ok {
+			return value
+		}
+		return label
+	}
+	resolveList := func(labels any) any {
+		list, ok := labels.([]any)
+		if !ok {
+			return resolve(labels)
+		}
+		resolved := make([]any, len(list))
+		for i, label := range list {
+			resolved[i] = resolve(label)
+		}
+		return resolved
 	}
 
+	resolved := *j
 	switch v := j.RunsOn.(type) {
-	case string:
-		return v == "ubuntu-slim"
+	case map[string]any:
+		runsOn := maps.Clone(v)
+		if labels, ok := runsOn["labels"]; ok {
+			runsOn["labels"] = resolveList(labels)
+		}
+		resolved.RunsOn = runsOn
+	default:
+		resolved.RunsOn = resolveList(v)
+	}
+
+	var keys []string
+	for _, label := range j.RunsOnLabels() {
+		if match := runsOnMatrixPattern.FindStringSubmatch(strings.TrimSpace(label)); match != nil {
+			keys = append(keys, match[1])
+		}
+	}
+	if strategy, ok := j.Strategy.(map[string]any); ok && len(keys) > 0 {
+		if matrix, ok := strategy["matrix"].(map[string]any); ok {
+			matrix = maps.Clone(matrix)
+			include, _ := matrix["include"].([]any)
+			include = slices.Clone(include)
+			for _, key := range keys {
+				if value, ok := matrix[key]; ok {
+					matrix[key] = resolveList(value)
+				}
+				for i, entry := range include {
+					if entryMap, ok := entry.(map[string]any); ok {
+						if value, ok := entryMap[key]; ok {
+							entryMap = maps.Clone(entryMap)
+							entryMap[key] = resolve(value)
+							include[i] = entryMap
+						}
+					}
+				}
+			}
+			if include != nil {
+				matrix["include"] = include
+			}
+			strategy = maps.Clone(strategy)
+			strategy["matrix"] = matrix
+			resolved.Strategy = strategy
+		}
+	}
+	return &resolved
+}
+
+// matrixValues returns the values strategy.matrix lists for key, including
+// those of its include entries.
+func (j *Job) matrixValues(key string) []any {
+	strategy, _ := j.Strategy.(map[string]any)
+	matrix, _ := strategy["matrix"].(map[string]any)
+
+	var values []any
+	switch v := matrix[key].(type) {
+	case nil:
 	case []any:
-		// runs-on can be a matrix or array
-		for _, item := range v {
-			if str, ok := item.(string); ok && str == "ubuntu-slim" {
-				return true
+		values = append(values, v...)
+	default:
+		values = append(values, v)
+	}
+	include, _ := matrix["include"].([]any)
+	for _, entry := range include {
+		if entryMap, ok := entry.(map[string]any); ok {
+			if value, ok := entryMap[key]; ok {
+				values = append(values, value)
 			}
 		}
-		return false
+	}
+	return values
+}
+
+// runsOnMatrixPattern matches a runs-on expression that refers to a single matrix key
+var runsOnMatrixPattern = regexp.MustCompile(`^\$\{\{\s*matrix\.([A-Za-z0-9_-]+)\s*\}\}$`)
+
+// RunsOnMatrixKey returns the matrix key of a runs-on expression like ${{ matrix.os }}.
+func (j *Job) RunsOnMatrixKey() (string, bool) {
+	runsOn, ok := j.RunsOn.(string)
+	if !ok {
+		return "", false
+	}
+	match := runsOnMatrixPattern.FindStringSubmatch(strings.TrimSpace(runsOn))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// RunsOnMatrixValues resolves a runs-on expression like ${{ matrix.os }} against
+// the job's strategy.matrix and returns the distinct values it can take
+// (see ResolvedRunsOnLabels).
+// Returns false if runs-on is not a matrix expression or the matrix cannot be
+// resolved statically (e.g. it is built with fromJSON).
+func (j *Job) RunsOnMatrixValues() ([]string, bool) {
+	if _, ok := j.RunsOnMatrixKey(); !ok {
+		return nil, false
+	}
+	values := j.ResolvedRunsOnLabels()
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values, true
+}
+
+// otherOSLabelPrefixes maps runner label prefixes of GitHub-hosted and
+// self-hosted non-Linux runners to the display name of their operating system.
+var otherOSLabelPrefixes = []struct {
+	prefix string
+	os     string
+}{
+	{"windows", "Windows"},
+	{"macos", "macOS"},
+}
+
+// OtherOperatingSystems returns the non-Linux operating systems a job runs on,
+// in order of first appearance (e.g. ["Windows", "macOS"] for a matrix over
+// windows-latest and macos-latest). Labels like windows-2022, macos-14-large or
+// a self-hosted runner's windows label count. It returns nil if runs-on can't
+// be resolved, or if any label names a Linux runner (ubuntu-*, linux), since
+// such jobs run on Linux at least in part.
+func (j *Job) OtherOperatingSystems() []string {
+	var systems []string
+	for _, label := range j.ResolvedRunsOnLabels() {
+		label = NormalizeLabel(label)
+		if label == "linux" || strings.HasPrefix(label, "ubuntu") {
+			return nil
+		}
+		for _, other := range otherOSLabelPrefixes {
+			if (label == other.prefix || strings.HasPrefix(label, other.prefix+"-")) && !slices.Contains(systems, other.os) {
+				systems = append(systems, other.os)
+			}
+		}
+	}
+	return systems
+}
+
+// ResolvedRunsOnLabels returns every concrete label the job could run on, in order
+// of first appearance. Matrix expressions like ${{ matrix.os }} in runs-on are
+// resolved against every combination of strategy.matrix, after exclude and include
+// entries are applied.
+// Returns nil if runs-on is not set or cannot be resolved statically, e.g. it
+// uses another expression, a runner group without labels, or a matrix built with fromJSON.
+func (j *Job) ResolvedRunsOnLabels() []string {
+	templates := j.RunsOnLabels()
+
+	var labels []string
+	add := func(label string) bool {
+		if label == "" || strings.Contains(label, "${{") {
+			return false
+		}
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+		return true
+	}
+
+	var combinations []map[string]any
+	for _, tmpl := range templates {
+		match := runsOnMatrixPattern.FindStringSubmatch(strings.TrimSpace(tmpl))
+		if match == nil {
+			if !add(tmpl) {
+				return nil
+			}
+			continue
+		}
+
+		if combinations == nil {
+			var ok bool
+			if combinations, ok = j.matrixCombinations(); !ok {
+				return nil
+			}
+		}
+		for _, combination := range combinations {
+			label, _ := combination[match[1]].(string)
+			if !add(label) {
+				return nil
+			}
+		}
+	}
+	return labels
+}
+
+// matrixCombinations expands the job's strategy.matrix into the job runs GitHub
+// Actions would create: the cartesian product of all keys, minus combinations
+// matching an exclude entry, extended by include entries.
+// An include entry is merged into every combination whose original values it does
+// not overwrite, or added as a new combination if there is none.
+// Returns false if the matrix is not set or cannot be resolved statically.
+func (j *Job) matrixCombinations() ([]map[string]any, bool) {
+	strategy, ok := j.Strategy.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	matrix, ok := strategy["matrix"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(matrix))
+	for key := range matrix {
+		if key != "include" && key != "exclude" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var combinations []map[string]any
+	for _, key := range keys {
+		var values []any
+		switch v := matrix[key].(type) {
+		case []any:
+			values = v
+		case string:
+			// An expression such as fromJSON(...) can't be expanded
+			if strings.Contains(v, "${{") {
+				return nil, false
+			}
+			values = []any{v}
+		default:
+			values = []any{v}
+		}
+
+		if combinations == nil {
+			combinations = []map[string]any{{}}
+		}
+		var expanded []map[string]any
+		for _, combination := range combinations {
+			for _, value := range values {
+				next := maps.Clone(combination)
+				next[key] = value
+				expanded = append(expanded, next)
+			}
+		}
+		combinations = expanded
+	}
+
+	if exclude, ok := matrix["exclude"].([]any); ok {
+		combinations = slices.DeleteFunc(combinations, func(combination map[string]any) bool {
+			for _, entry := range exclude {
+				if entryMap, ok := entry.(map[string]any); ok && matchesCombination(entryMap, combination) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if include, ok := matrix["include"].([]any); ok {
+		original := len(combinations)
+		for _, entry := range include {
+			entryMap, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			merged := false
+			for _, combination := range combinations[:original] {
+				if !matchesOriginal(entryMap, combination, keys) {
+					continue
+				}
+				maps.Copy(combination, entryMap)
+				merged = true
+			}
+			if !merged {
+				combinations = append(combinations, maps.Clone(entryMap))
+			}
+		}
+	}
+
+	return combinations, len(combinations) > 0
+}
+
+// matchesCombination reports whether every key of entry has the same value in combination.
+func matchesCombination(entry, combination map[string]any) bool {
+	for key, value := range entry {
+		if !reflect.DeepEqual(combination[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesOriginal reports whether entry can be merged into combination without
+// overwriting any of its original matrix values.
+func matchesOriginal(entry, combination map[string]any, originalKeys []string) bool {
+	for _, key := range originalKeys {
+		if value, ok := entry[key]; ok && !reflect.DeepEqual(combination[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDisabled reports whether the job's if: condition is a static false
+// (if: false, if: "false" or if: ${{ false }}), so the job never runs.
+func (j *Job) IsDisabled() bool {
+	value, ok := j.staticCondition()
+	return ok && !value
+}
+
+// Condition returns the job's if: expression when it can't be evaluated
+// statically, e.g. "${{ github.event_name == 'push' }}".
+// Returns "" if the job has no condition or it is a static true or false.
+func (j *Job) Condition() string {
+	if j.If == nil {
+		return ""
+	}
+	if _, ok := j.staticCondition(); ok {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(j.If))
+}
+
+// TimeoutMinutes returns the job's timeout-minutes if it is a positive
+// number, given as a YAML number or a numeric string such as "30".
+// Returns false if the job has no timeout or it is an expression, e.g.
+// "${{ inputs.timeout }}", whose value is unknown until the workflow runs.
+func (j *Job) TimeoutMinutes() (int, bool) {
+	var minutes float64
+	switch v := j.Timeout.(type) {
+	case int:
+		minutes = float64(v)
+	case float64:
+		minutes = v
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		minutes = f
 	default:
-		return false
+		return 0, false
 	}
+	if minutes <= 0 || math.IsNaN(minutes) || math.IsInf(minutes, 0) {
+		return 0, false
+	}
+	return int(math.Ceil(minutes)), true
+}
+
+// ignoreCommentPattern matches a "# slimify-ignore: <reason>" comment.
+var ignoreCommentPattern = regexp.MustCompile(`(?:^|\s)#\s*slimify-ignore:[ \t]*(\S.*?)\s*$`)
+
+// IgnoreJustification returns the reason given by a "# slimify-ignore: <reason>"
+// comment in the job block, which records why the job stays off ubuntu-slim.
+// Returns "" if the job has no such comment or its reason is empty.
+func (j *Job) IgnoreJustification() string {
+	for _, line := range j.Lines {
+		if m := ignoreCommentPattern.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// staticCondition evaluates the job's if: condition if its value is known
+// before the workflow runs, e.g. a boolean literal optionally wrapped in ${{ }}.
+func (j *Job) staticCondition() (bool, bool) {
+	return evaluateCondition(j.If, "")
+}
+
+// Dependencies returns the IDs of the jobs listed in the job's needs:, in the
+// order they are written. Returns nil if the job needs no other job.
+func (j *Job) Dependencies() []string {
+	switch needs := j.Needs.(type) {
+	case string:
+		if needs != "" {
+			return []string{needs}
+		}
+	case []interface{}:
+		var ids []string
+		for _, n := range needs {
+			if id, ok := n.(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+// IsReusableWorkflowCall reports whether the job calls a reusable workflow
+// (jobs.<job_id>.uses) instead of running steps itself.
+func (j *Job) IsReusableWorkflowCall() bool {
+	return j.Uses != ""
+}
+
+// LocalReusableWorkflowPath returns the path, relative to the repository root,
+// of the reusable workflow called by the job when it is in the same repository
+// (e.g. ./.github/workflows/build.yml).
+// Returns false for remote references like owner/repo/.github/workflows/build.yml@v1.
+func (j *Job) LocalReusableWorkflowPath() (string, bool) {
+	if !strings.HasPrefix(j.Uses, "./") {
+		return "", false
+	}
+	return filepath.Clean(j.Uses), true
 }
 
 // HasDockerCommands checks if a job uses Docker commands
 // It checks if the job uses any Docker commands in the run commands.
 // Matches patterns like "docker build", "docker-compose", "sudo docker run", etc.
 func (j *Job) HasDockerCommands() bool {
+	return len(j.DockerCommandSteps()) > 0
+}
+
+// DockerCommandSteps returns the 1-based indexes of steps whose run scripts use
+// container commands, as detected by HasDockerCommands. Scripts of shells that
+// don't run commands line by line, such as python, are skipped.
+func (j *Job) DockerCommandSteps() []int {
+	return j.DockerCommandStepsWith(nil, nil)
+}
+
+// newDockerCommandPattern returns a pattern matching docker invoked as a
+// command (also through sudo, $(...), a pipe, a quoted string or an absolute
+// path like /usr/bin/docker), optionally with global options (--flag,
+// --flag=value, or --config, --context, -H and the other options taking a
+// separate value), followed by one of subcommands. Words that merely contain
+// "docker" (docker-credential-helper, my-docker) and subcommand-like words
+// (docker builds-cache) are not matched. It returns nil if subcommands is
+// empty.
+func newDockerCommandPattern(subcommands []string) *regexp.Regexp {
+	if len(subcommands) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(subcommands))
+	for i, sub := range subcommands {
+		quoted[i] = regexp.QuoteMeta(sub)
+	}
+	return regexp.MustCompile(`(?m)(?:^|[\s;&|(\x60'"=/])docker(?:\s+(?:(?:--config|--context|-c|--host|-h|--log-level|-l|--tlscacert|--tlscert|--tlskey)\s+\S+|--?[a-z][\w-]*(?:=\S+)?))*\s+(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// DockerCommandStepsWith is like DockerCommandSteps but also detects commands
+// matching extraPatterns, which are matched against lower-cased scripts, and
+// doesn't detect docker commands whose subcommand is in safeDockerSubcommands
+// (e.g. ps or login, for runners where they work without a daemon).
+// Steps that never run on Linux are left out (see DockerCommandStepsSkippedOnLinux).
+func (j *Job) DockerCommandStepsWith(extraPatterns []*regexp.Regexp, safeDockerSubcommands []string) []int {
+	steps, _ := j.dockerCommandSteps(extraPatterns, safeDockerSubcommands)
+	return steps
+}
+
+// DockerCommandStepsSkippedOnLinux returns the 1-based indexes of steps that
+// use container commands, as detected by DockerCommandStepsWith, but whose if:
+// condition is false on Linux (see Step.SkippedOnLinux), so they never need a
+// Docker daemon on ubuntu-slim.
+func (j *Job) DockerCommandStepsSkippedOnLinux(extraPatterns []*regexp.Regexp, safeDockerSubcommands []string) []int {
+	_, skipped := j.dockerCommandSteps(extraPatterns, safeDockerSubcommands)
+	return skipped
+}
+
+// dockerCommandSteps returns the 1-based indexes of steps using container
+// commands, split by whether they can run on Linux.
+func (j *Job) dockerCommandSteps(extraPatterns []*regexp.Regexp, safeDockerSubcommands []string) (steps, skipped []int) {
+	docker := dockerCommandPattern
+	if len(safeDockerSubcommands) > 0 {
+		docker = newDockerCommandPattern(slices.DeleteFunc(slices.Clone(dockerDaemonSubcommands), func(sub string) bool {
+			return slices.Contains(safeDockerSubcommands, sub)
+		}))
+	}
+	patterns := slices.Concat(containerCommandPatterns, extraPatterns)
+	if docker != nil {
+		patterns = append(patterns, docker)
+	}
+	for i, step := range j.Steps {
+		if step.Run == "" || !runsCommandLines(j.StepShell(step)) {
+			continue
+		}
+
+		// Join continuation lines so "docker \" followed by "login" on the next
+		// line is seen as one command
+		runLower := strings.Join(joinContinuationLines(strings.ToLower(step.Run)), "\n")
+		// Check if run command, or a script it runs through bash -c or a
+		// command substitution, matches any container command pattern
+		scripts := append([]string{runLower}, nestedScripts(runLower)...)
+		if slices.ContainsFunc(scripts, func(script string) bool {
+			return slices.ContainsFunc(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(script)
+			})
+		}) {
+			if step.SkippedOnLinux() {
+				skipped = append(skipped, i+1)
+			} else {
+				steps = append(steps, i+1)
+			}
+		}
+	}
+	return steps, skipped
+}
+
+// LocalClusterTools returns the tools, such as kind, that steps of the job run
+// to create a local Kubernetes cluster, in the order first used. The cluster
+// nodes run as containers, so these steps need a Docker daemon. Steps that
+// never run on Linux are left out.
+func (j *Job) LocalClusterTools() []string {
+	var tools []string
 	for _, step := range j.Steps {
-		if step.Run == "" {
+		if step.Run == "" || !runsCommandLines(j.StepShell(step)) || step.SkippedOnLinux() {
 			continue
 		}
 
-		runLower := strings.ToLower(step.Run)
-		// Check if run command matches any container command pattern
-		for _, pattern := range containerCommandPatterns {
-			if pattern.MatchString(runLower) {
-				return true
+		runLower := strings.Join(joinContinuationLines(strings.ToLower(step.Run)), "\n")
+		scripts := append([]string{runLower}, nestedScripts(runLower)...)
+		for _, cmd := range localClusterCommands {
+			if slices.Contains(tools, cmd.tool) {
+				continue
+			}
+			if slices.ContainsFunc(scripts, cmd.pattern.MatchString) {
+				tools = append(tools, cmd.tool)
 			}
 		}
 	}
-	return false
+	return tools
 }
 
 // HasContainerActions checks if a job uses container-based GitHub Actions
@@ -132,7 +864,14 @@ func (j *Job) HasDockerCommands() bool {
 // - docker/ organization actions (e.g., "docker/build-push-action@v6")
 // Future container tools can be added by extending containerActionPrefixes.
 func (j *Job) HasContainerActions() bool {
-	for _, step := range j.Steps {
+	return len(j.ContainerActionSteps()) > 0
+}
+
+// ContainerActionSteps returns the 1-based indexes of steps that use
+// container-based GitHub Actions, as detected by HasContainerActions.
+func (j *Job) ContainerActionSteps() []int {
+	var steps []int
+	for i, step := range j.Steps {
 		if step.Uses == "" {
 			continue
 		}
@@ -140,11 +879,179 @@ func (j *Job) HasContainerActions() bool {
 		// Check if uses starts with any container action prefix
 		for _, prefix := range containerActionPrefixes {
 			if strings.HasPrefix(uses, prefix) {
-				return true
+				steps = append(steps, i+1)
+				break
+			}
+		}
+	}
+	return steps
+}
+
+// crossArchSetupActions are the actions that set up building images for other
+// architectures: QEMU emulation registered through binfmt, and the Buildx
+// builder running in a container.
+var crossArchSetupActions = []string{"docker/setup-qemu-action", "docker/setup-buildx-action"}
+
+// CrossArchSetupSteps returns the 1-based indexes of steps setting up
+// cross-architecture image builds with docker/setup-qemu-action or
+// docker/setup-buildx-action, and those actions in order of first use. The
+// steps are also container-based actions (see ContainerActionSteps), but call
+// for different guidance than other uses of Docker.
+func (j *Job) CrossArchSetupSteps() ([]int, []string) {
+	var steps []int
+	var actions []string
+	for i, step := range j.Steps {
+		name, _, _ := strings.Cut(step.Uses, "@")
+		if !slices.Contains(crossArchSetupActions, name) {
+			continue
+		}
+		steps = append(steps, i+1)
+		if !slices.Contains(actions, name) {
+			actions = append(actions, name)
+		}
+	}
+	return steps, actions
+}
+
+// DockerDependentActions returns the actions used by the job that need a Docker
+// daemon according to actions, without version, in order of first use.
+// An entry matches an action and its sub-actions (e.g. "github/codeql-action"
+// matches "github/codeql-action/analyze@v3"), and an entry ending in "/" matches
+// every action of an owner. Steps reported by ContainerActionSteps are skipped.
+func (j *Job) DockerDependentActions(actions []string) []string {
+	var found []string
+	containerSteps := j.ContainerActionSteps()
+	for i, step := range j.Steps {
+		if step.Uses == "" || slices.Contains(containerSteps, i+1) {
+			continue
+		}
+		name, _, _ := strings.Cut(step.Uses, "@")
+		for _, action := range actions {
+			if matchesAction(name, action) {
+				if !slices.Contains(found, name) {
+					found = append(found, name)
+				}
+				break
 			}
 		}
 	}
-	return false
+	return found
+}
+
+// matchesAction reports whether the action name (without version) is action,
+// one of its sub-actions, or an action of the owner if action ends in "/".
+// Owner and repository names are case-insensitive on GitHub.
+func matchesAction(name, action string) bool {
+	name, action = strings.ToLower(name), strings.ToLower(action)
+	if strings.HasSuffix(action, "/") {
+		return strings.HasPrefix(name, action)
+	}
+	return name == action || strings.HasPrefix(name, action+"/")
+}
+
+// SetupActions returns the official actions/setup-* actions used by the job
+// (e.g. actions/setup-node), without version, in order of first use.
+// These actions install tools that may expect packages present on ubuntu-latest,
+// so the tools should be verified on ubuntu-slim.
+func (j *Job) SetupActions() []string {
+	var actions []string
+	for _, step := range j.Steps {
+		action, _, _ := strings.Cut(step.Uses, "@")
+		if !strings.HasPrefix(action, "actions/setup-") {
+			continue
+		}
+		if !slices.Contains(actions, action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// cacheActions are the actions that save or restore a cache, whose path input
+// names the cached directories.
+var cacheActions = []string{"actions/cache", "actions/cache/restore", "actions/cache/save"}
+
+// dockerLayerCachePattern matches cache paths holding Docker image layers, such
+// as /tmp/.buildx-cache used with docker/build-push-action, or the Docker data
+// directory /var/lib/docker.
+var dockerLayerCachePattern = regexp.MustCompile(`(?i)buildx-cache|docker-cache|docker-layers?\b|/var/lib/docker\b`)
+
+// DockerLayerCacheSteps returns the 1-based indexes of steps caching Docker
+// image layers with actions/cache, according to the path in their with:
+// inputs. Caching layers hints that the job builds images, possibly through a
+// script or an action not detected as using Docker.
+func (j *Job) DockerLayerCacheSteps() []int {
+	var steps []int
+	for i, step := range j.Steps {
+		action, _, _ := strings.Cut(step.Uses, "@")
+		if !slices.Contains(cacheActions, action) {
+			continue
+		}
+		if path, ok := step.With["path"].(string); ok && dockerLayerCachePattern.MatchString(path) {
+			steps = append(steps, i+1)
+		}
+	}
+	return steps
+}
+
+// DefaultMinActionVersions maps actions to the oldest major version expected
+// to work on ubuntu-slim. Older versions (e.g. actions/checkout@v2) were built
+// for runtimes and tools of older runner images.
+var DefaultMinActionVersions = map[string]int{
+	"actions/checkout": 3,
+}
+
+// actionVersionPattern matches version refs like v2, v2.1 or 2.1.0 and captures the major version.
+var actionVersionPattern = regexp.MustCompile(`^v?(\d+)(?:\.\d+){0,2}$`)
+
+// actionMajorVersion returns the major version of an action ref (e.g. 2 for
+// v2.1.0). Returns false for refs that are not versions, like branches and SHAs.
+func actionMajorVersion(ref string) (int, bool) {
+	m := actionVersionPattern.FindStringSubmatch(ref)
+	if m == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
+
+// ParseMinActionVersion parses a minimum action version written as an action
+// reference, e.g. "actions/checkout@v3", into the action and its major version.
+func ParseMinActionVersion(s string) (string, int, error) {
+	action, ref, ok := strings.Cut(s, "@")
+	if !ok || action == "" {
+		return "", 0, fmt.Errorf("invalid minimum action version %q: want <action>@v<major>, e.g. actions/checkout@v3", s)
+	}
+	major, ok := actionMajorVersion(ref)
+	if !ok {
+		return "", 0, fmt.Errorf("invalid minimum action version %q: %q is not a version", s, ref)
+	}
+	return strings.ToLower(action), major, nil
+}
+
+// OutdatedActions returns the steps' actions, as "action@ref", pinned to a
+// major version older than the minimum for the action in minVersions, in order
+// of first use. Refs that are not versions, like branches and SHAs, are ignored
+// since their version is unknown.
+func (j *Job) OutdatedActions(minVersions map[string]int) []string {
+	var outdated []string
+	for _, step := range j.Steps {
+		action, ref, ok := strings.Cut(step.Uses, "@")
+		if !ok {
+			continue
+		}
+		minVersion, ok := minVersions[strings.ToLower(action)]
+		if !ok {
+			continue
+		}
+		if major, ok := actionMajorVersion(ref); ok && major < minVersion && !slices.Contains(outdated, step.Uses) {
+			outdated = append(outdated, step.Uses)
+		}
+	}
+	return outdated
 }
 
 // HasServices checks if a job uses services
@@ -155,29 +1062,45 @@ func (j *Job) HasServices() bool {
 	return j.Services != nil
 }
 
+// ServiceNames returns the sorted names of the service containers defined by the job.
+func (j *Job) ServiceNames() []string {
+	services, ok := j.Services.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // HasPrivilegedOperations checks if a job uses privileged operations
 // that require capabilities not available in non-privileged containers.
-// Returns whether privileged operations were found and a deduplicated list of command names.
+// Operations are detected with the patterns in privilegedOperations.
+// Scripts of non-POSIX shells, such as pwsh or python, are skipped.
+// Returns whether privileged operations were found and a deduplicated list of their names.
 func (j *Job) HasPrivilegedOperations() (bool, []string) {
 	seen := make(map[string]bool)
-	var cmds []string
+	var ops []string
 
 	for _, step := range j.Steps {
-		if step.Run == "" {
+		if step.Run == "" || !isPOSIXShell(j.StepShell(step)) {
 			continue
 		}
 
 		runLower := strings.ToLower(step.Run)
-		for _, match := range privilegedCommandPattern.FindAllStringSubmatch(runLower, -1) {
-			cmd := match[1]
-			if !seen[cmd] {
-				seen[cmd] = true
-				cmds = append(cmds, cmd)
+		for _, op := range privilegedOperations {
+			if !seen[op.name] && op.pattern.MatchString(runLower) {
+				seen[op.name] = true
+				ops = append(ops, op.name)
 			}
 		}
 	}
 
-	return len(cmds) > 0, cmds
+	return len(ops) > 0, ops
 }
 
 // HasContainer checks if a job uses the container: syntax
@@ -188,46 +1111,106 @@ func (j *Job) HasContainer() bool {
 	return j.Container != nil
 }
 
+// ContainerImage returns the image of the job's container, or "" if the job has
+// no container or the image is not set. Both the short form (container: node:20)
+// and the long form (container: {image: node:20}) are supported.
+func (j *Job) ContainerImage() string {
+	switch v := j.Container.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if image, ok := v["image"].(string); ok {
+			return image
+		}
+	}
+	return ""
+}
+
 // GetMissingCommands extracts commands from job steps and returns a list of commands
 // that exist in ubuntu-latest but are missing in ubuntu-slim.
 // It parses shell commands from step.Run fields and checks them against the
 // missing commands list.
 // Commands provided by setup actions (e.g., setup-go provides "go") are excluded
 // from the missing commands list since they will be available after the setup action runs.
+// Commands installed with apt-get install or apt install are excluded only after
+// the install: a command used before the line that installs it is still missing,
+// since that use would fail on ubuntu-slim.
+// Scripts of non-POSIX shells, such as pwsh or python, are skipped.
 func (j *Job) GetMissingCommands() []string {
-	if !j.IsUbuntuLatest() {
-		// Only check commands for ubuntu-latest jobs
+	return j.GetMissingCommandsFor([]string{"ubuntu-latest"}, nil)
+}
+
+// GetMissingCommandsFor is like GetMissingCommands but checks commands for jobs
+// running on any of sourceLabels instead of only ubuntu-latest.
+// availableCommands lists commands known to be installed on the target runner
+// (e.g. a customized ubuntu-slim image) in addition to the built-in ubuntu-slim
+// list. They are never reported as missing, the same way commands provided by
+// setup actions are not. Entries are normalized like commands found in steps,
+// so "/usr/bin/jq" and "jq" are equivalent.
+func (j *Job) GetMissingCommandsFor(sourceLabels []string, availableCommands []string) []string {
+	return j.GetMissingCommandsWith(sourceLabels, availableCommands, nil)
+}
+
+// GetMissingCommandsWith is like GetMissingCommandsFor but also reports the
+// commands in extraMissing, which are known to be missing on the target runner
+// although the built-in lists don't say so. availableCommands take precedence.
+func (j *Job) GetMissingCommandsWith(sourceLabels, availableCommands, extraMissing []string) []string {
+	if _, ok := j.MatchRunsOn(sourceLabels); !ok {
+		// Only check commands for jobs that are migration sources
 		return nil
 	}
 
-	// Collect commands provided by setup actions in this job
-	setupProvidedCommands := j.getSetupProvidedCommands()
+	// Collect commands provided by setup actions in this job or declared available
+	providedCommands := j.getSetupProvidedCommands()
+	for _, cmd := range availableCommands {
+		if cmdName := normalizeCommand(cmd); cmdName != "" {
+			providedCommands[cmdName] = true
+		}
+	}
+
+	knownMissing := make(map[string]bool)
+	for _, cmd := range extraMissing {
+		if cmdName := normalizeCommand(cmd); cmdName != "" {
+			knownMissing[cmdName] = true
+		}
+	}
 
 	var missingCommands []string
 	seen := make(map[string]bool)
+	// Commands installed with apt-get install or apt install so far. Unlike
+	// setup actions, installs only count for the lines after them
+	installedCommands := make(map[string]bool)
 
 	for _, step := range j.Steps {
-		if step.Run == "" {
+		if step.Run == "" || !isPOSIXShell(j.StepShell(step)) {
 			continue
 		}
 
-		commands := extractCommands(step.Run)
-		for _, cmd := range commands {
-			// Normalize command name (remove path, keep only basename)
-			cmdName := normalizeCommand(cmd)
-			if cmdName == "" {
-				continue
+		for _, line := range joinContinuationLines(step.Run) {
+			for _, pkg := range aptInstalledPackages(line) {
+				for _, cmdName := range commandsInstalledBy(pkg) {
+					installedCommands[cmdName] = true
+				}
 			}
 
-			// Skip if command is provided by a setup action
-			if setupProvidedCommands[cmdName] {
-				continue
-			}
+			for _, cmd := range extractCommands(line) {
+				// Normalize command name (remove path, keep only basename)
+				cmdName := normalizeCommand(cmd)
+				if cmdName == "" {
+					continue
+				}
 
-			// Check if command is missing in slim and not already added
-			if IsMissingInSlim(cmdName) && !seen[cmdName] {
-				missingCommands = append(missingCommands, cmdName)
-				seen[cmdName] = true
+				// Skip if command is provided by a setup action, declared available
+				// or installed by an earlier line
+				if providedCommands[cmdName] || installedCommands[cmdName] {
+					continue
+				}
+
+				// Check if command is missing in slim and not already added
+				if (IsMissingInSlim(cmdName) || knownMissing[cmdName]) && !seen[cmdName] {
+					missingCommands = append(missingCommands, cmdName)
+					seen[cmdName] = true
+				}
 			}
 		}
 	}
@@ -291,41 +1274,247 @@ func extractCommands(script string) []string {
 				commands = append(commands, cmd)
 			}
 		}
+
+		// Commands run by bash -c "..." or in $(...) and `...` substitutions
+		for _, nested := range nestedScripts(line) {
+			commands = append(commands, extractCommands(nested)...)
+		}
 	}
 
 	return commands
 }
 
-// splitCommandLine splits a command line by pipe, redirect, and logical operators
-// while preserving the command parts.
-func splitCommandLine(line string) []string {
-	// Split by |, &&, ||, ;, >, <, >>, <<
-	// Simple approach: split by these operators
-	parts := []string{line}
-	separators := []string{"|", "&&", "||", ";", ">>", "<<", ">", "<"}
+// joinContinuationLines splits a script into lines, joining lines that end with
+// a backslash to the next one, so a command split across lines is seen whole.
+func joinContinuationLines(script string) []string {
+	var lines []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		if trimmed := strings.TrimRight(line, " \t"); strings.HasSuffix(trimmed, "\\") {
+			current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		lines = append(lines, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
 
-	for _, sep := range separators {
-		var newParts []string
-		for _, part := range parts {
-			split := strings.Split(part, sep)
-			for i, s := range split {
-				s = strings.TrimSpace(s)
-				if s != "" {
-					if i == 0 {
-						newParts = append(newParts, s)
-					} else {
-						// For subsequent parts after separator, add them separately
-						newParts = append(newParts, s)
+// shellCommands are shells whose -c option takes a script to run.
+var shellCommands = map[string]bool{
+	"sh":   true,
+	"bash": true,
+	"dash": true,
+	"zsh":  true,
+	"ksh":  true,
+}
+
+// shellScriptFlag matches a shell's -c option, alone or combined with other
+// single-letter options (e.g. -ec, -xc).
+var shellScriptFlag = regexp.MustCompile(`^-[a-z]*c[a-z]*$`)
+
+// nestedScripts returns the scripts a shell line runs indirectly: the script
+// argument of sh -c, bash -c and similar, and the contents of $(...) and
+// backtick command substitutions. Substitutions inside single quotes are not
+// expanded by the shell and are skipped. Scripts nested further are not
+// returned; call nestedScripts on each result to descend.
+func nestedScripts(line string) []string {
+	var scripts []string
+
+	words := shellWords(line)
+	for i := 0; i+2 < len(words); i++ {
+		if shellCommands[normalizeCommand(words[i])] && shellScriptFlag.MatchString(words[i+1]) {
+			scripts = append(scripts, words[i+2])
+		}
+	}
+
+	inSingle, inDouble := false, false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && !inSingle:
+			i++
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		case inSingle:
+		case c == '`':
+			if end := strings.IndexByte(line[i+1:], '`'); end >= 0 {
+				scripts = append(scripts, line[i+1:i+1+end])
+				i += end + 1
+			}
+		case c == '$' && strings.HasPrefix(line[i+1:], "(") && !strings.HasPrefix(line[i+1:], "(("):
+			// Find the matching parenthesis, allowing nested ones
+			depth := 0
+			for j := i + 1; j < len(line); j++ {
+				if line[j] == '(' {
+					depth++
+				} else if line[j] == ')' {
+					depth--
+					if depth == 0 {
+						scripts = append(scripts, line[i+2:j])
+						i = j
+						break
 					}
 				}
 			}
 		}
-		parts = newParts
 	}
+	return scripts
+}
+
+// shellWords splits s into words the way a shell does, honoring single and
+// double quotes and backslash escapes, and returns the words with their quotes
+// removed. Operators are not separated from adjacent words.
+func shellWords(s string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0:
+				i++
+				word.WriteByte(s[i])
+			default:
+				word.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '\\' && i+1 < len(s):
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
 
+// splitCommandLine splits a command line into the commands separated by
+// pipes, logical operators and semicolons. Redirections (>, >>, <, <<, 2>&1...)
+// are dropped along with their targets, so that a file name is not taken for
+// a command. Operators inside single or double quotes or escaped with a
+// backslash are part of the command, e.g. in echo "a > b" or jq 'select(.x > 1)'.
+func splitCommandLine(line string) []string {
+	var parts []string
+	var part strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(part.String()); s != "" {
+			parts = append(parts, s)
+		}
+		part.Reset()
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := quotedEnd(line, i)
+			part.WriteString(line[i:end])
+			i = end - 1
+		case c == '\\' && i+1 < len(line):
+			part.WriteString(line[i : i+2])
+			i++
+		case c == '|' || c == ';':
+			flush()
+			if c == '|' && i+1 < len(line) && line[i+1] == '|' {
+				i++
+			}
+		case c == '&' && i+1 < len(line) && line[i+1] == '&':
+			flush()
+			i++
+		case c == '>' || c == '<':
+			// Drop a file descriptor number before the operator, as in 2>&1
+			text := part.String()
+			k := len(text)
+			for k > 0 && text[k-1] >= '0' && text[k-1] <= '9' {
+				k--
+			}
+			if k < len(text) && (k == 0 || text[k-1] == ' ' || text[k-1] == '\t') {
+				part.Reset()
+				part.WriteString(text[:k])
+			}
+			// Skip the rest of the operator (>>, >&, >|, <<-...) and its target
+			j := i + 1
+			for j < len(line) && strings.IndexByte("<>&|-", line[j]) >= 0 {
+				j++
+			}
+			for j < len(line) && (line[j] == ' ' || line[j] == '\t') {
+				j++
+			}
+			i = wordEnd(line, j) - 1
+		default:
+			part.WriteByte(c)
+		}
+	}
+	flush()
 	return parts
 }
 
+// quotedEnd returns the index after the quoted string starting at line[i],
+// which is a single or double quote, or len(line) if it is not closed.
+// Backslashes escape characters in double quotes only.
+func quotedEnd(line string, i int) int {
+	quote := line[i]
+	for j := i + 1; j < len(line); j++ {
+		switch {
+		case line[j] == quote:
+			return j + 1
+		case quote == '"' && line[j] == '\\':
+			j++
+		}
+	}
+	return len(line)
+}
+
+// wordEnd returns the index after the shell word starting at line[i], which
+// ends at unquoted whitespace or an operator character.
+func wordEnd(line string, i int) int {
+	for i < len(line) {
+		switch c := line[i]; {
+		case c == ' ' || c == '\t' || strings.IndexByte("|;&<>", c) >= 0:
+			return i
+		case c == '\'' || c == '"':
+			i = quotedEnd(line, i)
+		case c == '\\':
+			i += 2
+		default:
+			i++
+		}
+	}
+	return len(line)
+}
+
 // extractCommandFromPart extracts the command name from a command part.
 // It handles prefixes like sudo, env, time, etc.
 func extractCommandFromPart(part string) string {
@@ -335,8 +1524,8 @@ func extractCommandFromPart(part string) string {
 	}
 
 	// Handle variable assignments (VAR=value command)
-	// Split by space first to handle cases like "VAR=value command"
-	fields := strings.Fields(part)
+	// Split into words first to handle cases like "VAR='a b' command"
+	fields := shellWords(part)
 	if len(fields) == 0 {
 		return ""
 	}
@@ -355,13 +1544,7 @@ func extractCommandFromPart(part string) string {
 		return ""
 	}
 
-	part = strings.Join(fields[startIndex:], " ")
-
-	// Re-extract fields after handling variable assignments
-	fields = strings.Fields(part)
-	if len(fields) == 0 {
-		return ""
-	}
+	fields = fields[startIndex:]
 
 	// Common prefixes to skip
 	prefixes := []string{"sudo", "env", "time", "nohup", "setsid", "stdbuf"}
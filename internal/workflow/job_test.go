@@ -3,6 +3,7 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"testing"
 )
@@ -20,6 +21,13 @@ func TestJob_IsUbuntuSlim(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "mixed case with surrounding spaces",
+			job: &Job{
+				RunsOn: " Ubuntu-Slim ",
+			},
+			expected: true,
+		},
 		{
 			name: "ubuntu-latest string",
 			job: &Job{
@@ -63,12 +71,19 @@ func TestJob_IsUbuntuSlim(t *testing.T) {
 			expected: false,
 		},
 		{
-			name: "unsupported type - map",
+			name: "map without labels",
 			job: &Job{
 				RunsOn: map[string]interface{}{"os": "ubuntu-slim"},
 			},
 			expected: false,
 		},
+		{
+			name: "object with ubuntu-slim label",
+			job: &Job{
+				RunsOn: map[string]interface{}{"group": "slim-runners", "labels": []interface{}{"ubuntu-slim"}},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,6 +96,675 @@ func TestJob_IsUbuntuSlim(t *testing.T) {
 	}
 }
 
+func TestJob_IsSlim(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want bool
+	}{
+		{name: "custom label", job: &Job{RunsOn: "self-hosted-slim"}, want: true},
+		{name: "custom label in a label list", job: &Job{RunsOn: []any{"self-hosted", "self-hosted-slim"}}, want: true},
+		{name: "ubuntu-slim is not the custom label", job: &Job{RunsOn: "ubuntu-slim"}, want: false},
+		{name: "custom label in another case", job: &Job{RunsOn: " Self-Hosted-Slim"}, want: true},
+		{
+			name: "matrix of the custom label",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"self-hosted-slim"}}},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.IsSlim("self-hosted-slim"); got != tt.want {
+				t.Errorf("IsSlim(self-hosted-slim) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_RunsOnLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		runsOn     any
+		want       []string
+		wantLatest bool
+		wantSlim   bool
+	}{
+		{name: "not set", runsOn: nil, want: nil},
+		{name: "scalar", runsOn: "ubuntu-latest", want: []string{"ubuntu-latest"}, wantLatest: true},
+		{name: "scalar ubuntu-slim", runsOn: "ubuntu-slim", want: []string{"ubuntu-slim"}, wantSlim: true},
+		{name: "empty scalar", runsOn: "", want: []string{""}},
+		{name: "list", runsOn: []any{"self-hosted", "ubuntu-latest"}, want: []string{"self-hosted", "ubuntu-latest"}},
+		{name: "list with ubuntu-slim", runsOn: []any{"ubuntu-slim"}, want: []string{"ubuntu-slim"}, wantSlim: true},
+		{name: "list with non-string items", runsOn: []any{"linux", 42, nil}, want: []string{"linux"}},
+		{name: "empty list", runsOn: []any{}, want: nil},
+		{name: "object with label list", runsOn: map[string]any{"group": "ubuntu-runners", "labels": []any{"ubuntu-latest", "x64"}}, want: []string{"ubuntu-latest", "x64"}},
+		{name: "object with single label", runsOn: map[string]any{"labels": "ubuntu-slim"}, want: []string{"ubuntu-slim"}, wantSlim: true},
+		{name: "object with group only", runsOn: map[string]any{"group": "ubuntu-runners"}, want: nil},
+		{name: "matrix expression as written", runsOn: "${{ matrix.os }}", want: []string{"${{ matrix.os }}"}},
+		{name: "unsupported type", runsOn: 42, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{RunsOn: tt.runsOn}
+			if got := job.RunsOnLabels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RunsOnLabels() = %#v, want %#v", got, tt.want)
+			}
+			if got := job.IsUbuntuLatest(); got != tt.wantLatest {
+				t.Errorf("IsUbuntuLatest() = %v, want %v", got, tt.wantLatest)
+			}
+			if got := job.IsUbuntuSlim(); got != tt.wantSlim {
+				t.Errorf("IsUbuntuSlim() = %v, want %v", got, tt.wantSlim)
+			}
+		})
+	}
+}
+
+func TestJob_MatchRunsOn(t *testing.T) {
+	tests := []struct {
+		name      string
+		job       *Job
+		labels    []string
+		wantLabel string
+		wantMatch bool
+	}{
+		{
+			name:      "string matches",
+			job:       &Job{RunsOn: "ubuntu-24.04"},
+			labels:    []string{"ubuntu-latest", "ubuntu-24.04"},
+			wantLabel: "ubuntu-24.04",
+			wantMatch: true,
+		},
+		{
+			name:      "string does not match",
+			job:       &Job{RunsOn: "ubuntu-22.04"},
+			labels:    []string{"ubuntu-latest"},
+			wantMatch: false,
+		},
+		{
+			name:      "array matches",
+			job:       &Job{RunsOn: []interface{}{"ubuntu-22.04"}},
+			labels:    []string{"ubuntu-22.04"},
+			wantLabel: "ubuntu-22.04",
+			wantMatch: true,
+		},
+		{
+			name:      "self-hosted label set",
+			job:       &Job{RunsOn: []any{"self-hosted", "linux"}},
+			labels:    []string{"ubuntu-latest", "linux"},
+			wantMatch: false,
+		},
+		{
+			name:      "label set including a source label",
+			job:       &Job{RunsOn: []any{"self-hosted", "ubuntu-latest"}},
+			labels:    []string{"ubuntu-latest"},
+			wantMatch: false,
+		},
+		{
+			name:      "mixed case returns the configured label",
+			job:       &Job{RunsOn: "Ubuntu-24.04"},
+			labels:    []string{"ubuntu-24.04"},
+			wantLabel: "ubuntu-24.04",
+			wantMatch: true,
+		},
+		{
+			name:      "quoted with surrounding spaces",
+			job:       &Job{RunsOn: []interface{}{" ubuntu-latest "}},
+			labels:    []string{"ubuntu-latest"},
+			wantLabel: "ubuntu-latest",
+			wantMatch: true,
+		},
+		{
+			name: "mixed case matrix values",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"Ubuntu-Latest", "UBUNTU-24.04"}}},
+			},
+			labels:    []string{"ubuntu-latest", "ubuntu-24.04"},
+			wantLabel: "ubuntu-latest",
+			wantMatch: true,
+		},
+		{
+			name:      "nil runs-on",
+			job:       &Job{RunsOn: nil},
+			labels:    []string{"ubuntu-latest"},
+			wantMatch: false,
+		},
+		{
+			name:      "no labels",
+			job:       &Job{RunsOn: "ubuntu-latest"},
+			labels:    nil,
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLabel, gotMatch := tt.job.MatchRunsOn(tt.labels)
+			if gotMatch != tt.wantMatch || gotLabel != tt.wantLabel {
+				t.Errorf("MatchRunsOn() = (%q, %v), want (%q, %v)", gotLabel, gotMatch, tt.wantLabel, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestJob_RunsOnMatrixValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        *Job
+		wantValues []string
+		wantOK     bool
+	}{
+		{
+			name: "matrix list",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"ubuntu-latest", "windows-latest"}}},
+			},
+			wantValues: []string{"ubuntu-latest", "windows-latest"},
+			wantOK:     true,
+		},
+		{
+			name: "compact expression with include and duplicates",
+			job: &Job{
+				RunsOn: "${{matrix.runner}}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"runner": []any{"ubuntu-latest"},
+					"include": []any{
+						map[string]any{"runner": "ubuntu-latest", "go": "1.22"},
+						map[string]any{"runner": "macos-latest"},
+						map[string]any{"go": "1.23"},
+					},
+				}},
+			},
+			wantValues: []string{"ubuntu-latest", "macos-latest"},
+			wantOK:     true,
+		},
+		{
+			name: "include only",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"include": []any{map[string]any{"os": "ubuntu-latest"}},
+				}},
+			},
+			wantValues: []string{"ubuntu-latest"},
+			wantOK:     true,
+		},
+		{
+			name: "fromJSON matrix",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": "${{ fromJSON(needs.setup.outputs.os) }}"}},
+			},
+			wantOK: false,
+		},
+		{
+			name: "whole matrix from expression",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": "${{ fromJSON(needs.setup.outputs.matrix) }}"},
+			},
+			wantOK: false,
+		},
+		{
+			name: "key not in matrix",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"go": []any{"1.22"}}},
+			},
+			wantOK: false,
+		},
+		{
+			name:   "no strategy",
+			job:    &Job{RunsOn: "${{ matrix.os }}"},
+			wantOK: false,
+		},
+		{
+			name:   "plain label",
+			job:    &Job{RunsOn: "ubuntu-latest"},
+			wantOK: false,
+		},
+		{
+			name:   "other expression",
+			job:    &Job{RunsOn: "${{ inputs.runner }}"},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.job.RunsOnMatrixValues()
+			if ok != tt.wantOK {
+				t.Fatalf("RunsOnMatrixValues() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("RunsOnMatrixValues() = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestJob_RunsOnVariables(t *testing.T) {
+	values := map[string]string{"vars.LINUX_RUNNER": "ubuntu-latest"}
+	tests := []struct {
+		name         string
+		job          *Job
+		want         []string
+		wantResolved []string
+	}{
+		{
+			name:         "plain label",
+			job:          &Job{RunsOn: "ubuntu-latest"},
+			wantResolved: []string{"ubuntu-latest"},
+		},
+		{
+			name:         "configuration variable",
+			job:          &Job{RunsOn: "${{ vars.LINUX_RUNNER }}"},
+			want:         []string{"vars.LINUX_RUNNER"},
+			wantResolved: []string{"ubuntu-latest"},
+		},
+		{
+			name:         "unknown environment variable is kept",
+			job:          &Job{RunsOn: "${{env.RUNNER}}"},
+			want:         []string{"env.RUNNER"},
+			wantResolved: []string{"${{env.RUNNER}}"},
+		},
+		{
+			name:         "label list",
+			job:          &Job{RunsOn: []any{"self-hosted", "${{ vars.LINUX_RUNNER }}"}},
+			want:         []string{"vars.LINUX_RUNNER"},
+			wantResolved: []string{"self-hosted", "ubuntu-latest"},
+		},
+		{
+			name:         "labels of a runner group",
+			job:          &Job{RunsOn: map[string]any{"group": "large", "labels": "${{ vars.LINUX_RUNNER }}"}},
+			want:         []string{"vars.LINUX_RUNNER"},
+			wantResolved: []string{"ubuntu-latest"},
+		},
+		{
+			name:         "other expressions are not variables",
+			job:          &Job{RunsOn: "${{ inputs.runner }}"},
+			wantResolved: []string{"${{ inputs.runner }}"},
+		},
+		{
+			name:         "variable of another context with the same name is kept",
+			job:          &Job{RunsOn: "${{ env.LINUX_RUNNER }}"},
+			want:         []string{"env.LINUX_RUNNER"},
+			wantResolved: []string{"${{ env.LINUX_RUNNER }}"},
+		},
+		{
+			name:         "secret",
+			job:          &Job{RunsOn: "${{ secrets.RUNNER }}"},
+			want:         []string{"secrets.RUNNER"},
+			wantResolved: []string{"${{ secrets.RUNNER }}"},
+		},
+		{
+			name:         "github context",
+			job:          &Job{RunsOn: "${{ github.event.inputs.runner }}"},
+			want:         []string{"github.event.inputs.runner"},
+			wantResolved: []string{"${{ github.event.inputs.runner }}"},
+		},
+		{
+			name: "matrix values",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"${{ vars.LINUX_RUNNER }}", "ubuntu-22.04"},
+					"include": []any{map[string]any{"os": "${{ secrets.RUNNER }}"}, map[string]any{"node": 20}},
+				}},
+			},
+			want:         []string{"vars.LINUX_RUNNER", "secrets.RUNNER"},
+			wantResolved: []string{"${{ matrix.os }}"},
+		},
+		{
+			name: "matrix values of another key",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"ubuntu-latest"}, "runner": []any{"${{ vars.LINUX_RUNNER }}"}}},
+			},
+			wantResolved: []string{"${{ matrix.os }}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.RunsOnVariables(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RunsOnVariables() = %v, want %v", got, tt.want)
+			}
+			before := tt.job.RunsOnLabels()
+			if got := tt.job.WithRunsOnVariables(values).RunsOnLabels(); !reflect.DeepEqual(got, tt.wantResolved) {
+				t.Errorf("WithRunsOnVariables().RunsOnLabels() = %v, want %v", got, tt.wantResolved)
+			}
+			if after := tt.job.RunsOnLabels(); !reflect.DeepEqual(after, before) {
+				t.Errorf("WithRunsOnVariables() modified the job: runs-on %v, was %v", after, before)
+			}
+		})
+	}
+}
+
+func TestJob_WithRunsOnVariables_Matrix(t *testing.T) {
+	job := &Job{
+		RunsOn: "${{ matrix.os }}",
+		Strategy: map[string]any{"matrix": map[string]any{
+			"os":      []any{"${{ vars.LINUX_RUNNER }}", "ubuntu-22.04"},
+			"include": []any{map[string]any{"os": "${{ env.RUNNER }}", "experimental": true}},
+		}},
+	}
+	before := job.matrixValues("os")
+
+	resolved := job.WithRunsOnVariables(map[string]string{"vars.LINUX_RUNNER": "ubuntu-latest", "env.RUNNER": "ubuntu-24.04"})
+	if got, want := resolved.ResolvedRunsOnLabels(), []string{"ubuntu-latest", "ubuntu-22.04", "ubuntu-24.04"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WithRunsOnVariables().ResolvedRunsOnLabels() = %v, want %v", got, want)
+	}
+	if got := resolved.RunsOnVariables(); got != nil {
+		t.Errorf("WithRunsOnVariables().RunsOnVariables() = %v, want none", got)
+	}
+	if after := job.matrixValues("os"); !reflect.DeepEqual(after, before) {
+		t.Errorf("WithRunsOnVariables() modified the matrix: %v, was %v", after, before)
+	}
+
+	partial := job.WithRunsOnVariables(map[string]string{"vars.LINUX_RUNNER": "ubuntu-latest"})
+	if got, want := partial.RunsOnVariables(), []string{"env.RUNNER"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RunsOnVariables() after partial resolution = %v, want %v", got, want)
+	}
+	if got := partial.ResolvedRunsOnLabels(); got != nil {
+		t.Errorf("ResolvedRunsOnLabels() after partial resolution = %v, want nil", got)
+	}
+}
+
+func TestJob_Dependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want []string
+	}{
+		{name: "no needs", job: &Job{}},
+		{name: "single job", job: &Job{Needs: "build"}, want: []string{"build"}},
+		{name: "list of jobs", job: &Job{Needs: []any{"lint", "build"}}, want: []string{"lint", "build"}},
+		{name: "non-string entry is skipped", job: &Job{Needs: []any{"build", 1}}, want: []string{"build"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.Dependencies(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ResolvedRunsOnLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want []string
+	}{
+		{
+			name: "plain label",
+			job:  &Job{RunsOn: "ubuntu-latest"},
+			want: []string{"ubuntu-latest"},
+		},
+		{
+			name: "label array",
+			job:  &Job{RunsOn: []any{"self-hosted", "linux"}},
+			want: []string{"self-hosted", "linux"},
+		},
+		{
+			name: "cartesian product with duplicates",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os": []any{"ubuntu-latest", "windows-latest"},
+					"go": []any{"1.22", "1.23"},
+				}},
+			},
+			want: []string{"ubuntu-latest", "windows-latest"},
+		},
+		{
+			name: "exclude removes every combination of an OS",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os": []any{"ubuntu-latest", "windows-latest"},
+					"go": []any{"1.22", "1.23"},
+					"exclude": []any{
+						map[string]any{"os": "windows-latest", "go": "1.22"},
+						map[string]any{"os": "windows-latest", "go": "1.23"},
+					},
+				}},
+			},
+			want: []string{"ubuntu-latest"},
+		},
+		{
+			name: "partial exclude keeps the OS",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"ubuntu-latest", "windows-latest"},
+					"go":      []any{"1.22", "1.23"},
+					"exclude": []any{map[string]any{"os": "windows-latest", "go": "1.22"}},
+				}},
+			},
+			want: []string{"ubuntu-latest", "windows-latest"},
+		},
+		{
+			name: "include adds a new combination",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"ubuntu-latest"},
+					"include": []any{map[string]any{"os": "macos-latest", "experimental": true}},
+				}},
+			},
+			want: []string{"ubuntu-latest", "macos-latest"},
+		},
+		{
+			name: "include extends existing combinations",
+			job: &Job{
+				RunsOn: "${{ matrix.runner }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"go": []any{"1.22", "1.23"},
+					"include": []any{
+						map[string]any{"runner": "ubuntu-latest"},
+						map[string]any{"go": "1.23", "runner": "ubuntu-24.04"},
+					},
+				}},
+			},
+			want: []string{"ubuntu-latest", "ubuntu-24.04"},
+		},
+		{
+			name: "include after exclude",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"ubuntu-latest", "windows-latest"},
+					"exclude": []any{map[string]any{"os": "windows-latest"}},
+					"include": []any{map[string]any{"os": "windows-latest", "arch": "arm64"}},
+				}},
+			},
+			want: []string{"ubuntu-latest", "windows-latest"},
+		},
+		{
+			name: "include only",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"include": []any{
+						map[string]any{"os": "ubuntu-latest"},
+						map[string]any{"os": "macos-latest"},
+					},
+				}},
+			},
+			want: []string{"ubuntu-latest", "macos-latest"},
+		},
+		{
+			name: "everything excluded",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"ubuntu-latest"},
+					"exclude": []any{map[string]any{"os": "ubuntu-latest"}},
+				}},
+			},
+			want: nil,
+		},
+		{
+			name: "combination without the key",
+			job: &Job{
+				RunsOn: "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{
+					"os":      []any{"ubuntu-latest"},
+					"include": []any{map[string]any{"os": "ubuntu-latest"}, map[string]any{"go": "1.23", "os": nil}},
+				}},
+			},
+			want: nil,
+		},
+		{
+			name: "fromJSON values",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": "${{ fromJSON(needs.setup.outputs.os) }}"}},
+			},
+			want: nil,
+		},
+		{
+			name: "other expression",
+			job:  &Job{RunsOn: "${{ inputs.runner }}"},
+			want: nil,
+		},
+		{
+			name: "runner group",
+			job:  &Job{RunsOn: map[string]any{"group": "large"}},
+			want: nil,
+		},
+		{
+			name: "not set",
+			job:  &Job{},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.job.ResolvedRunsOnLabels()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolvedRunsOnLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_OtherOperatingSystems(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *Job
+		want []string
+	}{
+		{name: "windows-latest", job: &Job{RunsOn: "windows-latest"}, want: []string{"Windows"}},
+		{name: "pinned macOS with size", job: &Job{RunsOn: "macos-14-large"}, want: []string{"macOS"}},
+		{name: "self-hosted windows", job: &Job{RunsOn: []any{"self-hosted", "Windows", "X64"}}, want: []string{"Windows"}},
+		{
+			name: "matrix over windows and macOS",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"windows-latest", "macos-latest"}}},
+			},
+			want: []string{"Windows", "macOS"},
+		},
+		{
+			name: "matrix including ubuntu",
+			job: &Job{
+				RunsOn:   "${{ matrix.os }}",
+				Strategy: map[string]any{"matrix": map[string]any{"os": []any{"ubuntu-latest", "windows-latest"}}},
+			},
+			want: nil,
+		},
+		{name: "pinned ubuntu", job: &Job{RunsOn: "ubuntu-22.04"}, want: nil},
+		{name: "self-hosted linux", job: &Job{RunsOn: []any{"self-hosted", "linux"}}, want: nil},
+		{name: "label that only contains an OS name", job: &Job{RunsOn: "windowsill"}, want: nil},
+		{name: "runs-on not set", job: &Job{}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.OtherOperatingSystems(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OtherOperatingSystems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_MatchRunsOn_Matrix(t *testing.T) {
+	matrixJob := func(values ...any) *Job {
+		return &Job{
+			RunsOn:   "${{ matrix.os }}",
+			Strategy: map[string]any{"matrix": map[string]any{"os": values}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		job        *Job
+		labels     []string
+		wantMatch  bool
+		wantLatest bool
+		wantSlim   bool
+		wantLabel  string
+	}{
+		{
+			name:       "all ubuntu-latest",
+			job:        matrixJob("ubuntu-latest"),
+			labels:     []string{"ubuntu-latest"},
+			wantMatch:  true,
+			wantLatest: true,
+			wantLabel:  "ubuntu-latest",
+		},
+		{
+			name:   "mixed matrix",
+			job:    matrixJob("ubuntu-latest", "windows-latest"),
+			labels: []string{"ubuntu-latest"},
+		},
+		{
+			name:      "mixed matrix with all values as sources",
+			job:       matrixJob("ubuntu-latest", "ubuntu-24.04"),
+			labels:    []string{"ubuntu-latest", "ubuntu-24.04"},
+			wantMatch: true,
+			wantLabel: "ubuntu-latest",
+		},
+		{
+			name:     "all ubuntu-slim",
+			job:      matrixJob("ubuntu-slim"),
+			labels:   []string{"ubuntu-latest"},
+			wantSlim: true,
+		},
+		{
+			name:   "matrix of label sets",
+			job:    matrixJob([]any{"self-hosted", "ubuntu-latest"}),
+			labels: []string{"ubuntu-latest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, ok := tt.job.MatchRunsOn(tt.labels)
+			if ok != tt.wantMatch || label != tt.wantLabel {
+				t.Errorf("MatchRunsOn() = (%q, %v), want (%q, %v)", label, ok, tt.wantLabel, tt.wantMatch)
+			}
+			if got := tt.job.IsUbuntuLatest(); got != tt.wantLatest {
+				t.Errorf("IsUbuntuLatest() = %v, want %v", got, tt.wantLatest)
+			}
+			if got := tt.job.IsUbuntuSlim(); got != tt.wantSlim {
+				t.Errorf("IsUbuntuSlim() = %v, want %v", got, tt.wantSlim)
+			}
+		})
+	}
+}
+
 func TestJob_IsUbuntuLatest_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -88,127 +772,323 @@ func TestJob_IsUbuntuLatest_EdgeCases(t *testing.T) {
 		expected bool
 	}{
 		{
-			name: "empty string",
+			name: "empty string",
+			job: &Job{
+				RunsOn: "",
+			},
+			expected: false,
+		},
+		{
+			name: "ubuntu-latest with whitespace",
+			job: &Job{
+				RunsOn: "  ubuntu-latest  ",
+			},
+			expected: true, // GitHub trims labels
+		},
+		{
+			name: "quoted with a trailing space",
+			job: &Job{
+				RunsOn: "ubuntu-latest ",
+			},
+			expected: true,
+		},
+		{
+			name: "mixed case",
+			job: &Job{
+				RunsOn: "Ubuntu-Latest",
+			},
+			expected: true, // GitHub compares labels regardless of case
+		},
+		{
+			name: "mixed case in a list",
+			job: &Job{
+				RunsOn: []interface{}{"UBUNTU-LATEST"},
+			},
+			expected: true,
+		},
+		{
+			name: "ubuntu-latest-extra",
+			job: &Job{
+				RunsOn: "ubuntu-latest-extra",
+			},
+			expected: false,
+		},
+		{
+			name: "empty array",
+			job: &Job{
+				RunsOn: []interface{}{},
+			},
+			expected: false,
+		},
+		{
+			name: "array with non-string items",
+			job: &Job{
+				RunsOn: []interface{}{123, true, nil},
+			},
+			expected: false,
+		},
+		{
+			name: "label set with ubuntu-latest at end",
+			job: &Job{
+				RunsOn: []interface{}{"ubuntu-22.04", "macos-latest", "ubuntu-latest"},
+			},
+			expected: false,
+		},
+		{
+			name: "unsupported type - int",
+			job: &Job{
+				RunsOn: 123,
+			},
+			expected: false,
+		},
+		{
+			name: "unsupported type - map",
+			job: &Job{
+				RunsOn: map[string]interface{}{"os": "ubuntu-latest"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.job.IsUbuntuLatest()
+			if got != tt.expected {
+				t.Errorf("IsUbuntuLatest() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJob_HasDockerCommands_EdgeCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      *Job
+		expected bool
+	}{
+		{
+			name: "empty steps",
+			job: &Job{
+				Steps: []Step{},
+			},
+			expected: false,
+		},
+		{
+			name: "step with empty run",
+			job: &Job{
+				Steps: []Step{{Run: ""}},
+			},
+			expected: false,
+		},
+		{
+			name: "docker command in bash -c",
+			job: &Job{
+				Steps: []Step{{Run: `bash -c "docker run x"`}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker command in sh -ec with single quotes",
+			job: &Job{
+				Steps: []Step{{Run: `sh -ec 'cd app && docker build -t "my app" .'`}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker command in backtick substitution",
+			job: &Job{
+				Steps: []Step{{Run: "ID=`docker ps -q`"}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker word in bash -c string is not a command",
+			job: &Job{
+				Steps: []Step{{Run: `bash -c "echo dockerfile run"`}},
+			},
+			expected: false,
+		},
+		{
+			name: "docker command in comment",
+			job: &Job{
+				Steps: []Step{{Run: "# docker build should not match"}},
+			},
+			expected: true, // Current implementation detects docker in comments too
+		},
+		{
+			name: "docker command with prefix",
+			job: &Job{
+				Steps: []Step{{Run: "sudo docker build -t app ."}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker command in multi-line script",
+			job: &Job{
+				Steps: []Step{{
+					Run: `#!/bin/bash
+set -e
+echo "Building"
+docker build -t app .
+echo "Done"`,
+				}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker command in variable",
+			job: &Job{
+				Steps: []Step{{Run: "CMD='docker build'; $CMD"}},
+			},
+			expected: true,
+		},
+		{
+			name: "absolute path docker build",
+			job: &Job{
+				Steps: []Step{{Run: "/usr/bin/docker build -t app ."}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker buildx build",
+			job: &Job{
+				Steps: []Step{{Run: "docker buildx build --platform linux/amd64 ."}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker with global options",
+			job: &Job{
+				Steps: []Step{{Run: "docker --context=remote --debug run alpine"}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker management command",
+			job: &Job{
+				Steps: []Step{{Run: "docker image push ghcr.io/org/app"}},
+			},
+			expected: true,
+		},
+		{
+			name: "docker in command substitution",
 			job: &Job{
-				RunsOn: "",
+				Steps: []Step{{Run: "id=$(docker ps -q)"}},
 			},
-			expected: false,
+			expected: true,
 		},
 		{
-			name: "ubuntu-latest with whitespace",
+			name: "docker with extra spaces",
 			job: &Job{
-				RunsOn: "  ubuntu-latest  ",
+				Steps: []Step{{Run: "docker   build ."}},
 			},
-			expected: false, // Exact match required
+			expected: true,
 		},
 		{
-			name: "ubuntu-latest-extra",
+			name: "echo docker",
 			job: &Job{
-				RunsOn: "ubuntu-latest-extra",
+				Steps: []Step{{Run: "echo docker"}},
 			},
 			expected: false,
 		},
 		{
-			name: "empty array",
+			name: "docker credential helper",
 			job: &Job{
-				RunsOn: []interface{}{},
+				Steps: []Step{{Run: "docker-credential-helper list"}},
 			},
 			expected: false,
 		},
 		{
-			name: "array with non-string items",
+			name: "subcommand-like word",
 			job: &Job{
-				RunsOn: []interface{}{123, true, nil},
+				Steps: []Step{{Run: "docker builds-cache"}},
 			},
 			expected: false,
 		},
 		{
-			name: "array with ubuntu-latest at end",
+			name: "docker as part of another word",
 			job: &Job{
-				RunsOn: []interface{}{"ubuntu-22.04", "macos-latest", "ubuntu-latest"},
+				Steps: []Step{{Run: "my-docker build"}},
 			},
-			expected: true,
+			expected: false,
 		},
 		{
-			name: "unsupported type - int",
+			name: "docker version only",
 			job: &Job{
-				RunsOn: 123,
+				Steps: []Step{{Run: "docker --version"}},
 			},
 			expected: false,
 		},
 		{
-			name: "unsupported type - map",
+			name: "sudo podman push",
 			job: &Job{
-				RunsOn: map[string]interface{}{"os": "ubuntu-latest"},
+				Steps: []Step{{Run: "sudo podman push quay.io/org/app:latest"}},
 			},
-			expected: false,
+			expected: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := tt.job.IsUbuntuLatest()
-			if got != tt.expected {
-				t.Errorf("IsUbuntuLatest() = %v, want %v", got, tt.expected)
-			}
-		})
-	}
-}
-
-func TestJob_HasDockerCommands_EdgeCases(t *testing.T) {
-	tests := []struct {
-		name     string
-		job      *Job
-		expected bool
-	}{
 		{
-			name: "empty steps",
+			name: "buildah from",
 			job: &Job{
-				Steps: []Step{},
+				Steps: []Step{{Run: "ctr=$(buildah from alpine)"}},
 			},
-			expected: false,
+			expected: true,
 		},
 		{
-			name: "step with empty run",
+			name: "docker buildx bake",
 			job: &Job{
-				Steps: []Step{{Run: ""}},
+				Steps: []Step{{Run: "docker buildx bake -f docker-bake.hcl --push"}},
 			},
-			expected: false,
+			expected: true,
 		},
 		{
-			name: "docker command in comment",
+			name: "docker buildx build",
 			job: &Job{
-				Steps: []Step{{Run: "# docker build should not match"}},
+				Steps: []Step{{Run: "docker buildx build --platform linux/amd64,linux/arm64 -t app ."}},
 			},
-			expected: true, // Current implementation detects docker in comments too
+			expected: true,
 		},
 		{
-			name: "docker command with prefix",
+			name: "docker buildx create",
 			job: &Job{
-				Steps: []Step{{Run: "sudo docker build -t app ."}},
+				Steps: []Step{{Run: "docker buildx create --use --driver docker-container"}},
 			},
 			expected: true,
 		},
 		{
-			name: "docker command in multi-line script",
+			name: "buildx plugin binary",
 			job: &Job{
-				Steps: []Step{{
-					Run: `#!/bin/bash
-set -e
-echo "Building"
-docker build -t app .
-echo "Done"`,
-				}},
+				Steps: []Step{{Run: "~/.docker/cli-plugins/docker-buildx bake"}},
 			},
 			expected: true,
 		},
 		{
-			name: "docker command in variable",
+			name: "buildctl build",
 			job: &Job{
-				Steps: []Step{{Run: "CMD='docker build'; $CMD"}},
+				Steps: []Step{{Run: "buildctl build --frontend dockerfile.v0 --local context=."}},
 			},
 			expected: true,
 		},
+		{
+			name: "docker builderx typo",
+			job: &Job{
+				Steps: []Step{{Run: "docker builderx bake"}},
+			},
+			expected: false,
+		},
+		{
+			name: "podman without subcommand",
+			job: &Job{
+				Steps: []Step{{Run: "podman --version"}},
+			},
+			expected: false,
+		},
+		{
+			name: "podman inside another word",
+			job: &Job{
+				Steps: []Step{{Run: "mypodman-helper build"}},
+			},
+			expected: false,
+		},
 		{
 			name: "docker exec",
 			job: &Job{
@@ -297,6 +1177,153 @@ echo "Done"`,
 	}
 }
 
+func TestJob_DockerDependentActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []Step
+		actions []string
+		want    []string
+	}{
+		{
+			name:    "trivy with version",
+			steps:   []Step{{Uses: "actions/checkout@v4"}, {Uses: "aquasecurity/trivy-action@0.28.0"}},
+			actions: DefaultDockerDependentActions,
+			want:    []string{"aquasecurity/trivy-action"},
+		},
+		{
+			name:    "default set",
+			steps:   []Step{{Uses: "hadolint/hadolint-action@v3.1.0"}, {Uses: "github/super-linter@v5"}, {Uses: "super-linter/super-linter/slim@v7"}, {Uses: "addnab/docker-run-action@v3"}},
+			actions: DefaultDockerDependentActions,
+			want:    []string{"hadolint/hadolint-action", "github/super-linter", "super-linter/super-linter/slim", "addnab/docker-run-action"},
+		},
+		{
+			name:    "local Kubernetes cluster actions",
+			steps:   []Step{{Uses: "helm/kind-action@v1"}, {Uses: "medyagh/setup-minikube@latest"}, {Uses: "AbsaOSS/k3d-action@v2"}},
+			actions: DefaultDockerDependentActions,
+			want:    []string{"helm/kind-action", "medyagh/setup-minikube", "AbsaOSS/k3d-action"},
+		},
+		{
+			name:    "repeated action is reported once",
+			steps:   []Step{{Uses: "aquasecurity/trivy-action@v1"}, {Uses: "aquasecurity/trivy-action@v1"}},
+			actions: DefaultDockerDependentActions,
+			want:    []string{"aquasecurity/trivy-action"},
+		},
+		{
+			name:    "similar name is not matched",
+			steps:   []Step{{Uses: "aquasecurity/trivy-action-extra@v1"}, {Uses: "aquasecurity/setup-trivy@v0.2.0"}},
+			actions: DefaultDockerDependentActions,
+			want:    nil,
+		},
+		{
+			name:    "case-insensitive",
+			steps:   []Step{{Uses: "AquaSecurity/Trivy-Action@master"}},
+			actions: DefaultDockerDependentActions,
+			want:    []string{"AquaSecurity/Trivy-Action"},
+		},
+		{
+			name:    "docker organization actions are container actions",
+			steps:   []Step{{Uses: "docker/scout-action@v1"}},
+			actions: append([]string{"docker/"}, DefaultDockerDependentActions...),
+			want:    nil,
+		},
+		{
+			name:    "custom action",
+			steps:   []Step{{Uses: "my-org/scan-image@v2"}},
+			actions: append([]string{"my-org/scan-image"}, DefaultDockerDependentActions...),
+			want:    []string{"my-org/scan-image"},
+		},
+		{
+			name:    "custom owner",
+			steps:   []Step{{Uses: "my-org/build@v1"}, {Uses: "my-org/lint/check@v1"}},
+			actions: []string{"my-org/"},
+			want:    []string{"my-org/build", "my-org/lint/check"},
+		},
+		{
+			name:    "no actions configured",
+			steps:   []Step{{Uses: "aquasecurity/trivy-action@0.28.0"}},
+			actions: nil,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Steps: tt.steps}
+			if got := job.DockerDependentActions(tt.actions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DockerDependentActions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_LocalClusterTools(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		want  []string
+	}{
+		{
+			name:  "kind create cluster",
+			steps: []Step{{Run: "kind create cluster --name ci --wait 60s"}},
+			want:  []string{"kind"},
+		},
+		{
+			name:  "minikube start with docker driver",
+			steps: []Step{{Run: "minikube start --driver=docker"}},
+			want:  []string{"minikube"},
+		},
+		{
+			name:  "k3d cluster create",
+			steps: []Step{{Run: "k3d cluster create test --agents 2"}},
+			want:  []string{"k3d"},
+		},
+		{
+			name:  "installed binary after a separator",
+			steps: []Step{{Run: "curl -Lo ./bin/kind https://kind.sigs.k8s.io/dl/v0.23.0/kind-linux-amd64 && ./bin/kind create cluster"}},
+			want:  []string{"kind"},
+		},
+		{
+			name:  "continuation line",
+			steps: []Step{{Run: "kind create \\\n  cluster --config kind.yaml"}},
+			want:  []string{"kind"},
+		},
+		{
+			name:  "several tools in order",
+			steps: []Step{{Run: "k3d cluster create a"}, {Run: "kind create cluster"}, {Run: "kind create cluster --name b"}},
+			want:  []string{"k3d", "kind"},
+		},
+		{
+			name:  "kindly is not kind",
+			steps: []Step{{Run: "kindly create cluster"}},
+		},
+		{
+			name:  "kind-of is not kind",
+			steps: []Step{{Run: "kind-of create cluster"}},
+		},
+		{
+			name:  "word in a message",
+			steps: []Step{{Run: "echo \"a kind create cluster step\""}},
+		},
+		{
+			name:  "other subcommands",
+			steps: []Step{{Run: "kind get clusters\nminikube status\nk3d cluster list"}},
+		},
+		{
+			name:  "skipped on Linux",
+			steps: []Step{{Run: "minikube start", If: "runner.os == 'macOS'"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Steps: tt.steps}
+			if got := job.LocalClusterTools(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocalClusterTools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJob_HasContainerActions_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -394,6 +1421,20 @@ func TestJob_HasContainerActions_EdgeCases(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "owner starting with docker but not docker/",
+			job: &Job{
+				Steps: []Step{{Uses: "dockerfoo/safe-action@v1"}},
+			},
+			expected: false,
+		},
+		{
+			name: "docker without separator",
+			job: &Job{
+				Steps: []Step{{Uses: "docker"}},
+			},
+			expected: false,
+		},
 		{
 			name: "action containing docker but not docker/",
 			job: &Job{
@@ -605,6 +1646,44 @@ func TestJob_HasContainer_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestJob_ReasonDetails(t *testing.T) {
+	job := &Job{
+		Steps: []Step{
+			{Uses: "actions/checkout@v4"},
+			{Run: "docker build -t app ."},
+			{Uses: "docker/build-push-action@v6"},
+			{Run: "echo hello"},
+			{Run: "podman run --rm alpine"},
+		},
+		Services: map[string]any{
+			"redis":    map[string]any{"image": "redis:7"},
+			"postgres": map[string]any{"image": "postgres:14"},
+		},
+		Container: map[string]any{"image": "node:20"},
+	}
+
+	if got, want := job.DockerCommandSteps(), []int{2, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DockerCommandSteps() = %v, want %v", got, want)
+	}
+	if got, want := job.ContainerActionSteps(), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ContainerActionSteps() = %v, want %v", got, want)
+	}
+	if got, want := job.ServiceNames(), []string{"postgres", "redis"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ServiceNames() = %v, want %v", got, want)
+	}
+	if got, want := job.ContainerImage(), "node:20"; got != want {
+		t.Errorf("ContainerImage() = %q, want %q", got, want)
+	}
+
+	short := &Job{Container: "alpine:3"}
+	if got, want := short.ContainerImage(), "alpine:3"; got != want {
+		t.Errorf("ContainerImage() short form = %q, want %q", got, want)
+	}
+	if got := (&Job{}).ContainerImage(); got != "" {
+		t.Errorf("ContainerImage() without container = %q, want empty", got)
+	}
+}
+
 func TestJob_HasPrivilegedOperations(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -840,6 +1919,86 @@ echo "Done"`,
 			wantDetected: true,
 			wantCmds:     []string{"iptables"},
 		},
+		{
+			name: "absolute path",
+			job: &Job{
+				Steps: []Step{{Run: "sudo /usr/sbin/modprobe overlay"}},
+			},
+			wantDetected: true,
+			wantCmds:     []string{"modprobe"},
+		},
+		{
+			name: "after command separators",
+			job: &Job{
+				Steps: []Step{{Run: "mkdir -p /mnt/data && sudo -E mount -o loop disk.img /mnt/data; if true; then umount /mnt/data; fi"}},
+			},
+			wantDetected: true,
+			wantCmds:     []string{"mount", "umount"},
+		},
+		{
+			name: "mount after a benign mountpoint check",
+			job: &Job{
+				Steps: []Step{{Run: "mountpoint -q /mnt || sudo mount /dev/sdb1 /mnt"}},
+			},
+			wantDetected: true,
+			wantCmds:     []string{"mount"},
+		},
+		{
+			name: "write to loop device",
+			job: &Job{
+				Steps: []Step{{Run: "dd if=disk.img of=/dev/loop0 bs=1M"}},
+			},
+			wantDetected: true,
+			wantCmds:     []string{"write to /dev/loop"},
+		},
+		{
+			name: "redirect to loop device",
+			job: &Job{
+				Steps: []Step{{Run: "cat disk.img > /dev/loop3"}},
+			},
+			wantDetected: true,
+			wantCmds:     []string{"write to /dev/loop"},
+		},
+		{
+			name: "false positive - mountpoint check",
+			job: &Job{
+				Steps: []Step{{Run: "mountpoint -q /mnt && echo mounted"}},
+			},
+			wantDetected: false,
+			wantCmds:     nil,
+		},
+		{
+			name: "false positive - mount as an argument",
+			job: &Job{
+				Steps: []Step{{Run: "git mount\nnpm run mount\nmake mount"}},
+			},
+			wantDetected: false,
+			wantCmds:     nil,
+		},
+		{
+			name: "false positive - mount in a quoted message",
+			job: &Job{
+				Steps: []Step{{Run: `echo "mount the volume first"`}},
+			},
+			wantDetected: false,
+			wantCmds:     nil,
+		},
+		{
+			name: "false positive - script named after a command",
+			job: &Job{
+				Steps: []Step{{Run: "./scripts/mount.sh && sysctl-report"}},
+			},
+			wantDetected: false,
+			wantCmds:     nil,
+		},
+		{
+			name: "false positive - reading a loop device",
+			job: &Job{
+				Steps: []Step{{Run: "ls -l /dev/loop*"}},
+			},
+			wantDetected: false,
+			wantCmds:     nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1092,27 +2251,67 @@ func TestJob_GetMissingCommands(t *testing.T) {
 					{Run: "VAR=value docker ps"},
 				},
 			},
-			expectedMissing: []string{"docker"},
+			expectedMissing: []string{"docker"},
+		},
+		{
+			name: "job with sudo",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "sudo docker ps"},
+				},
+			},
+			expectedMissing: []string{"docker"},
+		},
+		{
+			name: "job with pipe",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "docker ps | grep running"},
+				},
+			},
+			expectedMissing: []string{"docker"},
+		},
+		{
+			name: "job with lsb_release",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "echo \"codename=$(lsb_release -cs)\" >> \"$GITHUB_OUTPUT\""},
+				},
+			},
+			expectedMissing: []string{"lsb_release"},
+		},
+		{
+			name: "job with add-apt-repository",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "sudo add-apt-repository -y ppa:git-core/ppa"},
+				},
+			},
+			expectedMissing: []string{"add-apt-repository"},
 		},
 		{
-			name: "job with sudo",
+			name: "job with locale-gen",
 			job: &Job{
 				RunsOn: "ubuntu-latest",
 				Steps: []Step{
-					{Run: "sudo docker ps"},
+					{Run: "sudo locale-gen en_US.UTF-8"},
 				},
 			},
-			expectedMissing: []string{"docker"},
+			expectedMissing: []string{"locale-gen"},
 		},
 		{
-			name: "job with pipe",
+			name: "job with dpkg-reconfigure",
 			job: &Job{
 				RunsOn: "ubuntu-latest",
 				Steps: []Step{
-					{Run: "docker ps | grep running"},
+					{Run: "sudo dpkg-reconfigure -f noninteractive tzdata"},
 				},
 			},
-			expectedMissing: []string{"docker"},
+			expectedMissing: []string{"dpkg-reconfigure"},
 		},
 		{
 			name: "job with command that exists in slim",
@@ -1316,6 +2515,71 @@ lsof -i :8080`},
 			},
 			expectedMissing: nil,
 		},
+		{
+			name: "commands installed with apt-get in an earlier step are not missing",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "sudo apt-get update && sudo apt-get install -y --no-install-recommends postgresql-client zip"},
+					{Run: "psql -c 'select 1'\nzip -r out.zip dist"},
+				},
+			},
+			expectedMissing: nil,
+		},
+		{
+			name: "commands installed with apt in the same step are not missing",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "sudo DEBIAN_FRONTEND=noninteractive apt install -y \\\n  lsof \\\n  net-tools\nlsof -i :8080\nnetstat -tlnp"},
+				},
+			},
+			expectedMissing: nil,
+		},
+		{
+			name: "commands used before they are installed are still missing",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "zip -r out.zip dist"},
+					{Run: "sudo apt-get install -y zip lsof"},
+					{Run: "lsof -i :8080"},
+				},
+			},
+			expectedMissing: []string{"zip"},
+		},
+		{
+			name: "other apt subcommands do not install commands",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: "sudo apt-get remove -y zip"},
+					{Run: "zip -r out.zip dist"},
+				},
+			},
+			expectedMissing: []string{"zip"},
+		},
+		{
+			name: "commands in bash -c and substitutions are detected",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: `bash -c "zip -r 'my archive.zip' dist && lsof -i :8080"`},
+					{Run: `echo "pid: $(pgrep app), dump: ` + "`pg_dump db`" + `"`},
+				},
+			},
+			expectedMissing: []string{"zip", "lsof", "pg_dump"},
+		},
+		{
+			name: "single-quoted substitutions are not detected",
+			job: &Job{
+				RunsOn: "ubuntu-latest",
+				Steps: []Step{
+					{Run: `echo '$(zip -r out.zip dist)'`},
+				},
+			},
+			expectedMissing: nil,
+		},
 		{
 			name: "job with setup-beam should not report elixir/mix as missing",
 			job: &Job{
@@ -1358,6 +2622,53 @@ lsof -i :8080`},
 
 // TestJob_GetMissingCommands_RealWorkflows tests GetMissingCommands with actual workflow files
 // from .github/workflows directory. This ensures the function works correctly with real-world examples.
+func TestJob_GetMissingCommandsFor_AvailableCommands(t *testing.T) {
+	job := &Job{
+		RunsOn: "ubuntu-latest",
+		Steps: []Step{
+			{Run: "zip -r out.zip dist"},
+			{Run: "rsync -a src/ dst/"},
+			{Run: "lsof -i :8080"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		available []string
+		want      []string
+	}{
+		{
+			name:      "no available commands uses the built-in list",
+			available: nil,
+			want:      []string{"zip", "rsync", "lsof"},
+		},
+		{
+			name:      "available commands are not missing",
+			available: []string{"zip", "rsync"},
+			want:      []string{"lsof"},
+		},
+		{
+			name:      "paths are normalized to basenames",
+			available: []string{"/usr/bin/zip", " lsof "},
+			want:      []string{"rsync"},
+		},
+		{
+			name:      "commands not used by the job have no effect",
+			available: []string{"terraform", ""},
+			want:      []string{"zip", "rsync", "lsof"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := job.GetMissingCommandsFor([]string{"ubuntu-latest"}, tt.available)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetMissingCommandsFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJob_GetMissingCommands_RealWorkflows(t *testing.T) {
 	// Get the workspace root directory
 	// This test assumes it's run from the repository root
@@ -1449,3 +2760,421 @@ func findWorkspaceRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestJob_IfCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		ifValue       interface{}
+		wantDisabled  bool
+		wantCondition string
+	}{
+		{name: "no if", ifValue: nil},
+		{name: "bool false", ifValue: false, wantDisabled: true},
+		{name: "bool true", ifValue: true},
+		{name: "string false", ifValue: "false", wantDisabled: true},
+		{name: "expression false", ifValue: "${{ false }}", wantDisabled: true},
+		{name: "compact expression false", ifValue: "${{false}}", wantDisabled: true},
+		{name: "expression true", ifValue: "${{ true }}"},
+		{
+			name:          "event expression",
+			ifValue:       "${{ github.event_name == 'push' }}",
+			wantCondition: "${{ github.event_name == 'push' }}",
+		},
+		{
+			name:          "bare expression",
+			ifValue:       "github.ref == 'refs/heads/main'",
+			wantCondition: "github.ref == 'refs/heads/main'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{If: tt.ifValue}
+			if got := job.IsDisabled(); got != tt.wantDisabled {
+				t.Errorf("IsDisabled() = %v, want %v", got, tt.wantDisabled)
+			}
+			if got := job.Condition(); got != tt.wantCondition {
+				t.Errorf("Condition() = %q, want %q", got, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func TestJob_TimeoutMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout string // timeout-minutes value in YAML, omitted if empty
+		want    int
+		wantOK  bool
+	}{
+		{name: "not set"},
+		{name: "integer", timeout: "30", want: 30, wantOK: true},
+		{name: "quoted string", timeout: `"45"`, want: 45, wantOK: true},
+		{name: "fraction is rounded up", timeout: "2.5", want: 3, wantOK: true},
+		{name: "zero", timeout: "0"},
+		{name: "negative", timeout: "-5"},
+		{name: "expression", timeout: "${{ inputs.timeout }}"},
+		{name: "non-numeric string", timeout: "ten"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+			if tt.timeout != "" {
+				content += "    timeout-minutes: " + tt.timeout + "\n"
+			}
+			content += "    steps:\n      - run: make\n"
+			path := filepath.Join(t.TempDir(), "ci.yml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write workflow: %v", err)
+			}
+			wf, err := ParseFile(path)
+			if err != nil {
+				t.Fatalf("ParseFile() error: %v", err)
+			}
+			got, ok := wf.Jobs["build"].TimeoutMinutes()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("TimeoutMinutes() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestJob_CrossArchSetupSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       []Step
+		wantSteps   []int
+		wantActions []string
+	}{
+		{
+			name:  "no setup",
+			steps: []Step{{Uses: "actions/checkout@v4"}, {Uses: "docker/build-push-action@v6"}, {Run: "docker buildx build ."}},
+		},
+		{
+			name: "QEMU and Buildx",
+			steps: []Step{
+				{Uses: "actions/checkout@v4"},
+				{Uses: "docker/setup-qemu-action@v3"},
+				{Uses: "docker/setup-buildx-action@v3"},
+				{Uses: "docker/setup-qemu-action@v2"},
+			},
+			wantSteps:   []int{2, 3, 4},
+			wantActions: []string{"docker/setup-qemu-action", "docker/setup-buildx-action"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Steps: tt.steps}
+			steps, actions := job.CrossArchSetupSteps()
+			if !reflect.DeepEqual(steps, tt.wantSteps) || !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("CrossArchSetupSteps() = %v, %v, want %v, %v", steps, actions, tt.wantSteps, tt.wantActions)
+			}
+		})
+	}
+}
+
+func TestJob_SetupActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		want  []string
+	}{
+		{
+			name:  "no setup actions",
+			steps: []Step{{Uses: "actions/checkout@v4"}, {Run: "make test"}},
+			want:  nil,
+		},
+		{
+			name: "official setup actions",
+			steps: []Step{
+				{Uses: "actions/checkout@v4"},
+				{Uses: "actions/setup-node@v4"},
+				{Uses: "actions/setup-python@v5"},
+			},
+			want: []string{"actions/setup-node", "actions/setup-python"},
+		},
+		{
+			name:  "duplicate with different versions",
+			steps: []Step{{Uses: "actions/setup-go@v5"}, {Uses: "actions/setup-go@v4"}},
+			want:  []string{"actions/setup-go"},
+		},
+		{
+			name:  "third-party setup action",
+			steps: []Step{{Uses: "hashicorp/setup-terraform@v3"}},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Steps: tt.steps}
+			if got := job.SetupActions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetupActions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_DockerLayerCacheSteps(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		want  []int
+	}{
+		{
+			name: "buildx cache",
+			steps: []Step{
+				{Uses: "actions/checkout@v4"},
+				{Uses: "actions/cache@v4", With: map[string]any{"path": "/tmp/.buildx-cache", "key": "buildx-${{ github.sha }}"}},
+			},
+			want: []int{2},
+		},
+		{
+			name: "one of several paths",
+			steps: []Step{
+				{Uses: "actions/cache/restore@v4", With: map[string]any{"path": "~/.npm\n/var/lib/docker\n"}},
+			},
+			want: []int{1},
+		},
+		{
+			name:  "other cache paths",
+			steps: []Step{{Uses: "actions/cache@v4", With: map[string]any{"path": "~/.cache/go-build"}}},
+			want:  nil,
+		},
+		{
+			name:  "cache without path",
+			steps: []Step{{Uses: "actions/cache@v4"}},
+			want:  nil,
+		},
+		{
+			name:  "path input of another action",
+			steps: []Step{{Uses: "actions/upload-artifact@v4", With: map[string]any{"path": "/tmp/.buildx-cache"}}},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Steps: tt.steps}
+			if got := job.DockerLayerCacheSteps(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DockerLayerCacheSteps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_OutdatedActions(t *testing.T) {
+	tests := []struct {
+		name        string
+		uses        []string
+		minVersions map[string]int
+		want        []string
+	}{
+		{
+			name:        "v1 and v2 are outdated",
+			uses:        []string{"actions/checkout@v1", "actions/checkout@v2"},
+			minVersions: DefaultMinActionVersions,
+			want:        []string{"actions/checkout@v1", "actions/checkout@v2"},
+		},
+		{
+			name:        "v3 and v4 are recent enough",
+			uses:        []string{"actions/checkout@v3", "actions/checkout@v4"},
+			minVersions: DefaultMinActionVersions,
+			want:        nil,
+		},
+		{
+			name:        "full version",
+			uses:        []string{"actions/checkout@v2.1.0", "actions/checkout@v4.2.2"},
+			minVersions: DefaultMinActionVersions,
+			want:        []string{"actions/checkout@v2.1.0"},
+		},
+		{
+			name:        "branch and sha refs are ignored",
+			uses:        []string{"actions/checkout@main", "actions/checkout@ee0669bd1cc54295c223e0bb666b733df41de1c5", "actions/checkout"},
+			minVersions: DefaultMinActionVersions,
+			want:        nil,
+		},
+		{
+			name:        "custom threshold",
+			uses:        []string{"actions/checkout@v3", "actions/checkout@v4", "actions/setup-node@v3"},
+			minVersions: map[string]int{"actions/checkout": 4, "actions/setup-node": 4},
+			want:        []string{"actions/checkout@v3", "actions/setup-node@v3"},
+		},
+		{
+			name:        "actions without a minimum are ignored",
+			uses:        []string{"actions/cache@v1"},
+			minVersions: DefaultMinActionVersions,
+			want:        nil,
+		},
+		{
+			name:        "reported once",
+			uses:        []string{"actions/checkout@v2", "actions/checkout@v2"},
+			minVersions: DefaultMinActionVersions,
+			want:        []string{"actions/checkout@v2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{}
+			for _, uses := range tt.uses {
+				job.Steps = append(job.Steps, Step{Uses: uses})
+			}
+			if got := job.OutdatedActions(tt.minVersions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OutdatedActions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMinActionVersion(t *testing.T) {
+	action, major, err := ParseMinActionVersion("Actions/Checkout@v4")
+	if err != nil || action != "actions/checkout" || major != 4 {
+		t.Errorf("ParseMinActionVersion() = (%q, %d, %v), want (\"actions/checkout\", 4, nil)", action, major, err)
+	}
+	for _, s := range []string{"actions/checkout", "@v3", "actions/checkout@main"} {
+		if _, _, err := ParseMinActionVersion(s); err == nil {
+			t.Errorf("ParseMinActionVersion(%q) expected error", s)
+		}
+	}
+}
+
+func TestJob_LocalReusableWorkflowPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		uses     string
+		wantPath string
+		wantOK   bool
+	}{
+		{name: "local", uses: "./.github/workflows/build.yml", wantPath: ".github/workflows/build.yml", wantOK: true},
+		{name: "remote", uses: "octo-org/example-repo/.github/workflows/build.yml@v1", wantOK: false},
+		{name: "not a call", uses: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Uses: tt.uses}
+			if got := job.IsReusableWorkflowCall(); got != (tt.uses != "") {
+				t.Errorf("IsReusableWorkflowCall() = %v, want %v", got, tt.uses != "")
+			}
+			path, ok := job.LocalReusableWorkflowPath()
+			if ok != tt.wantOK || filepath.ToSlash(path) != tt.wantPath {
+				t.Errorf("LocalReusableWorkflowPath() = (%q, %v), want (%q, %v)", path, ok, tt.wantPath, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNestedScripts(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{name: "no nested scripts", line: "make test", want: nil},
+		{name: "bash -c", line: `bash -c "docker run x"`, want: []string{"docker run x"}},
+		{name: "combined flags and path", line: `/bin/sh -ec 'zip a b; lsof'`, want: []string{"zip a b; lsof"}},
+		{name: "escaped quotes", line: `bash -c "echo \"hi\" && zip a"`, want: []string{`echo "hi" && zip a`}},
+		{name: "command substitution", line: "echo $(git rev-parse HEAD)", want: []string{"git rev-parse HEAD"}},
+		{name: "nested parentheses", line: "x=$(cd $(dirname f) && pwd)", want: []string{"cd $(dirname f) && pwd"}},
+		{name: "backticks", line: "v=`zip -v`", want: []string{"zip -v"}},
+		{name: "arithmetic expansion", line: "n=$((1 + 2))", want: nil},
+		{name: "single-quoted substitution", line: `echo '$(zip a)'`, want: nil},
+		{name: "substitution in double quotes", line: `echo "it's $(zip -v)"`, want: []string{"zip -v"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nestedScripts(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nestedScripts(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_DockerCommandStepsWith_SafeSubcommands(t *testing.T) {
+	job := &Job{
+		Steps: []Step{
+			{Run: "docker ps"},
+			{Run: "echo $TOKEN | docker login -u me --password-stdin"},
+			{Run: "docker --context remote ps -a && docker run alpine"},
+			{Run: "docker version"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		safe []string
+		want []int
+	}{
+		{name: "none", want: []int{1, 2, 3}},
+		{name: "ps", safe: []string{"ps"}, want: []int{2, 3}},
+		{name: "ps and login", safe: []string{"ps", "login"}, want: []int{3}},
+		{name: "all", safe: dockerDaemonSubcommands, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := job.DockerCommandStepsWith(nil, tt.safe); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DockerCommandStepsWith(nil, %v) = %v, want %v", tt.safe, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{line: "make build && make test", want: []string{"make build", "make test"}},
+		{line: "cat file | jq . || true; echo done", want: []string{"cat file", "jq .", "true", "echo done"}},
+		{line: `echo "a > b | c" && zip -r out.zip .`, want: []string{`echo "a > b | c"`, "zip -r out.zip ."}},
+		{line: "jq 'select(.x > 1) | .name' data.json", want: []string{"jq 'select(.x > 1) | .name' data.json"}},
+		{line: `grep foo\|bar file`, want: []string{`grep foo\|bar file`}},
+		// Redirection targets are not commands
+		{line: "make build > build.log 2>&1", want: []string{"make build"}},
+		{line: "python3 -c 'print(1)' 1> out", want: []string{"python3 -c 'print(1)'"}},
+		{line: "sort < input.txt | uniq", want: []string{"sort", "uniq"}},
+		{line: `tee "out file.txt" >> "log > file" && rsync a b`, want: []string{`tee "out file.txt"`, "rsync a b"}},
+		{line: "cat <<'EOF'", want: []string{"cat"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitCommandLine(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestExtractCommands_Quoted(t *testing.T) {
+	tests := []struct {
+		script string
+		want   []string
+	}{
+		{script: `echo "building > log" && zip -r out.zip .`, want: []string{"echo", "zip"}},
+		{script: "jq '.items[] | select(.size > 10)' data.json", want: []string{"jq"}},
+		{script: "make build > rsync", want: []string{"make"}},
+		{script: `MSG="hello world" zip out.zip .`, want: []string{"zip"}},
+	}
+
+	for _, tt := range tests {
+		if got := extractCommands(tt.script); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("extractCommands(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestJob_DockerCommandSteps_QuotedOperators(t *testing.T) {
+	job := &Job{
+		Steps: []Step{
+			{Run: `echo "build > log" && docker build -t app .`},
+			{Run: "docker build . > build.log 2>&1"},
+			{Run: `echo 'docker | ps' > notes.txt`},
+		},
+	}
+	if got, want := job.DockerCommandSteps(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DockerCommandSteps() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+package workflow
+
+import (
+	"sort"
+	"strings"
+)
+
+// aptPackages maps commands that are not in missing_commands.yml, which
+// records the packages of commands missing on ubuntu-slim, to the Ubuntu apt
+// package that provides them, for commands whose package name differs from the
+// command name. Such commands can still be reported as missing through
+// .slimify.yml. Commands not listed anywhere are assumed to be provided by a
+// package of the same name (e.g. jq, zip, rsync).
+var aptPackages = map[string]string{
+	"cc":       "gcc",
+	"convert":  "imagemagick",
+	"gpg":      "gnupg",
+	"identify": "imagemagick",
+	"mogrify":  "imagemagick",
+	"pip":      "python3-pip",
+	"pip3":     "python3-pip",
+}
+
+// nonAptCommands lists commands not in missing_commands.yml that are not
+// installed through apt on Ubuntu, usually because they come from a vendor
+// installer or a setup action.
+var nonAptCommands = map[string]bool{
+	"aws":        true,
+	"az":         true,
+	"nvm":        true,
+	"sdkmanager": true,
+	"terraform":  true,
+}
+
+// AptPackageFor returns the apt package that provides cmd on Ubuntu.
+// It returns false for commands that cannot be installed with apt.
+func AptPackageFor(cmd string) (string, bool) {
+	cmd = normalizeCommand(cmd)
+	if cmd == "" || nonAptCommands[cmd] {
+		return "", false
+	}
+	if c, ok := slimMissingCommands[cmd]; ok {
+		return c.Package, c.Package != ""
+	}
+	if pkg, ok := aptPackages[cmd]; ok {
+		return pkg, true
+	}
+	return cmd, true
+}
+
+// AptPackagesFor returns the sorted, deduplicated apt packages that provide
+// commands, along with the commands that cannot be installed with apt.
+func AptPackagesFor(commands []string) (packages []string, unavailable []string) {
+	seen := make(map[string]bool)
+	for _, cmd := range commands {
+		pkg, ok := AptPackageFor(cmd)
+		if !ok {
+			unavailable = append(unavailable, cmd)
+			continue
+		}
+		if !seen[pkg] {
+			seen[pkg] = true
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+	return packages, unavailable
+}
+
+// aptOptionsWithValue are apt options whose value is the next argument.
+var aptOptionsWithValue = map[string]bool{
+	"-o":               true,
+	"--option":         true,
+	"-t":               true,
+	"--target-release": true,
+	"-c":               true,
+	"--config-file":    true,
+}
+
+// aptInstalledPackages returns the packages installed by apt-get install or
+// apt install commands on a shell line, e.g. "sudo apt-get install -y jq zip"
+// gives jq and zip. Options, version pins (jq=1.6*) and architecture
+// qualifiers (jq:amd64) are dropped; local .deb files and variables are skipped.
+func aptInstalledPackages(line string) []string {
+	var packages []string
+	for _, part := range splitCommandLine(line) {
+		fields := strings.Fields(part)
+		// Skip sudo and environment variable assignments before the command
+		for len(fields) > 0 && (fields[0] == "sudo" || strings.HasPrefix(fields[0], "-") || strings.Contains(fields[0], "=")) {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if cmd := normalizeCommand(fields[0]); cmd != "apt-get" && cmd != "apt" {
+			continue
+		}
+
+		// The first argument that isn't an option is the subcommand
+		var packageArgs []string
+		for i := 1; i < len(fields); i++ {
+			field := fields[i]
+			if aptOptionsWithValue[field] {
+				i++
+				continue
+			}
+			if strings.HasPrefix(field, "-") {
+				continue
+			}
+			if field == "install" {
+				packageArgs = fields[i+1:]
+			}
+			break
+		}
+
+		for i := 0; i < len(packageArgs); i++ {
+			field := packageArgs[i]
+			if aptOptionsWithValue[field] {
+				i++
+				continue
+			}
+			if strings.HasPrefix(field, "-") || strings.ContainsAny(field, "$/") {
+				continue
+			}
+			pkg, _, _ := strings.Cut(field, "=")
+			pkg, _, _ = strings.Cut(pkg, ":")
+			if pkg != "" {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	return packages
+}
+
+// commandsInstalledBy returns the commands an apt package provides: the
+// command of the same name, plus the commands mapped to it in
+// missing_commands.yml and aptPackages.
+func commandsInstalledBy(pkg string) []string {
+	commands := []string{pkg}
+	for cmd, c := range slimMissingCommands {
+		if c.Package == pkg && cmd != pkg {
+			commands = append(commands, cmd)
+		}
+	}
+	for cmd, p := range aptPackages {
+		if p == pkg {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAptPackageFor(t *testing.T) {
+	tests := []struct {
+		cmd     string
+		wantPkg string
+		wantOK  bool
+	}{
+		{cmd: "jq", wantPkg: "jq", wantOK: true},
+		{cmd: "zip", wantPkg: "zip", wantOK: true},
+		{cmd: "gpg", wantPkg: "gnupg", wantOK: true},
+		{cmd: "7z", wantPkg: "p7zip-full", wantOK: true},
+		{cmd: "/usr/bin/psql", wantPkg: "postgresql-client", wantOK: true},
+		{cmd: "lsb_release", wantPkg: "lsb-release", wantOK: true},
+		{cmd: "add-apt-repository", wantPkg: "software-properties-common", wantOK: true},
+		{cmd: "nvm", wantOK: false},
+		{cmd: "terraform", wantOK: false},
+		{cmd: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cmd, func(t *testing.T) {
+			pkg, ok := AptPackageFor(tt.cmd)
+			if pkg != tt.wantPkg || ok != tt.wantOK {
+				t.Errorf("AptPackageFor(%q) = (%q, %v), want (%q, %v)", tt.cmd, pkg, ok, tt.wantPkg, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAptPackagesFor(t *testing.T) {
+	packages, unavailable := AptPackagesFor([]string{"zip", "nslookup", "jq", "dig", "nvm"})
+
+	if want := []string{"dnsutils", "jq", "zip"}; !reflect.DeepEqual(packages, want) {
+		t.Errorf("AptPackagesFor() packages = %v, want %v", packages, want)
+	}
+	if want := []string{"nvm"}; !reflect.DeepEqual(unavailable, want) {
+		t.Errorf("AptPackagesFor() unavailable = %v, want %v", unavailable, want)
+	}
+}
+
+func TestAptInstalledPackages(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{line: "sudo apt-get install -y jq zip", want: []string{"jq", "zip"}},
+		{line: "apt install jq", want: []string{"jq"}},
+		{line: "sudo apt-get update && sudo apt-get install -qq postgresql-client", want: []string{"postgresql-client"}},
+		{line: "DEBIAN_FRONTEND=noninteractive sudo -E apt-get -y install jq", want: []string{"jq"}},
+		{line: "apt-get install -y -o Dpkg::Options::=--force-confold jq=1.6-2 zip:amd64", want: []string{"jq", "zip"}},
+		{line: "apt-get install -y ./tool.deb $EXTRA_PACKAGES", want: nil},
+		{line: "apt-get update", want: nil},
+		{line: "apt-get remove jq", want: nil},
+		{line: "echo apt-get install jq", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := aptInstalledPackages(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aptInstalledPackages(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"path"
+	"strings"
+)
+
+// posixShells are the shells whose run scripts are POSIX shell commands, which
+// command extraction assumes. An empty shell is the runner's default, bash.
+var posixShells = map[string]bool{
+	"":     true,
+	"bash": true,
+	"sh":   true,
+	"dash": true,
+	"zsh":  true,
+	"ksh":  true,
+}
+
+// commandLineShells are non-POSIX shells that still run each line of a script
+// as a command, so a line like "docker build ." runs Docker.
+var commandLineShells = map[string]bool{
+	"pwsh":       true,
+	"powershell": true,
+	"cmd":        true,
+}
+
+// StepShell returns the shell that runs a step's run script: its shell:, or
+// else the job's defaults.run.shell, which includes the workflow's.
+// An empty string means the runner's default, bash.
+func (j *Job) StepShell(step Step) string {
+	if step.Shell != "" {
+		return step.Shell
+	}
+	return j.Defaults.Run.Shell
+}
+
+// NonPOSIXShellSteps returns the 1-based indexes of run steps whose shell is
+// not a POSIX shell (e.g. pwsh or python). Their scripts are not checked for
+// missing commands or privileged operations, and only shells running commands
+// line by line, like pwsh, are checked for Docker commands.
+func (j *Job) NonPOSIXShellSteps() []int {
+	var steps []int
+	for i, step := range j.Steps {
+		if step.Run != "" && !isPOSIXShell(j.StepShell(step)) {
+			steps = append(steps, i+1)
+		}
+	}
+	return steps
+}
+
+// shellProgram returns the program of a shell: value, e.g. "bash" for
+// "bash --noprofile --norc -eo pipefail {0}" or "python" for "/usr/bin/python {0}".
+func shellProgram(shell string) string {
+	fields := strings.Fields(shell)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(path.Base(fields[0]))
+}
+
+// isPOSIXShell reports whether shell runs scripts as POSIX shell commands.
+func isPOSIXShell(shell string) bool {
+	return posixShells[shellProgram(shell)]
+}
+
+// runsCommandLines reports whether shell runs each line of a script as a
+// command, like POSIX shells, PowerShell and cmd do.
+func runsCommandLines(shell string) bool {
+	program := shellProgram(shell)
+	return posixShells[program] || commandLineShells[program]
+}
@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_DefaultsShell(t *testing.T) {
+	content := `on: push
+defaults:
+  run:
+    shell: pwsh
+jobs:
+  windows-style:
+    runs-on: ubuntu-latest
+    steps:
+      - run: Get-ChildItem
+      - run: zip -r out.zip .
+        shell: bash
+  own-default:
+    runs-on: ubuntu-latest
+    defaults:
+      run:
+        shell: python
+    steps:
+      - run: print("hello")
+`
+	wf, err := Parse(strings.NewReader(content), "ci.yml")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	tests := []struct {
+		job   string
+		step  int
+		want  string
+		posix bool
+	}{
+		{job: "windows-style", step: 0, want: "pwsh"},
+		{job: "windows-style", step: 1, want: "bash", posix: true},
+		{job: "own-default", step: 0, want: "python"},
+	}
+	for _, tt := range tests {
+		job := wf.Jobs[tt.job]
+		got := job.StepShell(job.Steps[tt.step])
+		if got != tt.want || isPOSIXShell(got) != tt.posix {
+			t.Errorf("%s step %d: StepShell() = %q (POSIX %v), want %q (POSIX %v)", tt.job, tt.step+1, got, isPOSIXShell(got), tt.want, tt.posix)
+		}
+	}
+}
+
+func TestJob_NonPOSIXShellSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         *Job
+		wantSteps   []int
+		wantDocker  []int
+		wantMissing []string
+		wantPriv    bool
+	}{
+		{
+			name: "bash by default",
+			job: &Job{RunsOn: "ubuntu-latest", Steps: []Step{
+				{Run: "docker build ."},
+				{Run: "zip -r out.zip ."},
+			}},
+			wantDocker:  []int{1},
+			wantMissing: []string{"docker", "zip"},
+		},
+		{
+			name: "pwsh default still runs docker",
+			job: &Job{RunsOn: "ubuntu-latest", Defaults: defaultShell("pwsh"), Steps: []Step{
+				{Run: "docker build ."},
+				{Run: "zip -r out.zip ."},
+			}},
+			wantSteps:  []int{1, 2},
+			wantDocker: []int{1},
+		},
+		{
+			name: "python scripts are not commands",
+			job: &Job{RunsOn: "ubuntu-latest", Steps: []Step{
+				{Run: "mount = 'docker build'\nprint(mount)", Shell: "python {0}"},
+			}},
+			wantSteps: []int{1},
+		},
+		{
+			name: "step overrides the default shell",
+			job: &Job{RunsOn: "ubuntu-latest", Defaults: defaultShell("pwsh"), Steps: []Step{
+				{Run: "sudo mount -t tmpfs none /mnt", Shell: "bash --noprofile --norc -eo pipefail {0}"},
+			}},
+			wantPriv: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.NonPOSIXShellSteps(); !reflect.DeepEqual(got, tt.wantSteps) {
+				t.Errorf("NonPOSIXShellSteps() = %v, want %v", got, tt.wantSteps)
+			}
+			if got := tt.job.DockerCommandSteps(); !reflect.DeepEqual(got, tt.wantDocker) {
+				t.Errorf("DockerCommandSteps() = %v, want %v", got, tt.wantDocker)
+			}
+			if got := tt.job.GetMissingCommands(); !reflect.DeepEqual(got, tt.wantMissing) {
+				t.Errorf("GetMissingCommands() = %v, want %v", got, tt.wantMissing)
+			}
+			if got, _ := tt.job.HasPrivilegedOperations(); got != tt.wantPriv {
+				t.Errorf("HasPrivilegedOperations() = %v, want %v", got, tt.wantPriv)
+			}
+		})
+	}
+}
+
+// defaultShell returns job defaults with the given defaults.run.shell
+func defaultShell(shell string) Defaults {
+	var d Defaults
+	d.Run.Shell = shell
+	return d
+}
@@ -1,9 +1,17 @@
 package workflow
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -12,9 +20,29 @@ import (
 // Workflow represents a GitHub Actions workflow file
 type Workflow struct {
 	Path string
+	Name string // Workflow name from the name: key, empty if not set
+	On   any    // Trigger events from the on: key, a string, list or mapping
+	// Env holds the workflow-level env: variables with scalar values, as
+	// written. Values may be expressions such as ${{ vars.RUNNER }}.
+	Env  map[string]string
 	Jobs map[string]*Job
 }
 
+// Triggers returns the names of the events that trigger the workflow.
+// Events of the list form are returned in order, and events of the mapping
+// form (on: {push: ..., pull_request: ...}) are sorted.
+func (w *Workflow) Triggers() []string {
+	if events, ok := w.On.(map[string]any); ok {
+		names := make([]string, 0, len(events))
+		for event := range events {
+			names = append(names, event)
+		}
+		sort.Strings(names)
+		return names
+	}
+	return stringLabels(w.On)
+}
+
 // Job represents a job in a GitHub Actions workflow
 type Job struct {
 	ID        string      // Job ID (the key in the jobs map)
@@ -23,154 +51,484 @@ type Job struct {
 	Steps     []Step      `yaml:"steps"`
 	Services  interface{} `yaml:"services"`
 	Container interface{} `yaml:"container"`
-	LineStart int         // Line number where the job starts
+	Strategy  interface{} `yaml:"strategy"`
+	If        interface{} `yaml:"if"`    // Job-level condition, a bool or an expression string
+	Uses      string      `yaml:"uses"`  // Reusable workflow called by the job, if any
+	Needs     interface{} `yaml:"needs"` // Jobs that must complete first, a job ID or a list of them
+	// Timeout is the job's timeout-minutes, a number or an expression string
+	Timeout interface{} `yaml:"timeout-minutes"`
+	// Defaults are the job's defaults:, with the workflow's defaults.run.shell
+	// filled in if the job doesn't set a shell
+	Defaults  Defaults `yaml:"defaults"`
+	LineStart int      // Line of the job's runs-on key, or of the job key if it has none
+	// Lines are the raw lines of the job block in the workflow file, including
+	// comments, which are lost when the YAML is decoded
+	Lines []string `yaml:"-"`
 }
 
 // Step represents a step in a job
 type Step struct {
-	Name string                 `yaml:"name"`
-	Uses string                 `yaml:"uses"`
-	Run  string                 `yaml:"run"`
-	With map[string]interface{} `yaml:"with"`
+	Name  string                 `yaml:"name"`
+	Uses  string                 `yaml:"uses"`
+	Run   string                 `yaml:"run"`
+	Shell string                 `yaml:"shell"` // Shell running Run, overriding defaults.run.shell
+	If    interface{}            `yaml:"if"`    // Step-level condition, a bool or an expression string
+	With  map[string]interface{} `yaml:"with"`  // Inputs of the action in Uses
 }
 
+// Defaults represents the defaults: key of a workflow or job
+type Defaults struct {
+	Run struct {
+		Shell string `yaml:"shell"`
+	} `yaml:"run"`
+}
+
+// DefaultWorkflowDir is the directory GitHub reads workflow files from
+const DefaultWorkflowDir = ".github/workflows"
+
+// DefaultSlimLabel is the runs-on label of GitHub's slim runners, which jobs
+// are migrated to unless another label is configured
+const DefaultSlimLabel = "ubuntu-slim"
+
 // LoadWorkflows loads all workflow files from .github/workflows directory
 func LoadWorkflows() ([]*Workflow, error) {
-	workflowDir := ".github/workflows"
+	return LoadWorkflowsFromDirs([]string{DefaultWorkflowDir})
+}
 
-	// Check if directory exists
-	if _, err := os.Stat(workflowDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("workflow directory not found: %s", workflowDir)
+// LoadWorkflowsFromDirs loads all .yml and .yaml workflow files found under dirs,
+// walking each directory recursively. This supports monorepos that keep
+// additional workflow roots under subprojects.
+// Directories that do not exist are skipped, but an error is returned if none of
+// them exist. A file reachable from more than one directory is loaded only once.
+func LoadWorkflowsFromDirs(dirs []string) ([]*Workflow, error) {
+	paths, err := FindWorkflowFiles(dirs)
+	if err != nil {
+		return nil, err
 	}
 
 	var workflows []*Workflow
+	for _, path := range paths {
+		wf, err := LoadWorkflow(path)
+		if err != nil {
+			// Log error but continue processing other files
+			fmt.Fprintf(os.Stderr, "Warning: failed to load %s: %v\n", path, err)
+			continue
+		}
+		workflows = append(workflows, wf)
+	}
 
-	err := filepath.Walk(workflowDir, func(path string, info os.FileInfo, err error) error {
+	return workflows, nil
+}
+
+// FindWorkflowFiles returns the paths of all .yml and .yaml files under dirs,
+// walking each directory recursively, without parsing them.
+// Directories that do not exist are skipped, but an error is returned if none of
+// them exist. Symbolic links to files and directories are followed. A file
+// reachable from more than one directory or through more than one link,
+// including paths differing only in case on a case-insensitive filesystem, is
+// returned only once, under the path it is first reached by.
+func FindWorkflowFiles(dirs []string) ([]string, error) {
+	var existingDirs []string
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			existingDirs = append(existingDirs, dir)
+		}
+	}
+
+	if len(existingDirs) == 0 {
+		return nil, fmt.Errorf("workflow directory not found: %s", strings.Join(dirs, ", "))
+	}
+
+	w := &workflowWalker{}
+	for _, workflowDir := range existingDirs {
+		if err := w.walk(workflowDir, nil); err != nil {
+			return w.paths, err
+		}
+	}
+
+	return w.paths, nil
+}
+
+// workflowWalker collects workflow files while walking directories.
+type workflowWalker struct {
+	paths []string
+	// files are the workflow files found so far, compared with os.SameFile
+	// rather than by path so that links and case differences are detected
+	files []os.FileInfo
+}
+
+// walk adds the workflow files under dir to w.paths. ancestors are the
+// directories being walked that contain dir, so that a link back to one of
+// them is skipped instead of looping forever.
+func (w *workflowWalker) walk(dir string, ancestors []os.FileInfo) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(ancestors, func(a os.FileInfo) bool { return os.SameFile(a, info) }) {
+		return nil
+	}
+	ancestors = append(ancestors, info)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		info, err := os.Stat(path)
 		if err != nil {
-			return err
+			// Other entries such as broken links to notes can't hold workflows
+			if !isWorkflowFile(path) {
+				slog.Debug("skipping entry that cannot be read", "path", path, "error", err)
+				continue
+			}
+			// Keep broken links to workflow files, so that loading them
+			// reports the error, and tell them apart by the link itself
+			if entry.Type()&os.ModeSymlink == 0 {
+				return err
+			}
+			if info, err = os.Lstat(path); err != nil {
+				return err
+			}
 		}
 
-		// Only process .yml and .yaml files
-		if !info.IsDir() && (strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")) {
-			wf, err := LoadWorkflow(path)
-			if err != nil {
-				// Log error but continue processing other files
-				fmt.Fprintf(os.Stderr, "Warning: failed to load %s: %v\n", path, err)
-				return nil
+		if info.IsDir() {
+			if err := w.walk(path, ancestors); err != nil {
+				return err
 			}
-			workflows = append(workflows, wf)
+			continue
+		}
+		if isWorkflowFile(path) {
+			w.add(path, info)
 		}
+	}
+	return nil
+}
 
-		return nil
-	})
+// add adds the workflow file at path unless it was already found.
+func (w *workflowWalker) add(path string, info os.FileInfo) {
+	if slices.ContainsFunc(w.files, func(f os.FileInfo) bool { return os.SameFile(f, info) }) {
+		return
+	}
+	w.files = append(w.files, info)
+	w.paths = append(w.paths, path)
+}
 
-	return workflows, err
+// isWorkflowFile reports whether path has a .yml or .yaml extension.
+func isWorkflowFile(path string) bool {
+	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")
 }
 
-// LoadWorkflow loads a single workflow file
+// LoadWorkflow loads a single workflow file. It is equivalent to ParseFile.
 func LoadWorkflow(path string) (*Workflow, error) {
+	return ParseFile(path)
+}
+
+// ParseError reports a workflow file that is not valid YAML.
+type ParseError struct {
+	Path string
+	Line int // 1-based line of the syntax error, or 0 if unknown
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		msg := yamlErrorLine.ReplaceAllString(yamlErrorMessage(e.Err), "")
+		return fmt.Sprintf("failed to parse YAML %s:%d: %s", e.Path, e.Line, msg)
+	}
+	return fmt.Sprintf("failed to parse YAML %s: %v", e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// yamlErrorLine matches the position yaml.v3 puts in its error messages,
+// e.g. "yaml: line 8: did not find expected node content".
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// newParseError wraps a yaml.v3 error, extracting the line it refers to.
+func newParseError(path string, err error) *ParseError {
+	perr := &ParseError{Path: path, Err: err}
+	if m := yamlErrorLine.FindStringSubmatch(yamlErrorMessage(err)); m != nil {
+		perr.Line, _ = strconv.Atoi(m[1])
+	}
+	return perr
+}
+
+// yamlErrorMessage returns the message of a yaml.v3 error. For type errors only
+// the first error is returned, since it is the one a user has to fix first.
+func yamlErrorMessage(err error) string {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		return typeErr.Errors[0]
+	}
+	return err.Error()
+}
+
+// ParseFile reads and parses a single workflow file into typed jobs.
+// Read errors include the path; YAML errors are returned as a *ParseError
+// carrying the path and, when known, the line of the syntax error.
+// YAML anchors, aliases and merge keys are resolved before jobs are decoded.
+// Jobs whose definition cannot be decoded are skipped.
+func ParseFile(path string) (*Workflow, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
 	}
+	return parse(data, path)
+}
 
-	var workflowData map[string]any
-	if err := yaml.Unmarshal(data, &workflowData); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML %s: %w", path, err)
+// Parse parses a single workflow read from r, like ParseFile but without
+// touching the filesystem. path is only used to label the workflow and its
+// errors (e.g. the path the content would have in the repository).
+func Parse(r io.Reader, path string) (*Workflow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
+	return parse(data, path)
+}
 
-	// Parse jobs
-	jobs := make(map[string]*Job)
-	if jobsData, ok := workflowData["jobs"].(map[string]any); ok {
-		// Convert file content to lines for line number detection
-		lines := strings.Split(string(data), "\n")
+// parse parses the content of the workflow at path. A file holding several
+// YAML documents separated by --- is read as one workflow with the jobs of
+// all documents; its name and triggers are the first ones set.
+func parse(data []byte, path string) (*Workflow, error) {
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		return nil, newParseError(path, err)
+	}
 
-		for jobID, jobData := range jobsData {
-			jobBytes, err := yaml.Marshal(jobData)
-			if err != nil {
-				continue
-			}
+	// Convert file content to lines for line number detection
+	lines := strings.Split(string(data), "\n")
 
-			var job Job
-			if err := yaml.Unmarshal(jobBytes, &job); err != nil {
+	wf := &Workflow{Path: path, Jobs: make(map[string]*Job)}
+	for i, doc := range docs {
+		var workflowData map[string]any
+		if err := doc.Decode(&workflowData); err != nil {
+			return nil, newParseError(path, err)
+		}
+		if wf.Name == "" {
+			wf.Name, _ = workflowData["name"].(string)
+		}
+		if wf.On == nil {
+			wf.On = workflowData["on"]
+		}
+		if wf.Env == nil {
+			wf.Env = envValues(workflowData["env"])
+		}
+
+		// A document's lines end where the next document starts
+		end := len(lines)
+		if i+1 < len(docs) {
+			end = min(docs[i+1].Line-1, end)
+		}
+		for jobID, job := range parseJobs(doc, workflowData, lines[:end], path) {
+			if _, ok := wf.Jobs[jobID]; ok {
+				slog.Warn("skipping job defined in an earlier YAML document", "path", path, "job", jobID, "line", job.LineStart)
 				continue
 			}
+			wf.Jobs[jobID] = job
+		}
+	}
+	return wf, nil
+}
 
-			job.ID = jobID
-			// If Name field is not specified in YAML, use the job ID as the display name
-			if job.Name == "" {
-				job.Name = jobID
-			}
-			// Find line number for this job's runs-on by searching in original file
-			job.LineStart = findRunsOnLineNumber(lines, jobID)
-			jobs[jobID] = &job
+// envValues returns the variables of an env: mapping whose values are
+// scalars, formatted as strings. Returns nil if env is not a mapping.
+func envValues(env any) map[string]string {
+	vars, ok := env.(map[string]any)
+	if !ok {
+		return nil
+	}
+	values := make(map[string]string, len(vars))
+	for name, value := range vars {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case int, float64, bool:
+			values[name] = fmt.Sprint(v)
 		}
 	}
+	return values
+}
 
-	return &Workflow{
-		Path: path,
-		Jobs: jobs,
-	}, nil
+// decodeDocuments decodes each YAML document in data into a node tree.
+func decodeDocuments(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return docs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
 }
 
-// findRunsOnLineNumber finds the line number of runs-on for a specific job by searching in file lines
-func findRunsOnLineNumber(lines []string, jobName string) int {
-	inJobsSection := false
-	inTargetJob := false
-	indentLevel := 0
+// parseJobs decodes the jobs of one YAML document of the workflow at path,
+// given as its node tree root and decoded as workflowData. lines are the lines
+// of the file up to the end of the document.
+func parseJobs(root *yaml.Node, workflowData map[string]any, lines []string, path string) map[string]*Job {
+	// Jobs inherit the workflow's default shell
+	var defaults Defaults
+	if defaultsBytes, err := yaml.Marshal(workflowData["defaults"]); err == nil {
+		_ = yaml.Unmarshal(defaultsBytes, &defaults)
+	}
 
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
+	jobs := make(map[string]*Job)
+	jobsData, ok := workflowData["jobs"].(map[string]any)
+	if !ok {
+		return jobs
+	}
 
-		// Check if we're in jobs section
-		if trimmed == "jobs:" {
-			inJobsSection = true
+	// The node tree locates each job's runs-on line and job block, whose raw
+	// lines keep the comments the decoded job doesn't have
+	blocks := jobBlocks(root, lines)
+	runsOnLines := jobRunsOnLines(root)
+
+	for jobID, jobData := range jobsData {
+		jobBytes, err := yaml.Marshal(jobData)
+		if err != nil {
+			slog.Debug("skipping job that cannot be decoded", "path", path, "job", jobID, "error", err)
 			continue
 		}
 
-		if !inJobsSection {
+		var job Job
+		if err := yaml.Unmarshal(jobBytes, &job); err != nil {
+			slog.Debug("skipping job that cannot be decoded", "path", path, "job", jobID, "error", err)
 			continue
 		}
 
-		// Calculate indentation level
-		lineIndent := 0
-		for _, char := range line {
-			switch char {
-			case ' ':
-				lineIndent++
-			case '\t':
-				lineIndent += 4 // Treat tab as 4 spaces
-			default:
-			}
+		job.ID = jobID
+		// If Name field is not specified in YAML, use the job ID as the display name
+		if job.Name == "" {
+			job.Name = jobID
 		}
+		if job.Defaults.Run.Shell == "" {
+			job.Defaults.Run.Shell = defaults.Run.Shell
+		}
+		job.LineStart = runsOnLines[jobID]
+		job.Lines = blocks[jobID]
+		jobs[jobID] = &job
+	}
+	return jobs
+}
 
-		// Check if we've left the jobs section (back to top level or another top-level key)
-		if inJobsSection && lineIndent == 0 && trimmed != "" && !strings.HasSuffix(trimmed, ":") {
+// jobBlocks returns the raw lines of each job block in a workflow, keyed by job ID.
+// A block runs from the job key to the line before the next job key. Comment
+// lines directly above a job key that are indented like it belong to that job,
+// not to the block before it, so a job can be annotated from either place.
+func jobBlocks(root *yaml.Node, lines []string) map[string][]string {
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	top := root.Content[0]
+
+	// The jobs mapping ends where the next top-level key starts
+	var jobsNode *yaml.Node
+	end := len(lines)
+	for i := 0; i+1 < len(top.Content); i += 2 {
+		if jobsNode != nil {
+			end = top.Content[i].Line - 1
 			break
 		}
+		if top.Content[i].Value == "jobs" {
+			jobsNode = top.Content[i+1]
+		}
+	}
+	if jobsNode == nil || jobsNode.Kind != yaml.MappingNode {
+		return nil
+	}
 
-		// Check if this is the target job name
-		if inJobsSection && strings.HasPrefix(trimmed, jobName+":") {
-			inTargetJob = true
-			indentLevel = lineIndent
+	isComment := func(line string, column int) bool {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		return trimmed == "" || (strings.HasPrefix(trimmed, "#") && indent <= column-1)
+	}
+
+	blocks := make(map[string][]string)
+	for i := 0; i+1 < len(jobsNode.Content); i += 2 {
+		key := jobsNode.Content[i]
+		if key.Line < 1 || key.Line > len(lines) {
 			continue
 		}
+		blockEnd := end
+		if i+2 < len(jobsNode.Content) {
+			blockEnd = jobsNode.Content[i+2].Line - 1
+		}
+		blockEnd = min(blockEnd, len(lines))
+		// Trailing blank lines and comments at the job key's indentation
+		// belong to the next job
+		for blockEnd > key.Line && isComment(lines[blockEnd-1], key.Column) {
+			blockEnd--
+		}
+		start := key.Line
+		for start > 1 && strings.HasPrefix(strings.TrimSpace(lines[start-2]), "#") && isComment(lines[start-2], key.Column) {
+			start--
+		}
+		blocks[key.Value] = lines[start-1 : blockEnd]
+	}
+	return blocks
+}
 
-		// If we're in the target job, look for runs-on
-		if inTargetJob {
-			// Check if we've left this job (back to same or lower indent level)
-			if lineIndent <= indentLevel && trimmed != "" && !strings.HasPrefix(trimmed, " ") {
-				break
-			}
+// jobRunsOnLines returns the line of each job's runs-on key, keyed by job ID.
+// Jobs without their own runs-on key (e.g. ones calling a reusable workflow,
+// or taking it from an alias or merge key) get the line of the job key.
+func jobRunsOnLines(root *yaml.Node) map[string]int {
+	if len(root.Content) == 0 {
+		return nil
+	}
+	jobsNode := mappingValue(root.Content[0], "jobs")
+	if jobsNode == nil || jobsNode.Kind != yaml.MappingNode {
+		return nil
+	}
 
-			// Look for runs-on line
-			if strings.Contains(trimmed, "runs-on:") {
-				return i + 1 // Line numbers are 1-based
-			}
+	lineNumbers := make(map[string]int)
+	for i := 0; i+1 < len(jobsNode.Content); i += 2 {
+		key := jobsNode.Content[i]
+		lineNumbers[key.Value] = key.Line
+		if runsOn := mappingKey(jobsNode.Content[i+1], "runs-on"); runsOn != nil {
+			lineNumbers[key.Value] = runsOn.Line
 		}
 	}
+	return lineNumbers
+}
 
-	return 0
+// findJobNode returns the node of job jobID in the workflow content data of
+// filePath, looking through every YAML document in it.
+func findJobNode(data []byte, filePath, jobID string) (*yaml.Node, error) {
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML %s: %w", filePath, err)
+	}
+	for _, doc := range docs {
+		if len(doc.Content) == 0 {
+			continue
+		}
+		if jobNode := mappingValue(mappingValue(doc.Content[0], "jobs"), jobID); jobNode != nil {
+			return jobNode, nil
+		}
+	}
+	return nil, fmt.Errorf("job %s not found in %s", jobID, filePath)
+}
+
+// mappingKey returns the key node for key in a YAML mapping node, or nil if
+// node is not a mapping or has no such key.
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
 }
 
 // UpdateRunsOn updates the runs-on value for a specific job in a workflow file
@@ -236,20 +594,13 @@ func UpdateRunsOn(filePath string, jobID string, newRunsOn string) error {
 			// Look for runs-on line and replace ubuntu-latest with new value
 			if strings.Contains(trimmed, "runs-on:") {
 				// Handle both "runs-on: ubuntu-latest" and "runs-on:ubuntu-latest" formats
-				if strings.Contains(trimmed, "ubuntu-latest") {
-					// Extract original indentation from the line (preserve exact whitespace)
-					originalIndent := ""
-					for j := 0; j < len(line); j++ {
-						char := line[j]
-						if char == ' ' || char == '\t' {
-							originalIndent += string(char)
-						} else {
-							break
-						}
-					}
-					// Replace the value while preserving original indentation and format
-					// Use the exact same format as the original line
-					lines[i] = originalIndent + "runs-on: " + newRunsOn
+				if replaced, ok := replaceRunsOnLabel(line, "ubuntu-latest", newRunsOn); ok {
+					lines[i] = replaced
+					updated = true
+					break
+				}
+				// Handle runs-on values spanning multiple lines (e.g. block sequences)
+				if replaceRunsOnBlockLabel(lines, i, "ubuntu-latest", newRunsOn) {
 					updated = true
 					break
 				}
@@ -269,3 +620,291 @@ func UpdateRunsOn(filePath string, jobID string, newRunsOn string) error {
 
 	return nil
 }
+
+// UpdateRunsOnAtLine replaces oldLabel with newLabel on the runs-on line at lineNumber (1-based).
+// If the runs-on value spans multiple lines (e.g. a block sequence), the label is
+// replaced in the lines below instead.
+// Only the label token itself is rewritten; indentation, quoting style, flow brackets and
+// trailing comments on the line are preserved byte-for-byte.
+// This is the preferred way to apply a scan result, since Candidate.LineNumber already
+// points at the job's runs-on line.
+func UpdateRunsOnAtLine(filePath string, lineNumber int, oldLabel, newLabel string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if lineNumber < 1 || lineNumber > len(lines) {
+		return fmt.Errorf("line %d is out of range in %s", lineNumber, filePath)
+	}
+
+	replaced, ok := replaceRunsOnLabel(lines[lineNumber-1], oldLabel, newLabel)
+	if ok {
+		lines[lineNumber-1] = replaced
+	} else if !strings.Contains(lines[lineNumber-1], "runs-on:") || !replaceRunsOnBlockLabel(lines, lineNumber-1, oldLabel, newLabel) {
+		return fmt.Errorf("line %d in %s is not a runs-on line with %s", lineNumber, filePath, oldLabel)
+	}
+
+	if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// UpdateRunsOnMatrix updates a job whose runs-on is a matrix expression like
+// ${{ matrix.os }} by rewriting the matrix values instead of runs-on itself.
+// Every value of the matrix key equal to one of oldLabels is replaced with newLabel,
+// in the key's value list as well as in include and exclude entries, so that
+// the matrix keeps expanding to the same combinations.
+// Only the label tokens are changed; formatting and comments are preserved.
+func UpdateRunsOnMatrix(filePath, jobID string, oldLabels []string, newLabel string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	jobNode, err := findJobNode(data, filePath, jobID)
+	if err != nil {
+		return err
+	}
+	runsOnNode := mappingValue(jobNode, "runs-on")
+	if runsOnNode == nil {
+		return fmt.Errorf("job %s in %s has no runs-on", jobID, filePath)
+	}
+	job := Job{RunsOn: runsOnNode.Value}
+	key, ok := job.RunsOnMatrixKey()
+	if !ok {
+		return fmt.Errorf("runs-on of job %s in %s is not a matrix expression", jobID, filePath)
+	}
+	matrixNode := mappingValue(mappingValue(jobNode, "strategy"), "matrix")
+	if matrixNode == nil {
+		return fmt.Errorf("job %s in %s has no strategy.matrix", jobID, filePath)
+	}
+
+	// Collect the scalar nodes holding values of the matrix key
+	var valueNodes []*yaml.Node
+	collect := func(n *yaml.Node) {
+		if n == nil {
+			return
+		}
+		if n.Kind == yaml.SequenceNode {
+			valueNodes = append(valueNodes, n.Content...)
+			return
+		}
+		valueNodes = append(valueNodes, n)
+	}
+	collect(mappingValue(matrixNode, key))
+	for _, section := range []string{"include", "exclude"} {
+		if entries := mappingValue(matrixNode, section); entries != nil && entries.Kind == yaml.SequenceNode {
+			for _, entry := range entries.Content {
+				collect(mappingValue(entry, key))
+			}
+		}
+	}
+
+	var targets []*yaml.Node
+	for _, n := range valueNodes {
+		if _, ok := MatchLabel(oldLabels, n.Value); n.Kind == yaml.ScalarNode && ok {
+			targets = append(targets, n)
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no matrix values of %s in job %s in %s match %s", key, jobID, filePath, strings.Join(oldLabels, ", "))
+	}
+
+	// Replace from the end so earlier columns on the same line stay valid
+	sort.Slice(targets, func(i, k int) bool {
+		if targets[i].Line != targets[k].Line {
+			return targets[i].Line > targets[k].Line
+		}
+		return targets[i].Column > targets[k].Column
+	})
+
+	lines := strings.Split(string(data), "\n")
+	for _, n := range targets {
+		if n.Line < 1 || n.Line > len(lines) {
+			return fmt.Errorf("line %d is out of range in %s", n.Line, filePath)
+		}
+		line := lines[n.Line-1]
+		start := n.Column - 1
+		if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			start++
+		}
+		if start < 0 || start+len(n.Value) > len(line) || line[start:start+len(n.Value)] != n.Value {
+			return fmt.Errorf("unexpected content at line %d in %s", n.Line, filePath)
+		}
+		lines[n.Line-1] = line[:start] + newLabel + line[start+len(n.Value):]
+	}
+
+	if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// PrependRunStep inserts a run step named name before the first step of a job.
+// The new step uses the same indentation as the existing steps, so the rest of
+// the file, including comments and formatting, is left untouched.
+// The job must have at least one step in block style.
+func PrependRunStep(filePath, jobID, name, run string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	jobNode, err := findJobNode(data, filePath, jobID)
+	if err != nil {
+		return err
+	}
+	stepsNode := mappingValue(jobNode, "steps")
+	if stepsNode == nil || stepsNode.Kind != yaml.SequenceNode || len(stepsNode.Content) == 0 {
+		return fmt.Errorf("job %s in %s has no steps", jobID, filePath)
+	}
+	if stepsNode.Style&yaml.FlowStyle != 0 {
+		return fmt.Errorf("steps of job %s in %s use flow style, which is not supported", jobID, filePath)
+	}
+
+	// The first step starts on the line with its "- " sequence indicator
+	lines := strings.Split(string(data), "\n")
+	first := stepsNode.Content[0]
+	if first.Line < 1 || first.Line > len(lines) {
+		return fmt.Errorf("line %d is out of range in %s", first.Line, filePath)
+	}
+	line := lines[first.Line-1]
+	dashIdx := strings.Index(line, "-")
+	if dashIdx < 0 || strings.TrimSpace(line[:dashIdx]) != "" {
+		return fmt.Errorf("unexpected step layout at line %d in %s", first.Line, filePath)
+	}
+	indent := line[:dashIdx]
+
+	step := []string{
+		indent + "- name: " + name,
+		indent + "  run: " + run,
+	}
+	lines = slices.Insert(lines, first.Line-1, step...)
+
+	if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or nil if
+// node is not a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceRunsOnLabel replaces oldLabel with newLabel in the value part of a runs-on line.
+// It returns the rewritten line and whether a replacement was made.
+// Text after a YAML comment marker is never touched.
+func replaceRunsOnLabel(line, oldLabel, newLabel string) (string, bool) {
+	keyIdx := strings.Index(line, "runs-on:")
+	if keyIdx < 0 {
+		return line, false
+	}
+	return replaceLabelAt(line, keyIdx+len("runs-on:"), oldLabel, newLabel)
+}
+
+// replaceLabelAt replaces oldLabel with newLabel in the part of line that starts
+// at valueStart and ends before a YAML comment marker.
+// It returns the rewritten line and whether a replacement was made.
+func replaceLabelAt(line string, valueStart int, oldLabel, newLabel string) (string, bool) {
+	value := line[valueStart:]
+	if commentIdx := strings.Index(value, "#"); commentIdx >= 0 {
+		value = value[:commentIdx]
+	}
+
+	oldLabel = NormalizeLabel(oldLabel)
+	labelIdx := indexLabel(value, oldLabel)
+	if labelIdx < 0 {
+		return line, false
+	}
+
+	start := valueStart + labelIdx
+	return line[:start] + newLabel + line[start+len(oldLabel):], true
+}
+
+// replaceRunsOnBlockLabel replaces oldLabel with newLabel in the block value of the
+// runs-on key at lines[keyLine], for runs-on values that span multiple lines:
+//
+//	runs-on:
+//	  - ubuntu-latest
+//
+// Block sequence entries, a plain scalar on the next line and the labels key of a
+// runs-on mapping are searched, in order. Only the first match is replaced.
+// It returns whether a replacement was made.
+func replaceRunsOnBlockLabel(lines []string, keyLine int, oldLabel, newLabel string) bool {
+	keyIndent := len(lines[keyLine]) - len(strings.TrimLeft(lines[keyLine], " \t"))
+	first := true
+	for i := keyLine + 1; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		// Block sequences may be indented at the same level as their key
+		if indent < keyIndent || (indent == keyIndent && !strings.HasPrefix(trimmed, "- ")) {
+			return false
+		}
+
+		valueStart := -1
+		switch {
+		case strings.HasPrefix(trimmed, "- "):
+			valueStart = indent + len("- ")
+		case strings.HasPrefix(trimmed, "labels:"):
+			valueStart = indent + len("labels:")
+		case first && !strings.Contains(trimmed, ":"):
+			valueStart = indent
+		}
+		first = false
+		if valueStart < 0 {
+			continue
+		}
+		if replaced, ok := replaceLabelAt(line, valueStart, oldLabel, newLabel); ok {
+			lines[i] = replaced
+			return true
+		}
+	}
+	return false
+}
+
+// indexLabel returns the index of the first occurrence of label in s that is not
+// part of a longer label (e.g. "ubuntu-latest" does not match "ubuntu-latest-arm").
+// Labels are compared case-insensitively, as GitHub does.
+// Returns -1 if no such occurrence exists.
+func indexLabel(s, label string) int {
+	label = NormalizeLabel(label)
+	if label == "" {
+		return -1
+	}
+	for start := 0; start+len(label) <= len(s); start++ {
+		end := start + len(label)
+		if !strings.EqualFold(s[start:end], label) {
+			continue
+		}
+		if (start == 0 || !isLabelChar(s[start-1])) && (end == len(s) || !isLabelChar(s[end])) {
+			return start
+		}
+	}
+	return -1
+}
+
+// isLabelChar reports whether c can be part of a runner label.
+func isLabelChar(c byte) bool {
+	return c == '-' || c == '_' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
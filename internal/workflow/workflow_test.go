@@ -1,8 +1,11 @@
 package workflow
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -148,6 +151,34 @@ func TestLoadWorkflow_LineNumbers(t *testing.T) {
 			wantLineNum:  9,
 			wantLineText: "    runs-on: ubuntu-22.04",
 		},
+		{
+			name:         "reusable workflow call without runs-on",
+			filename:     "reusable-call.yml",
+			jobName:      "build",
+			wantLineNum:  4,
+			wantLineText: "  build:",
+		},
+		{
+			name:         "runs-on text in a step before runs-on",
+			filename:     "identical-runs-on.yml",
+			jobName:      "build",
+			wantLineNum:  6,
+			wantLineText: "    runs-on: ubuntu-latest",
+		},
+		{
+			name:         "identical runs-on in earlier job",
+			filename:     "identical-runs-on.yml",
+			jobName:      "test",
+			wantLineNum:  8,
+			wantLineText: "    runs-on: ubuntu-latest",
+		},
+		{
+			name:         "identical runs-on after key named like another job",
+			filename:     "identical-runs-on.yml",
+			jobName:      "deploy",
+			wantLineNum:  14,
+			wantLineText: "    runs-on: ubuntu-latest",
+		},
 	}
 
 	for _, tt := range tests {
@@ -268,6 +299,147 @@ func TestLoadWorkflows_NoDirectory(t *testing.T) {
 	}
 }
 
+func TestLoadWorkflowsFromDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	content := loadTestData(t, "valid.yml")
+	files := []string{
+		".github/workflows/ci.yml",
+		".github/workflows/nested/release.yaml",
+		"services/api/.github/workflows/api.yml",
+		"services/api/.github/workflows/README.md",
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", f, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		dirs      []string
+		wantPaths []string
+		wantErr   bool
+	}{
+		{
+			name:      "default directory walks nested directories",
+			dirs:      []string{DefaultWorkflowDir},
+			wantPaths: []string{".github/workflows/ci.yml", ".github/workflows/nested/release.yaml"},
+		},
+		{
+			name: "additional directory",
+			dirs: []string{DefaultWorkflowDir, "services/api/.github/workflows"},
+			wantPaths: []string{
+				".github/workflows/ci.yml",
+				".github/workflows/nested/release.yaml",
+				"services/api/.github/workflows/api.yml",
+			},
+		},
+		{
+			name:      "overlapping directories load files once",
+			dirs:      []string{DefaultWorkflowDir, ".github/workflows/nested"},
+			wantPaths: []string{".github/workflows/ci.yml", ".github/workflows/nested/release.yaml"},
+		},
+		{
+			name:      "missing directory is skipped",
+			dirs:      []string{"does-not-exist", "services/api/.github/workflows"},
+			wantPaths: []string{"services/api/.github/workflows/api.yml"},
+		},
+		{
+			name:    "no directory exists",
+			dirs:    []string{"does-not-exist"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := LoadWorkflowsFromDirs(tt.dirs)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("LoadWorkflowsFromDirs() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadWorkflowsFromDirs() error: %v", err)
+			}
+
+			var gotPaths []string
+			for _, wf := range loaded {
+				gotPaths = append(gotPaths, filepath.ToSlash(wf.Path))
+			}
+			if !reflect.DeepEqual(gotPaths, tt.wantPaths) {
+				t.Errorf("LoadWorkflowsFromDirs() paths = %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestFindWorkflowFiles_Symlinks(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	content := loadTestData(t, "valid.yml")
+	if err := os.MkdirAll("ci/workflows", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile("ci/workflows/ci.yml", []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+	if err := os.Mkdir(".github", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	links := map[string]string{
+		".github/workflows":     "../ci/workflows", // Symlinked workflows directory
+		"ci/workflows/copy.yml": "ci.yml",          // Second path to the same file
+		"ci/workflows/loop":     "..",              // Link back to a parent directory
+		"ci/workflows/gone.yml": "missing.yml",     // Broken link, reported when loaded
+		"ci/workflows/notes":    "missing-notes",   // Broken link to something else, skipped
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("Symbolic links not supported: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		dirs      []string
+		wantPaths []string
+	}{
+		{
+			name:      "symlinked workflows directory",
+			dirs:      []string{DefaultWorkflowDir},
+			wantPaths: []string{".github/workflows/ci.yml", ".github/workflows/gone.yml"},
+		},
+		{
+			name:      "link target is also scanned",
+			dirs:      []string{DefaultWorkflowDir, "ci/workflows"},
+			wantPaths: []string{".github/workflows/ci.yml", ".github/workflows/gone.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := FindWorkflowFiles(tt.dirs)
+			if err != nil {
+				t.Fatalf("FindWorkflowFiles() error: %v", err)
+			}
+			var gotPaths []string
+			for _, p := range paths {
+				gotPaths = append(gotPaths, filepath.ToSlash(p))
+			}
+			if !reflect.DeepEqual(gotPaths, tt.wantPaths) {
+				t.Errorf("FindWorkflowFiles() = %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
 func TestLoadWorkflows_InvalidFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	workflowDir := filepath.Join(tmpDir, ".github", "workflows")
@@ -591,12 +763,40 @@ func TestJob_IsUbuntuLatest(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "object with single label",
+			job: &Job{
+				RunsOn: map[string]interface{}{"group": "ubuntu-runners", "labels": "ubuntu-latest"},
+			},
+			expected: true,
+		},
+		{
+			name: "object with labels list",
+			job: &Job{
+				RunsOn: map[string]interface{}{"group": "ubuntu-runners", "labels": []interface{}{"self-hosted", "ubuntu-latest"}},
+			},
+			expected: false,
+		},
+		{
+			name: "object without ubuntu-latest",
+			job: &Job{
+				RunsOn: map[string]interface{}{"group": "ubuntu-runners", "labels": []interface{}{"ubuntu-22.04"}},
+			},
+			expected: false,
+		},
+		{
+			name: "object with group only",
+			job: &Job{
+				RunsOn: map[string]interface{}{"group": "ubuntu-runners"},
+			},
+			expected: false,
+		},
 		{
 			name: "array with mixed types",
 			job: &Job{
 				RunsOn: []interface{}{"ubuntu-22.04", "ubuntu-latest", 123},
 			},
-			expected: true,
+			expected: false,
 		},
 	}
 
@@ -680,6 +880,32 @@ func TestJob_HasDockerCommands(t *testing.T) {
 	}
 }
 
+// TestJob_HasDockerCommands_DockerLogin checks that docker login is detected
+// anywhere in multi-line scripts, however the token is passed to it.
+func TestJob_HasDockerCommands_DockerLogin(t *testing.T) {
+	wf, err := ParseFile(filepath.Join("testdata", "docker-login.yml"))
+	if err != nil {
+		t.Fatalf("ParseFile() error: %v", err)
+	}
+
+	want := map[string]bool{
+		"heredoc":      true,
+		"piped":        true,
+		"continuation": true,
+		"config":       true,
+		"text-only":    false,
+	}
+	for jobID, expected := range want {
+		job, ok := wf.Jobs[jobID]
+		if !ok {
+			t.Fatalf("Job %s not found", jobID)
+		}
+		if got := job.HasDockerCommands(); got != expected {
+			t.Errorf("Job %s HasDockerCommands() = %v, want %v", jobID, got, expected)
+		}
+	}
+}
+
 func TestJob_HasContainerActions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -795,3 +1021,889 @@ func TestJob_HasServices(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateRunsOnAtLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		lineNumber int
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "plain scalar",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: ubuntu-slim
+    steps:
+      - run: echo hello`,
+		},
+		{
+			name: "preserves quotes and trailing comment",
+			content: `jobs:
+  test:
+    runs-on: "ubuntu-latest" # keep me: ubuntu-latest
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: "ubuntu-slim" # keep me: ubuntu-latest
+    steps: []`,
+		},
+		{
+			name: "mixed case label",
+			content: `jobs:
+  test:
+    runs-on: Ubuntu-Latest
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: ubuntu-slim
+    steps: []`,
+		},
+		{
+			name: "quoted with a trailing space",
+			content: `jobs:
+  test:
+    runs-on: "ubuntu-latest "
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: "ubuntu-slim "
+    steps: []`,
+		},
+		{
+			name: "flow sequence",
+			content: `jobs:
+  test:
+    runs-on: [ubuntu-latest]`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: [ubuntu-slim]`,
+		},
+		{
+			name: "flow sequence with spaces and quotes",
+			content: `jobs:
+  test:
+    runs-on: [ 'ubuntu-latest' ] # single label
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: [ 'ubuntu-slim' ] # single label
+    steps: []`,
+		},
+		{
+			name: "block sequence",
+			content: `jobs:
+  test:
+    runs-on:
+      - ubuntu-latest
+    steps:
+      - run: echo ubuntu-latest`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on:
+      - ubuntu-slim
+    steps:
+      - run: echo ubuntu-latest`,
+		},
+		{
+			name: "block sequence at key indentation with comments",
+			content: `jobs:
+  test:
+    runs-on: # the runner
+    # only one label
+    - "ubuntu-latest" # keep: ubuntu-latest
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on: # the runner
+    # only one label
+    - "ubuntu-slim" # keep: ubuntu-latest
+    steps: []`,
+		},
+		{
+			name: "plain scalar on the next line",
+			content: `jobs:
+  test:
+    runs-on:
+      ubuntu-latest
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on:
+      ubuntu-slim
+    steps: []`,
+		},
+		{
+			name: "runner group labels",
+			content: `jobs:
+  test:
+    runs-on:
+      group: ubuntu-latest-runners
+      labels: [ubuntu-latest]
+    steps: []`,
+			lineNumber: 3,
+			want: `jobs:
+  test:
+    runs-on:
+      group: ubuntu-latest-runners
+      labels: [ubuntu-slim]
+    steps: []`,
+		},
+		{
+			name: "block sequence without the label",
+			content: `jobs:
+  test:
+    runs-on:
+      - macos-latest
+    steps:
+      - run: echo ubuntu-latest`,
+			lineNumber: 3,
+			wantErr:    true,
+		},
+		{
+			name: "block value does not extend into the next key",
+			content: `jobs:
+  test:
+    runs-on: macos-latest
+    steps:
+      - ubuntu-latest`,
+			lineNumber: 3,
+			wantErr:    true,
+		},
+		{
+			name: "only targets the given line",
+			content: `jobs:
+  a:
+    runs-on: ubuntu-latest
+  b:
+    runs-on: ubuntu-latest`,
+			lineNumber: 5,
+			want: `jobs:
+  a:
+    runs-on: ubuntu-latest
+  b:
+    runs-on: ubuntu-slim`,
+		},
+		{
+			name: "longer label is not rewritten",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-latest-arm`,
+			lineNumber: 3,
+			wantErr:    true,
+		},
+		{
+			name: "line is not runs-on",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-latest`,
+			lineNumber: 2,
+			wantErr:    true,
+		},
+		{
+			name: "line out of range",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-latest`,
+			lineNumber: 10,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			err := UpdateRunsOnAtLine(filePath, tt.lineNumber, "ubuntu-latest", "ubuntu-slim")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("UpdateRunsOnAtLine() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateRunsOnAtLine() unexpected error: %v", err)
+			}
+
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read updated file: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("UpdateRunsOnAtLine() content =\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRunsOnMatrix(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		labels  []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "block sequence with comments",
+			content: `jobs:
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os:
+          - ubuntu-latest # primary
+        go: ["1.22", "1.23"]
+    steps:
+      - run: echo ubuntu-latest`,
+			labels: []string{"ubuntu-latest"},
+			want: `jobs:
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os:
+          - ubuntu-slim # primary
+        go: ["1.22", "1.23"]
+    steps:
+      - run: echo ubuntu-latest`,
+		},
+		{
+			name: "flow sequence with several source labels on one line",
+			content: `jobs:
+  test:
+    runs-on: ${{matrix.os}}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, "ubuntu-24.04"]`,
+			labels: []string{"ubuntu-latest", "ubuntu-24.04"},
+			want: `jobs:
+  test:
+    runs-on: ${{matrix.os}}
+    strategy:
+      matrix:
+        os: [ubuntu-slim, "ubuntu-slim"]`,
+		},
+		{
+			name: "include and exclude entries",
+			content: `jobs:
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest]
+        node: [18, 20]
+        include:
+          - os: ubuntu-latest
+            node: 22
+        exclude:
+          - os: 'ubuntu-latest'
+            node: 18`,
+			labels: []string{"ubuntu-latest"},
+			want: `jobs:
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-slim]
+        node: [18, 20]
+        include:
+          - os: ubuntu-slim
+            node: 22
+        exclude:
+          - os: 'ubuntu-slim'
+            node: 18`,
+		},
+		{
+			name: "runs-on is not a matrix expression",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-latest`,
+			labels:  []string{"ubuntu-latest"},
+			wantErr: true,
+		},
+		{
+			name: "no matching matrix values",
+			content: `jobs:
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [windows-latest]`,
+			labels:  []string{"ubuntu-latest"},
+			wantErr: true,
+		},
+		{
+			name: "job not found",
+			content: `jobs:
+  other:
+    runs-on: ubuntu-latest`,
+			labels:  []string{"ubuntu-latest"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			err := UpdateRunsOnMatrix(filePath, "test", tt.labels, "ubuntu-slim")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("UpdateRunsOnMatrix() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateRunsOnMatrix() unexpected error: %v", err)
+			}
+
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read updated file: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("UpdateRunsOnMatrix() content =\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrependRunStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "indented steps",
+			content: `jobs:
+  test:
+    runs-on: ubuntu-slim
+    steps:
+      - uses: actions/checkout@v4
+      - run: zip -r out.zip .`,
+			want: `jobs:
+  test:
+    runs-on: ubuntu-slim
+    steps:
+      - name: Install tools
+        run: sudo apt-get install -y zip
+      - uses: actions/checkout@v4
+      - run: zip -r out.zip .`,
+		},
+		{
+			name: "non-indented steps with leading comment",
+			content: `jobs:
+  test:
+    steps:
+    # checkout
+    - uses: actions/checkout@v4
+    runs-on: ubuntu-slim`,
+			want: `jobs:
+  test:
+    steps:
+    # checkout
+    - name: Install tools
+      run: sudo apt-get install -y zip
+    - uses: actions/checkout@v4
+    runs-on: ubuntu-slim`,
+		},
+		{
+			name: "only the target job is changed",
+			content: `jobs:
+  other:
+    steps:
+      - run: echo other
+  test:
+    steps:
+        - run: echo test`,
+			want: `jobs:
+  other:
+    steps:
+      - run: echo other
+  test:
+    steps:
+        - name: Install tools
+          run: sudo apt-get install -y zip
+        - run: echo test`,
+		},
+		{
+			name: "flow style steps",
+			content: `jobs:
+  test:
+    steps: [{run: echo test}]`,
+			wantErr: true,
+		},
+		{
+			name: "no steps",
+			content: `jobs:
+  test:
+    uses: ./.github/workflows/reusable.yml`,
+			wantErr: true,
+		},
+		{
+			name: "job not found",
+			content: `jobs:
+  other:
+    steps:
+      - run: echo other`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			err := PrependRunStep(filePath, "test", "Install tools", "sudo apt-get install -y zip")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("PrependRunStep() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PrependRunStep() unexpected error: %v", err)
+			}
+
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read updated file: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("PrependRunStep() content =\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+		wantMsg  string
+	}{
+		{
+			name:     "unclosed flow sequence",
+			content:  loadTestData(t, "invalid.yml"),
+			wantLine: 9,
+			wantMsg:  "did not find expected node content",
+		},
+		{
+			// yaml.v3 reports the line of the enclosing mapping for this error
+			name:     "bad indentation",
+			content:  "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n   steps:\n      - run: echo hi\n",
+			wantLine: 2,
+			wantMsg:  "did not find expected key",
+		},
+		{
+			name:     "tab indentation",
+			content:  "on: push\njobs:\n\ttest:\n",
+			wantLine: 3,
+			wantMsg:  "found character that cannot start any token",
+		},
+		{
+			name:     "not a mapping",
+			content:  "- on: push\n",
+			wantLine: 1,
+			wantMsg:  "cannot unmarshal !!seq",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			_, err := ParseFile(filePath)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseFile() error = %v, want a *ParseError", err)
+			}
+			if perr.Path != filePath || perr.Line != tt.wantLine {
+				t.Errorf("ParseFile() error at %s:%d, want %s:%d", perr.Path, perr.Line, filePath, tt.wantLine)
+			}
+			prefix := fmt.Sprintf("failed to parse YAML %s:%d: ", filePath, tt.wantLine)
+			if msg := err.Error(); !strings.HasPrefix(msg, prefix) || !strings.Contains(msg, tt.wantMsg) {
+				t.Errorf("ParseFile() error = %q, want prefix %q containing %q", msg, prefix, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseFile_NotFound(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "missing.yml")
+	_, err := ParseFile(filePath)
+	if err == nil || !strings.Contains(err.Error(), filePath) {
+		t.Errorf("ParseFile() error = %v, want an error mentioning %s", err, filePath)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ParseFile() error = %v, want it to wrap os.ErrNotExist", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	content := loadTestData(t, "multiple-jobs.yml")
+	filePath := filepath.Join(t.TempDir(), "workflow.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	fromFile, err := ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+
+	wf, err := Parse(strings.NewReader(content), "ci.yml")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if wf.Path != "ci.yml" {
+		t.Errorf("Parse() Path = %q, want %q", wf.Path, "ci.yml")
+	}
+	// Parsing from a reader gives the same jobs as parsing the file
+	fromFile.Path = wf.Path
+	if !reflect.DeepEqual(wf, fromFile) {
+		t.Errorf("Parse() = %+v, want %+v", wf, fromFile)
+	}
+
+	var parseErr *ParseError
+	if _, err := Parse(strings.NewReader("jobs: ["), "ci.yml"); !errors.As(err, &parseErr) || parseErr.Path != "ci.yml" {
+		t.Errorf("Parse() error = %v, want a *ParseError for ci.yml", err)
+	}
+}
+
+func TestParseFile_MultipleDocuments(t *testing.T) {
+	content := `name: first
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+# trailing comment of the first document
+---
+name: second
+on: pull_request
+jobs:
+  test:
+    runs-on: ubuntu-22.04
+    steps:
+      - run: make test
+  lint:
+    runs-on: windows-latest
+`
+	filePath := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	wf, err := ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+	if wf.Name != "first" || !reflect.DeepEqual(wf.Triggers(), []string{"push"}) {
+		t.Errorf("ParseFile() name = %q, triggers = %v, want the first document's", wf.Name, wf.Triggers())
+	}
+	if len(wf.Jobs) != 2 {
+		t.Fatalf("ParseFile() got %d jobs, want 2 from both documents", len(wf.Jobs))
+	}
+
+	// A job ID defined again in a later document keeps its first definition
+	lint := wf.Jobs["lint"]
+	if got := lint.RunsOnLabels(); !reflect.DeepEqual(got, []string{"ubuntu-latest"}) {
+		t.Errorf("lint runs-on = %v, want [ubuntu-latest]", got)
+	}
+	if lint.LineStart != 5 {
+		t.Errorf("lint LineStart = %d, want 5", lint.LineStart)
+	}
+	if last := lint.Lines[len(lint.Lines)-1]; strings.TrimSpace(last) != "- run: make lint" {
+		t.Errorf("lint block ends with %q, want it to end before the next document", last)
+	}
+
+	// Lines of later documents count from the start of the file
+	test := wf.Jobs["test"]
+	if test == nil {
+		t.Fatal("ParseFile() dropped job test of the second document")
+	}
+	if test.LineStart != 14 {
+		t.Errorf("test LineStart = %d, want 14", test.LineStart)
+	}
+	if got := test.RunsOnLabels(); !reflect.DeepEqual(got, []string{"ubuntu-22.04"}) {
+		t.Errorf("test runs-on = %v, want [ubuntu-22.04]", got)
+	}
+	if test.Lines[0] != "  test:" || len(test.Lines) != 4 {
+		t.Errorf("test block = %q, want the 4 lines of the job", test.Lines)
+	}
+}
+
+func TestParseFile_NameAndTriggers(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantName     string
+		wantTriggers []string
+	}{
+		{
+			name:         "single event",
+			content:      "name: CI\non: push\njobs: {}\n",
+			wantName:     "CI",
+			wantTriggers: []string{"push"},
+		},
+		{
+			name:         "event list",
+			content:      "name: Lint\non: [push, pull_request]\njobs: {}\n",
+			wantName:     "Lint",
+			wantTriggers: []string{"push", "pull_request"},
+		},
+		{
+			name:         "event map is sorted",
+			content:      "on:\n  workflow_dispatch:\n  push:\n    branches: [main]\njobs: {}\n",
+			wantTriggers: []string{"push", "workflow_dispatch"},
+		},
+		{
+			name:    "no name or triggers",
+			content: "jobs: {}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			wf, err := ParseFile(filePath)
+			if err != nil {
+				t.Fatalf("ParseFile() unexpected error: %v", err)
+			}
+			if wf.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", wf.Name, tt.wantName)
+			}
+			if got := wf.Triggers(); !reflect.DeepEqual(got, tt.wantTriggers) {
+				t.Errorf("Triggers() = %v, want %v", got, tt.wantTriggers)
+			}
+		})
+	}
+}
+
+func TestJob_IgnoreJustification(t *testing.T) {
+	content := `on: push
+jobs:
+  # slimify-ignore: builds images with Docker
+  image:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+  db:
+    runs-on: ubuntu-latest
+    services:
+      postgres:
+        image: postgres # slimify-ignore: needs a database
+    steps:
+      - run: make test
+
+  # slimify-ignore:
+  empty:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+  none:
+    runs-on: ubuntu-latest
+    steps:
+      - run: docker build .
+# slimify-ignore: not in any job
+other: value
+`
+	filePath := filepath.Join(t.TempDir(), "workflow.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	wf, err := ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		jobID     string
+		want      string
+		wantLines int
+	}{
+		{jobID: "image", want: "builds images with Docker", wantLines: 5},
+		{jobID: "db", want: "needs a database", wantLines: 7},
+		{jobID: "empty", want: "", wantLines: 5},
+		{jobID: "none", want: "", wantLines: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jobID, func(t *testing.T) {
+			job := wf.Jobs[tt.jobID]
+			if got := job.IgnoreJustification(); got != tt.want {
+				t.Errorf("IgnoreJustification() = %q, want %q", got, tt.want)
+			}
+			if len(job.Lines) != tt.wantLines {
+				t.Errorf("Lines = %q, want %d lines", job.Lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+// TestLoadWorkflow_Anchors checks that YAML anchors and aliases are resolved,
+// so aliased jobs get the same values as the anchored one.
+func TestLoadWorkflow_Anchors(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "workflow.yml")
+	if err := os.WriteFile(filePath, []byte(loadTestData(t, "anchors.yml")), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	wf, err := LoadWorkflow(filePath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() unexpected error: %v", err)
+	}
+
+	wantRunsOn := map[string]any{"group": "default", "labels": "ubuntu-latest"}
+	wantCheckout := Step{Uses: "actions/checkout@v4"}
+	for _, jobID := range []string{"lint", "test", "e2e"} {
+		job, ok := wf.Jobs[jobID]
+		if !ok {
+			t.Fatalf("LoadWorkflow() missing job: %s", jobID)
+		}
+		if !reflect.DeepEqual(job.RunsOn, wantRunsOn) {
+			t.Errorf("job %s RunsOn = %#v, want %#v", jobID, job.RunsOn, wantRunsOn)
+		}
+		if _, ok := job.MatchRunsOn([]string{"ubuntu-latest"}); !ok {
+			t.Errorf("job %s MatchRunsOn(ubuntu-latest) = false, want true", jobID)
+		}
+		if len(job.Steps) != 2 || !reflect.DeepEqual(job.Steps[0], wantCheckout) {
+			t.Errorf("job %s Steps = %+v, want %+v first", jobID, job.Steps, wantCheckout)
+		}
+	}
+
+	for _, jobID := range []string{"test", "e2e"} {
+		if got, want := wf.Jobs[jobID].ServiceNames(), []string{"redis"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("job %s ServiceNames() = %v, want %v", jobID, got, want)
+		}
+	}
+}
+
+// TestUpdateRunsOnAtLine_Styles migrates every job of a fixture with one runs-on
+// style per job and compares the result byte-for-byte with a golden file.
+func TestUpdateRunsOnAtLine_Styles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "workflow.yml")
+	if err := os.WriteFile(filePath, []byte(loadTestData(t, "runs-on-styles.yml")), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	wf, err := LoadWorkflow(filePath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() unexpected error: %v", err)
+	}
+	for jobID, job := range wf.Jobs {
+		if _, ok := job.MatchRunsOn([]string{"ubuntu-latest"}); !ok {
+			t.Errorf("job %s does not run on ubuntu-latest", jobID)
+		}
+		if err := UpdateRunsOnAtLine(filePath, job.LineStart, "ubuntu-latest", "ubuntu-slim"); err != nil {
+			t.Errorf("UpdateRunsOnAtLine() for job %s unexpected error: %v", jobID, err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read updated file: %v", err)
+	}
+	if want := loadTestData(t, "runs-on-styles.golden.yml"); string(data) != want {
+		t.Errorf("UpdateRunsOnAtLine() content =\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestParse_Env(t *testing.T) {
+	content := `on: push
+env:
+  RUNNER: ubuntu-latest
+  NODE_VERSION: 20
+  DEBUG: false
+  DYNAMIC: ${{ vars.RUNNER }}
+  LIST: [a, b]
+jobs:
+  test:
+    runs-on: ${{ env.RUNNER }}
+`
+	wf, err := Parse(strings.NewReader(content), "ci.yml")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"RUNNER":       "ubuntu-latest",
+		"NODE_VERSION": "20",
+		"DEBUG":        "false",
+		"DYNAMIC":      "${{ vars.RUNNER }}",
+	}
+	if !reflect.DeepEqual(wf.Env, want) {
+		t.Errorf("Parse() Env = %v, want %v", wf.Env, want)
+	}
+}
+
+func TestParse_StepWith(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/cache@v4
+        with:
+          path: |
+            /tmp/.buildx-cache
+            ~/.npm
+          key: ${{ runner.os }}-buildx
+          enableCrossOsArchive: true
+          lookup-only: false
+`
+	wf, err := Parse(strings.NewReader(content), "ci.yml")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	steps := wf.Jobs["build"].Steps
+	if steps[0].With != nil {
+		t.Errorf("Parse() step 1 With = %v, want nil without with:", steps[0].With)
+	}
+	want := map[string]any{
+		"path":                 "/tmp/.buildx-cache\n~/.npm\n",
+		"key":                  "${{ runner.os }}-buildx",
+		"enableCrossOsArchive": true,
+		"lookup-only":          false,
+	}
+	if !reflect.DeepEqual(steps[1].With, want) {
+		t.Errorf("Parse() step 2 With = %v, want %v", steps[1].With, want)
+	}
+}